@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"apisrv/pkg/app"
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/newsportal"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-pg/pg/v10"
+	"github.com/namsral/flag"
+	"github.com/vmkteam/vfs"
+	vfsdb "github.com/vmkteam/vfs/db"
+)
+
+// Exit statuses of cli commands.
+const (
+	exitOK          = 0
+	exitError       = 1 // command failed
+	exitUsage       = 2 // unknown command or invalid arguments
+	exitUnsupported = 3 // command is not supported by config, e.g. disabled feature
+)
+
+var (
+	errUsage       = errors.New("usage")
+	errUnsupported = errors.New("not supported")
+)
+
+// cmdEnv is an environment shared by cli commands. It is created with the same config as the server.
+type cmdEnv struct {
+	embedlog.Logger
+	cfg    app.Config
+	meta   toml.MetaData
+	dbc    db.DB
+	dbconn *pg.DB
+}
+
+// command is a cli subcommand, e.g. "user create".
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, env cmdEnv, args []string) error
+}
+
+var commands = []command{
+	{name: "migrate", usage: "migrate up | down [steps] | status | baseline <version>", run: runMigrate},
+	{name: "user create", usage: "user create -login <login> [-email <email>] [-role admin|editor|author] [-password-stdin]", run: runUserCreate},
+	{name: "user reset-password", usage: "user reset-password -login <login> [-password-stdin]", run: runUserResetPassword},
+	{name: "user disable", usage: "user disable -login <login>", run: runUserDisable},
+	{name: "news reindex", usage: "news reindex [-concurrently]", run: runNewsReindex},
+	{name: "cache flush", usage: "cache flush", run: runCacheFlush},
+	{name: "vfs scan", usage: "vfs scan", run: runVFSScan},
+	{name: "config check", usage: "config check", run: runConfigCheck},
+	{name: "config print", usage: "config print", run: runConfigPrint},
+}
+
+// runCommand finds command by args, runs it and returns exit status for os.Exit.
+func runCommand(ctx context.Context, env cmdEnv, args []string) int {
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", strings.Join(args, " "))
+		printUsage(os.Stderr)
+		return exitUsage
+	}
+
+	err := cmd.run(ctx, env, rest)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		fmt.Fprintf(os.Stderr, "%v\nusage: %s\n", err, cmd.usage)
+		return exitUsage
+	case errors.Is(err, errUnsupported):
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		return exitUnsupported
+	default:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		return exitError
+	}
+}
+
+// findCommand returns command which name matches the beginning of args and the rest of args.
+func findCommand(args []string) (*command, []string) {
+	for i := range commands {
+		parts := strings.Fields(commands[i].name)
+		if len(args) >= len(parts) && strings.Join(args[:len(parts)], " ") == commands[i].name {
+			return &commands[i], args[len(parts):]
+		}
+	}
+
+	return nil, nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s\n", c.usage)
+	}
+}
+
+// newCmdFlagSet returns flag set for command args which does not exit on errors.
+func newCmdFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// usageErr returns errUsage with message.
+func usageErr(format string, v ...interface{}) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, v...))
+}
+
+// newsTables are tables of news and their links reindexed by "news reindex".
+var newsTables = []string{
+	db.Tables.News.Name,
+	db.Tables.NewsTag.Name,
+	db.Tables.NewsAuthor.Name,
+	db.Tables.NewsRevision.Name,
+	db.Tables.NewsTransition.Name,
+}
+
+// runNewsReindex rebuilds indexes of news tables and updates their planner statistics.
+// By default writes to a table are blocked while its indexes are rebuilt, -concurrently avoids it on Postgres 12+.
+func runNewsReindex(ctx context.Context, env cmdEnv, args []string) error {
+	fs := newCmdFlagSet("news reindex")
+	concurrently := fs.Bool("concurrently", false, "rebuild indexes without blocking writes")
+	if err := fs.Parse(args); err != nil {
+		return usageErr("%v", err)
+	} else if fs.NArg() > 0 {
+		return usageErr("unexpected arguments %v", fs.Args())
+	}
+
+	reindex := `REINDEX TABLE ?`
+	if *concurrently {
+		reindex = `REINDEX TABLE CONCURRENTLY ?`
+	}
+
+	for _, table := range newsTables {
+		start := time.Now()
+		if _, err := env.dbc.ExecContext(ctx, reindex, pg.Ident(table)); err != nil {
+			return fmt.Errorf("reindex %s: %w", table, err)
+		} else if _, err = env.dbc.ExecContext(ctx, `ANALYZE ?`, pg.Ident(table)); err != nil {
+			return fmt.Errorf("analyze %s: %w", table, err)
+		}
+
+		fmt.Printf("reindexed table=%s duration=%v\n", table, time.Since(start).Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+// runCacheFlush flushes public API cache of all running servers, they are notified via Postgres NOTIFY.
+func runCacheFlush(ctx context.Context, env cmdEnv, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	} else if env.cfg.Cache.TTL <= 0 {
+		return fmt.Errorf("%w: cache is disabled by Cache.TTL", errUnsupported)
+	}
+
+	if _, err := env.dbc.ExecContext(ctx, `NOTIFY ?`, pg.Ident(newsportal.CacheFlushChannel)); err != nil {
+		return err
+	}
+
+	fmt.Println("cache flush is sent to running servers")
+	return nil
+}
+
+// runVFSScan scans VFS folder and adds new files to vfsHashes for indexing.
+func runVFSScan(ctx context.Context, env cmdEnv, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	} else if !env.cfg.Server.EnableVFS {
+		return fmt.Errorf("%w: vfs is disabled by Server.EnableVFS", errUnsupported)
+	}
+
+	vf, err := vfs.New(env.cfg.VFS)
+	if err != nil {
+		return err
+	}
+
+	vfsRepo := vfsdb.NewVfsRepo(env.dbconn)
+	hi := vfs.NewHashIndexer(vfsdb.New(env.dbconn), &vfsRepo, vf, 1, 1)
+	r, err := hi.ScanFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("scanned=%d added=%d duration=%v\n", r.Scanned, r.Added, r.Duration)
+	return nil
+}
+
+// runConfigCheck checks config file and availability of services configured in it.
+// All problems are printed, command fails if any of them was found.
+func runConfigCheck(_ context.Context, env cmdEnv, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+
+	var problems []string
+	for _, k := range env.meta.Undecoded() {
+		problems = append(problems, fmt.Sprintf("unknown key %s", k))
+	}
+
+	if err := env.cfg.Validate(); err != nil {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+
+	if v, err := env.dbc.Version(); err != nil {
+		problems = append(problems, fmt.Sprintf("database: %v", err))
+	} else {
+		fmt.Printf("database: %s\n", v)
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("config is valid")
+		return nil
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+
+	return fmt.Errorf("found %d problem(s)", len(problems))
+}
+
+// runConfigPrint prints effective config with environment overrides applied and secrets redacted.
+func runConfigPrint(_ context.Context, env cmdEnv, args []string) error {
+	if len(args) > 0 {
+		return usageErr("unexpected arguments %v", args)
+	}
+
+	return env.cfg.Print(os.Stdout)
+}
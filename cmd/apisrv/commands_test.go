@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/newsportal"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/namsral/flag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantRest []string
+	}{
+		{name: "single word", args: []string{"migrate", "down", "2"}, wantName: "migrate", wantRest: []string{"down", "2"}},
+		{name: "two words", args: []string{"user", "create", "-login", "admin"}, wantName: "user create", wantRest: []string{"-login", "admin"}},
+		{name: "no args", args: []string{"config", "check"}, wantName: "config check", wantRest: []string{}},
+		{name: "flags", args: []string{"news", "reindex", "-concurrently"}, wantName: "news reindex", wantRest: []string{"-concurrently"}},
+		{name: "unknown subcommand", args: []string{"user", "delete"}},
+		{name: "prefix only", args: []string{"user"}},
+		{name: "empty", args: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, rest := findCommand(tt.args)
+			if tt.wantName == "" {
+				assert.Nil(t, cmd)
+				return
+			}
+
+			if assert.NotNil(t, cmd) {
+				assert.Equal(t, tt.wantName, cmd.name)
+			}
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	errFn := func(err error) func(context.Context, cmdEnv, []string) error {
+		return func(context.Context, cmdEnv, []string) error { return err }
+	}
+
+	saved := commands
+	commands = append([]command{
+		{name: "test ok", run: errFn(nil)},
+		{name: "test fail", run: errFn(errors.New("failed"))},
+		{name: "test usage", run: errFn(usageErr("invalid"))},
+		{name: "test help", run: errFn(flag.ErrHelp)},
+		{name: "test unsupported", run: errFn(fmt.Errorf("%w: disabled", errUnsupported))},
+	}, saved...)
+	defer func() { commands = saved }()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "success", args: []string{"test", "ok"}, want: exitOK},
+		{name: "failure", args: []string{"test", "fail"}, want: exitError},
+		{name: "invalid arguments", args: []string{"test", "usage"}, want: exitUsage},
+		{name: "help", args: []string{"test", "help"}, want: exitUsage},
+		{name: "unsupported", args: []string{"test", "unsupported"}, want: exitUnsupported},
+		{name: "unknown command", args: []string{"test", "unknown"}, want: exitUsage},
+		{name: "unexpected arguments", args: []string{"config", "print", "all"}, want: exitUsage},
+		{name: "disabled vfs", args: []string{"vfs", "scan"}, want: exitUnsupported},
+		{name: "disabled cache", args: []string{"cache", "flush"}, want: exitUnsupported},
+		{name: "unknown flag", args: []string{"news", "reindex", "-all"}, want: exitUsage},
+		{name: "unexpected reindex arguments", args: []string{"news", "reindex", "news"}, want: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, runCommand(context.Background(), cmdEnv{}, tt.args))
+		})
+	}
+}
+
+// testCmdEnv returns command environment with DB from DB_CONN.
+func testCmdEnv(t *testing.T) cmdEnv {
+	dbConn := os.Getenv("DB_CONN")
+	if dbConn == "" {
+		dbConn = "postgresql://localhost:5432/apisrv?sslmode=disable"
+	}
+
+	opts, err := pg.ParseURL(dbConn)
+	require.NoError(t, err)
+
+	dbconn := pg.Connect(opts)
+	t.Cleanup(func() { _ = dbconn.Close() })
+
+	return cmdEnv{dbc: db.New(dbconn), dbconn: dbconn}
+}
+
+func TestDB_NewsReindex(t *testing.T) {
+	env := testCmdEnv(t)
+
+	assert.Equal(t, exitOK, runCommand(context.Background(), env, []string{"news", "reindex"}))
+	assert.Equal(t, exitOK, runCommand(context.Background(), env, []string{"news", "reindex", "-concurrently"}))
+}
+
+func TestDB_CacheFlush(t *testing.T) {
+	ctx := context.Background()
+	env := testCmdEnv(t)
+	env.cfg.Cache.TTL = time.Minute
+
+	ln := env.dbconn.Listen(ctx, newsportal.CacheFlushChannel)
+	defer func() { _ = ln.Close() }()
+	// the first receive subscribes to the channel and times out as there are no notifications yet
+	_, _, err := ln.ReceiveTimeout(ctx, 100*time.Millisecond)
+	require.Error(t, err)
+
+	assert.Equal(t, exitOK, runCommand(ctx, env, []string{"cache", "flush"}))
+
+	channel, _, err := ln.ReceiveTimeout(ctx, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, newsportal.CacheFlushChannel, channel)
+}
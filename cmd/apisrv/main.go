@@ -1,20 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math/rand"
 	"os"
 	"os/signal"
+	"runtime"
 	"runtime/debug"
 	"syscall"
 	"time"
 
 	"apisrv/pkg/app"
 	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/migrate"
+	"apisrv/pkg/tracing"
 
-	"github.com/BurntSushi/toml"
 	"github.com/getsentry/sentry-go"
 	"github.com/go-pg/pg/v10"
 	"github.com/namsral/flag"
@@ -22,13 +27,15 @@ import (
 
 const appName = "newsportal"
 
+// buildTime is set by linker flags on build, e.g. -ldflags "-X main.buildTime=2024-07-23T18:55:10Z".
+var buildTime string
+
 var (
-	fs                 = flag.NewFlagSetWithEnvPrefix(os.Args[0], "NEWSPORTAL", 0)
+	fs                 = flag.NewFlagSetWithEnvPrefix(os.Args[0], app.EnvPrefix, 0)
 	flConfigPath       = fs.String("config", "./cfg/local.toml", "Path to config file")
 	flVerbose          = fs.Bool("verbose", false, "enable debug output")
 	flVerboseSql       = fs.Bool("verbose-sql", false, "enable all sql output")
 	flGenerateTSClient = fs.Bool("ts_client", false, "generate TypeScript vt rpc client and exit")
-	cfg                app.Config
 )
 
 func main() {
@@ -39,8 +46,15 @@ func main() {
 
 	version := appVersion()
 	log.Printf("starting %v version=%v", appName, version)
-	if _, err := toml.DecodeFile(*flConfigPath, &cfg); err != nil {
-		exitOnError(err)
+	cfg, meta, err := app.LoadConfig(*flConfigPath)
+	exitOnError(err)
+
+	// commands check config by themselves, e.g. config check prints all problems
+	isCommand := fs.NArg() > 0
+	if !isCommand {
+		exitOnError(cfg.Validate())
+	} else if cfg.Database == nil {
+		exitOnError(errors.New("config: Database section is required"))
 	}
 
 	// enable sentry
@@ -52,21 +66,54 @@ func main() {
 		}))
 	}
 
-	// check db connection
 	dbconn := pg.Connect(cfg.Database)
-	dbc := db.New(dbconn)
-	v, err := dbc.Version()
-	exitOnError(err)
-	log.Println(v)
+	replicas := db.NewReplicas(cfg.Database, cfg.Replicas)
+	dbc := db.New(dbconn).WithReplicas(replicas)
+	addQueryHook := func(hook pg.QueryHook) {
+		dbconn.AddQueryHook(hook)
+		replicas.AddQueryHook(hook)
+	}
 
 	// log all sql queries
 	if *flVerboseSql {
 		sqlLogger := log.New(os.Stdout, "Q", log.LstdFlags)
-		dbconn.AddQueryHook(db.NewQueryLogger(sqlLogger))
+		addQueryHook(db.NewQueryLogger(sqlLogger))
+	}
+
+	// run cli command and exit
+	if isCommand {
+		// commands log to stderr, so their output could be parsed by scripts
+		env := cmdEnv{Logger: newLogger(os.Stderr, cfg.Log), cfg: cfg, meta: meta, dbc: dbc.Primary(), dbconn: dbconn}
+		os.Exit(runCommand(context.Background(), env, fs.Args()))
+	}
+
+	// check db connection
+	v, err := dbc.Version()
+	exitOnError(err)
+	log.Println(v)
+
+	// apply pending migrations on start
+	if cfg.Migrate.OnStart {
+		migrator, err := migrate.New(dbc, newLogger(os.Stdout, cfg.Log).Component("migrate"))
+		exitOnError(err)
+		_, err = migrator.Up(context.Background())
+		exitOnError(err)
 	}
 
 	// create & run app
-	application := app.New(appName, *flVerbose, cfg, dbc, dbconn)
+	application := app.New(appName, *flVerbose, cfg, dbc, dbconn, app.BuildInfo{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		BuildTime: appBuildTime(),
+	})
+
+	// export spans of requests, rpc calls and sql queries, exporter is flushed on shutdown
+	stopTracing, err := tracing.New(context.Background(), cfg.Tracing, appName, version)
+	exitOnError(err)
+	application.Append(app.Hook{Name: "tracing", OnStop: stopTracing})
+	if cfg.Tracing.Enabled() {
+		addQueryHook(db.NewQueryTracer())
+	}
 
 	// enable vfs
 	if cfg.Server.EnableVFS {
@@ -82,26 +129,39 @@ func main() {
 		os.Exit(0)
 	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	// app is shut down gracefully on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// run app and send panic to sentry
+	// reload config on SIGHUP, only settings which are safe to change are applied
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
 	go func() {
-		defer func() {
-			if err := recover(); err != nil {
-				sentry.CurrentHub().Recover(err)
-				sentry.Flush(time.Second * 3)
-				panic(err)
+		for range hup {
+			newCfg, _, err := app.LoadConfig(*flConfigPath)
+			if err == nil {
+				err = newCfg.Validate()
+			}
+
+			if err != nil {
+				application.Error(ctx, "reload config", "err", err)
+				continue
 			}
-		}()
 
-		if err := application.Run(); err != nil {
-			application.Printf("shutting down http server err=%q", err)
+			application.Reload(newCfg)
 		}
 	}()
-	<-quit
-	application.Shutdown(5 * time.Second)
 
+	// run app and send panic to sentry
+	defer func() {
+		if err := recover(); err != nil {
+			sentry.CurrentHub().Recover(err)
+			sentry.Flush(time.Second * 3)
+			panic(err)
+		}
+	}()
+
+	exitOnError(application.Run(ctx))
 }
 
 // fixStdLog sets additional params to std logger (prefix D, filename & line).
@@ -116,6 +176,11 @@ func fixStdLog(verbose bool) {
 	}
 }
 
+// newLogger returns logger for commands and migrations which run before app is created.
+func newLogger(w io.Writer, cfg embedlog.Config) embedlog.Logger {
+	return embedlog.New(w, cfg.Format, embedlog.NewLevels(cfg, *flVerbose))
+}
+
 // exitOnError calls log.Fatal if err wasn't nil.
 func exitOnError(err error) {
 	if err != nil {
@@ -144,3 +209,23 @@ func appVersion() string {
 
 	return result
 }
+
+// appBuildTime returns build time from linker flags or commit time from VCS info.
+func appBuildTime() string {
+	if buildTime != "" {
+		return buildTime
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, v := range info.Settings {
+		if v.Key == "vcs.time" {
+			return v.Value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"apisrv/pkg/migrate"
+)
+
+// runMigrate applies, rolls back or shows embedded migrations.
+func runMigrate(ctx context.Context, env cmdEnv, args []string) error {
+	if len(args) == 0 {
+		return usageErr("subcommand is required")
+	}
+
+	m, err := migrate.New(env.dbc, env.Logger)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		ms, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrations("applied", ms)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return usageErr("invalid steps %q", args[1])
+			}
+			steps = n
+		}
+		ms, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		printMigrations("rolled back", ms)
+	case "baseline":
+		if len(args) < 2 {
+			return usageErr("version is required")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErr("invalid version %q", args[1])
+		}
+		ms, err := m.Baseline(ctx, version)
+		if err != nil {
+			return err
+		}
+		printMigrations("marked as applied", ms)
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range list {
+			at := "pending"
+			if s.AppliedAt != nil {
+				at = s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Up == "" {
+				at += " (unknown to this binary)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, at)
+		}
+		return w.Flush()
+	default:
+		return usageErr("unknown subcommand %q", args[0])
+	}
+
+	return nil
+}
+
+func printMigrations(action string, ms []migrate.Migration) {
+	if len(ms) == 0 {
+		fmt.Printf("nothing %s\n", action)
+	}
+	for _, m := range ms {
+		fmt.Printf("%s %04d_%s\n", action, m.Version, m.Name)
+	}
+}
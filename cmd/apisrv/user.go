@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/passwd"
+	"apisrv/pkg/workflow"
+)
+
+// runUserCreate creates enabled user with given password or with generated one.
+func runUserCreate(ctx context.Context, env cmdEnv, args []string) error {
+	fs := newCmdFlagSet("user create")
+	login := fs.String("login", "", "user login")
+	email := fs.String("email", "", "user email")
+	role := fs.String("role", string(workflow.RoleAdmin), "user role")
+	fromStdin := fs.Bool("password-stdin", false, "read password from stdin instead of generating it")
+	if err := fs.Parse(args); err != nil {
+		return usageErr("%v", err)
+	} else if *login == "" {
+		return usageErr("login is required")
+	} else if !workflow.IsValidRole(workflow.Role(*role)) {
+		return usageErr("unknown role %q", *role)
+	}
+
+	cr := db.NewCommonRepo(env.dbc)
+	if u, err := cr.OneUser(ctx, &db.UserSearch{Login: login}); err != nil {
+		return err
+	} else if u != nil {
+		return fmt.Errorf("user %q already exists", *login)
+	}
+
+	user := &db.User{Login: *login, Role: *role, StatusID: db.StatusEnabled}
+	if *email != "" {
+		if u, err := cr.UserByEmail(ctx, *email); err != nil {
+			return err
+		} else if u != nil {
+			return fmt.Errorf("email %q is used by user %q", *email, u.Login)
+		}
+		user.Email = email
+	}
+
+	password, err := userPassword(env, *login, *fromStdin)
+	if err != nil {
+		return err
+	}
+
+	if user.Password, err = passwd.New(env.cfg.Auth.Hasher).Hash(password); err != nil {
+		return err
+	}
+
+	if user, err = cr.AddUser(ctx, user); err != nil {
+		return err
+	}
+
+	fmt.Printf("user created id=%d login=%s role=%s\n", user.ID, user.Login, user.Role)
+	if !*fromStdin {
+		fmt.Printf("password: %s\n", password)
+	}
+
+	return nil
+}
+
+// runUserResetPassword sets new password for user and logs the user out.
+func runUserResetPassword(ctx context.Context, env cmdEnv, args []string) error {
+	fs := newCmdFlagSet("user reset-password")
+	login := fs.String("login", "", "user login")
+	fromStdin := fs.Bool("password-stdin", false, "read password from stdin instead of generating it")
+	if err := fs.Parse(args); err != nil {
+		return usageErr("%v", err)
+	} else if *login == "" {
+		return usageErr("login is required")
+	}
+
+	cr := db.NewCommonRepo(env.dbc)
+	user, err := userByLogin(ctx, cr, *login)
+	if err != nil {
+		return err
+	}
+
+	password, err := userPassword(env, *login, *fromStdin)
+	if err != nil {
+		return err
+	}
+
+	if user.Password, err = passwd.New(env.cfg.Auth.Hasher).Hash(password); err != nil {
+		return err
+	}
+	user.AuthKey = ""
+
+	if _, err = cr.UpdateUserPassword(ctx, user); err != nil {
+		return err
+	} else if err = cr.UseUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("password changed id=%d login=%s\n", user.ID, user.Login)
+	if !*fromStdin {
+		fmt.Printf("password: %s\n", password)
+	}
+
+	return nil
+}
+
+// runUserDisable disables user and logs the user out.
+func runUserDisable(ctx context.Context, env cmdEnv, args []string) error {
+	fs := newCmdFlagSet("user disable")
+	login := fs.String("login", "", "user login")
+	if err := fs.Parse(args); err != nil {
+		return usageErr("%v", err)
+	} else if *login == "" {
+		return usageErr("login is required")
+	}
+
+	cr := db.NewCommonRepo(env.dbc)
+	user, err := userByLogin(ctx, cr, *login)
+	if err != nil {
+		return err
+	}
+
+	user.StatusID, user.AuthKey = db.StatusDisabled, ""
+	if ok, err := cr.UpdateUserVersion(ctx, user); err != nil {
+		return err
+	} else if !ok {
+		return errors.New("user was changed concurrently, try again")
+	} else if err = cr.UseUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("user disabled id=%d login=%s\n", user.ID, user.Login)
+	return nil
+}
+
+// userByLogin returns not deleted user by login.
+func userByLogin(ctx context.Context, cr db.CommonRepo, login string) (*db.User, error) {
+	user, err := cr.OneUser(ctx, &db.UserSearch{Login: &login})
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, fmt.Errorf("user %q not found", login)
+	}
+
+	return user, nil
+}
+
+// userPassword reads password from the first line of stdin or generates random one.
+// Password from stdin must satisfy password policy.
+func userPassword(env cmdEnv, login string, fromStdin bool) (string, error) {
+	if err := env.cfg.Auth.Hasher.Validate(); err != nil {
+		return "", err
+	}
+
+	if !fromStdin {
+		b := make([]byte, 12)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(b), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if vv := env.cfg.Auth.Policy.Check(password, login); len(vv) > 0 {
+		codes := make([]string, 0, len(vv))
+		for _, v := range vv {
+			codes = append(codes, v.Code)
+		}
+		return "", fmt.Errorf("password violates policy: %s", strings.Join(codes, ", "))
+	}
+
+	return password, nil
+}
@@ -29,11 +29,14 @@ CREATE TABLE "statuses" (
 CREATE TABLE "users" (
 	"userId" SERIAL NOT NULL,
 	"login" varchar(64) NOT NULL,
-	"password" varchar(64) NOT NULL,
+	"password" varchar(255) NOT NULL,
 	"authKey" varchar(32),
 	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
 	"lastActivityAt" timestamp with time zone,
 	"statusId" int4 NOT NULL,
+	"email" varchar(255),
+	"role" varchar(16) NOT NULL DEFAULT 'author',
+	"version" int4 NOT NULL DEFAULT 1,
 	CONSTRAINT "users_pkey" PRIMARY KEY("userId")
 );
 
@@ -41,6 +44,27 @@ CREATE INDEX "IX_FK_users_statusId_users" ON "users" USING BTREE (
 	"statusId"
 );
 
+CREATE UNIQUE INDEX "IX_users_email" ON "users" USING BTREE (
+	lower("email")
+) WHERE "statusId" <> 3;
+
+
+CREATE TABLE "userTokens" (
+	"userTokenId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"type" varchar(16) NOT NULL,
+	"token" varchar(64) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone NOT NULL,
+	"usedAt" timestamp with time zone,
+	PRIMARY KEY("userTokenId"),
+	CONSTRAINT "userTokens_token_key" UNIQUE("token")
+);
+
+CREATE INDEX "IX_FK_userTokens_userId_userTokens" ON "userTokens" USING BTREE (
+	"userId"
+);
+
 
 CREATE TABLE "vfsFiles" (
 	"fileId" SERIAL NOT NULL,
@@ -109,32 +133,229 @@ CREATE INDEX "IX_vfsHashes_indexedAt" ON "vfsHashes" USING BTREE (
 CREATE TABLE "tags" (
 	"tagId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
 	"title" varchar(128) NOT NULL,
+	"alias" varchar(128) NOT NULL,
 	"statusId" int4 NOT NULL,
+	"version" int4 NOT NULL DEFAULT 1,
 	PRIMARY KEY("tagId")
 );
 
+CREATE UNIQUE INDEX "IX_tags_alias" ON "tags" USING BTREE (
+	"alias"
+) WHERE "statusId" <> 3;
+
+CREATE TABLE "tagMerges" (
+	"tagMergeId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"sourceTagId" int4 NOT NULL,
+	"sourceTitle" varchar(128) NOT NULL,
+	"targetTagId" int4 NOT NULL,
+	"userId" int4,
+	"mergedAt" timestamp with time zone NOT NULL DEFAULT now(),
+	PRIMARY KEY("tagMergeId"),
+	CONSTRAINT "tagMerges_sourceTagId_key" UNIQUE("sourceTagId")
+);
+
+CREATE INDEX "IX_FK_tagMerges_targetTagId" ON "tagMerges" USING BTREE (
+	"targetTagId"
+);
+
 CREATE TABLE "news" (
 	"newsId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
 	"title" varchar(255) NOT NULL,
 	"categoryId" int4 NOT NULL,
 	"foreword" varchar(1024) NOT NULL,
 	"content" text,
-	"tagIds" int4[] NOT NULL DEFAULT '{}',
 	"author" varchar(64) NOT NULL,
 	"publishedAt" timestamp with time zone NOT NULL,
+	"unpublishAt" timestamp with time zone,
 	"statusId" int4 NOT NULL,
+	"state" varchar(16) NOT NULL DEFAULT 'draft',
+	"version" int4 NOT NULL DEFAULT 1,
 	PRIMARY KEY("newsId")
 );
 
+CREATE INDEX "IX_news_state" ON "news" USING BTREE (
+	"state"
+);
+
+CREATE INDEX "IX_news_unpublishAt" ON "news" USING BTREE (
+	"unpublishAt"
+) WHERE "unpublishAt" IS NOT NULL;
+
+CREATE TABLE "newsTags" (
+	"newsId" int4 NOT NULL,
+	"tagId" int4 NOT NULL,
+	"position" int4 NOT NULL DEFAULT 0,
+	PRIMARY KEY("newsId","tagId")
+);
+
+CREATE INDEX "IX_FK_newsTags_tagId" ON "newsTags" USING BTREE (
+	"tagId"
+);
+
+CREATE TABLE "authors" (
+	"authorId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"name" varchar(128) NOT NULL,
+	"alias" varchar(128) NOT NULL,
+	"bio" text,
+	"avatarHash" varchar(40),
+	"statusId" int4 NOT NULL,
+	"version" int4 NOT NULL DEFAULT 1,
+	PRIMARY KEY("authorId")
+);
+
+CREATE UNIQUE INDEX "IX_authors_alias" ON "authors" USING BTREE (
+	"alias"
+) WHERE "statusId" <> 3;
+
+CREATE TABLE "newsAuthors" (
+	"newsId" int4 NOT NULL,
+	"authorId" int4 NOT NULL,
+	"position" int4 NOT NULL DEFAULT 0,
+	PRIMARY KEY("newsId","authorId")
+);
+
+CREATE INDEX "IX_FK_newsAuthors_authorId" ON "newsAuthors" USING BTREE (
+	"authorId"
+);
+
+CREATE TABLE "newsTransitions" (
+	"newsTransitionId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"newsId" int4 NOT NULL,
+	"userId" int4,
+	"apiTokenId" int4,
+	"fromState" varchar(16) NOT NULL,
+	"toState" varchar(16) NOT NULL,
+	"reason" text,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	PRIMARY KEY("newsTransitionId")
+);
+
+CREATE INDEX "IX_FK_newsTransitions_newsId" ON "newsTransitions" USING BTREE (
+	"newsId"
+);
+
+CREATE TABLE "newsRevisions" (
+	"newsRevisionId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"newsId" int4 NOT NULL,
+	"revision" int4 NOT NULL,
+	"userId" int4,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"title" varchar(255) NOT NULL,
+	"categoryId" int4 NOT NULL,
+	"foreword" varchar(1024) NOT NULL,
+	"content" text,
+	"tagIds" int4[] NOT NULL DEFAULT '{}',
+	"authorIds" int4[] NOT NULL DEFAULT '{}',
+	"author" varchar(64) NOT NULL,
+	"publishedAt" timestamp with time zone NOT NULL,
+	"unpublishAt" timestamp with time zone,
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("newsRevisionId"),
+	CONSTRAINT "newsRevisions_newsId_revision_key" UNIQUE("newsId","revision")
+);
+
+CREATE TABLE "apiTokens" (
+	"apiTokenId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"userId" int4 NOT NULL,
+	"title" varchar(128) NOT NULL,
+	"token" varchar(64) NOT NULL,
+	"prefix" varchar(8) NOT NULL,
+	"scopes" varchar(32)[] NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"expiresAt" timestamp with time zone,
+	"lastUsedAt" timestamp with time zone,
+	"statusId" int4 NOT NULL,
+	PRIMARY KEY("apiTokenId"),
+	CONSTRAINT "apiTokens_token_key" UNIQUE("token")
+);
+
+CREATE INDEX "IX_FK_apiTokens_userId_apiTokens" ON "apiTokens" USING BTREE (
+	"userId"
+);
+
+
+CREATE TABLE "auditLogs" (
+	"auditLogId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"userId" int4,
+	"apiTokenId" int4,
+	"namespace" varchar(32) NOT NULL,
+	"method" varchar(64) NOT NULL,
+	"entityType" varchar(32),
+	"entityId" int4,
+	"before" jsonb,
+	"after" jsonb,
+	"requestId" varchar(64),
+	"ip" varchar(64),
+	PRIMARY KEY("auditLogId")
+);
+
+CREATE INDEX "IX_auditLogs_createdAt" ON "auditLogs" USING BTREE (
+	"createdAt"
+);
+
+CREATE INDEX "IX_auditLogs_entity" ON "auditLogs" USING BTREE (
+	"entityType",
+	"entityId"
+);
+
+CREATE INDEX "IX_FK_auditLogs_userId_auditLogs" ON "auditLogs" USING BTREE (
+	"userId"
+);
+
+
+CREATE TABLE "trashItems" (
+	"trashItemId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"entityType" varchar(32) NOT NULL,
+	"entityId" int4 NOT NULL,
+	"title" varchar(255) NOT NULL,
+	"previousStatusId" int4 NOT NULL,
+	"deletedAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"userId" int4,
+	"apiTokenId" int4,
+	PRIMARY KEY("trashItemId")
+);
+
+CREATE INDEX "IX_trashItems_deletedAt" ON "trashItems" USING BTREE (
+	"deletedAt"
+);
+
+CREATE INDEX "IX_trashItems_entity" ON "trashItems" USING BTREE (
+	"entityType",
+	"entityId"
+);
+
+
 CREATE TABLE "categories" (
 	"categoryId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
 	"title" varchar(255) NOT NULL,
+	"parentCategoryId" int4,
 	"orderNumber" int4,
 	"alias" varchar(255) NOT NULL,
 	"statusId" int4 NOT NULL,
-	PRIMARY KEY("categoryId")
+	"version" int4 NOT NULL DEFAULT 1,
+	PRIMARY KEY("categoryId"),
+	CONSTRAINT "categories_parentCategoryId_check" CHECK ("parentCategoryId" <> "categoryId")
 );
 
+CREATE INDEX "IX_FK_categories_parentCategoryId" ON "categories" USING BTREE (
+	"parentCategoryId"
+);
+
+
+ALTER TABLE "apiTokens" ADD CONSTRAINT "Ref_apiTokens_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "apiTokens" ADD CONSTRAINT "Ref_apiTokens_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE RESTRICT
+	NOT DEFERRABLE;
 
 ALTER TABLE "users" ADD CONSTRAINT "FK_users_statusId" FOREIGN KEY ("statusId")
 	REFERENCES "statuses"("statusId")
@@ -143,6 +364,13 @@ ALTER TABLE "users" ADD CONSTRAINT "FK_users_statusId" FOREIGN KEY ("statusId")
 	ON UPDATE RESTRICT
 	NOT DEFERRABLE;
 
+ALTER TABLE "userTokens" ADD CONSTRAINT "Ref_userTokens_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
 ALTER TABLE "vfsFiles" ADD CONSTRAINT "vfsFiles_folderId_fkey" FOREIGN KEY ("folderId")
 	REFERENCES "vfsFolders"("folderId")
 	MATCH SIMPLE
@@ -178,6 +406,20 @@ ALTER TABLE "tags" ADD CONSTRAINT "Ref_tags_to_statuses" FOREIGN KEY ("statusId"
 	ON UPDATE NO ACTION
 	NOT DEFERRABLE;
 
+ALTER TABLE "tagMerges" ADD CONSTRAINT "Ref_tagMerges_to_tags" FOREIGN KEY ("targetTagId")
+	REFERENCES "tags"("tagId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "tagMerges" ADD CONSTRAINT "Ref_tagMerges_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
 ALTER TABLE "news" ADD CONSTRAINT "Ref_news_to_statuses" FOREIGN KEY ("statusId")
 	REFERENCES "statuses"("statusId")
 	MATCH SIMPLE
@@ -192,6 +434,104 @@ ALTER TABLE "news" ADD CONSTRAINT "Ref_news_to_categories" FOREIGN KEY ("categor
 	ON UPDATE NO ACTION
 	NOT DEFERRABLE;
 
+ALTER TABLE "newsTags" ADD CONSTRAINT "Ref_newsTags_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTags" ADD CONSTRAINT "Ref_newsTags_to_tags" FOREIGN KEY ("tagId")
+	REFERENCES "tags"("tagId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "authors" ADD CONSTRAINT "Ref_authors_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsAuthors" ADD CONSTRAINT "Ref_newsAuthors_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsAuthors" ADD CONSTRAINT "Ref_newsAuthors_to_authors" FOREIGN KEY ("authorId")
+	REFERENCES "authors"("authorId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsRevisions" ADD CONSTRAINT "Ref_newsRevisions_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsRevisions" ADD CONSTRAINT "Ref_newsRevisions_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTransitions" ADD CONSTRAINT "Ref_newsTransitions_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTransitions" ADD CONSTRAINT "Ref_newsTransitions_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTransitions" ADD CONSTRAINT "Ref_newsTransitions_to_apiTokens" FOREIGN KEY ("apiTokenId")
+	REFERENCES "apiTokens"("apiTokenId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "auditLogs" ADD CONSTRAINT "Ref_auditLogs_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "auditLogs" ADD CONSTRAINT "Ref_auditLogs_to_apiTokens" FOREIGN KEY ("apiTokenId")
+	REFERENCES "apiTokens"("apiTokenId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "trashItems" ADD CONSTRAINT "Ref_trashItems_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "trashItems" ADD CONSTRAINT "Ref_trashItems_to_apiTokens" FOREIGN KEY ("apiTokenId")
+	REFERENCES "apiTokens"("apiTokenId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
 ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_statuses" FOREIGN KEY ("statusId")
 	REFERENCES "statuses"("statusId")
 	MATCH SIMPLE
@@ -199,4 +539,11 @@ ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_statuses" FOREIGN KEY
 	ON UPDATE NO ACTION
 	NOT DEFERRABLE;
 
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_categories" FOREIGN KEY ("parentCategoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
 
@@ -0,0 +1,55 @@
+-- adds editorial workflow: "users"."role", "news"."state" and "newsTransitions"
+-- must be applied before the binary with workflow is deployed, otherwise public API shows no news
+-- and existing admins are demoted to authors
+BEGIN;
+
+-- existing users keep full access, new users are authors by default
+ALTER TABLE "users" ADD COLUMN "role" varchar(16) NOT NULL DEFAULT 'author';
+UPDATE "users" SET "role" = 'admin';
+
+-- enabled news stay visible in public API, disabled ones become drafts
+ALTER TABLE "news" ADD COLUMN "state" varchar(16) NOT NULL DEFAULT 'draft';
+UPDATE "news" SET "state" = 'published' WHERE "statusId" = 1;
+
+CREATE INDEX "IX_news_state" ON "news" USING BTREE (
+	"state"
+);
+
+CREATE TABLE "newsTransitions" (
+	"newsTransitionId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"newsId" int4 NOT NULL,
+	"userId" int4,
+	"apiTokenId" int4,
+	"fromState" varchar(16) NOT NULL,
+	"toState" varchar(16) NOT NULL,
+	"reason" text,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	PRIMARY KEY("newsTransitionId")
+);
+
+CREATE INDEX "IX_FK_newsTransitions_newsId" ON "newsTransitions" USING BTREE (
+	"newsId"
+);
+
+ALTER TABLE "newsTransitions" ADD CONSTRAINT "Ref_newsTransitions_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTransitions" ADD CONSTRAINT "Ref_newsTransitions_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTransitions" ADD CONSTRAINT "Ref_newsTransitions_to_apiTokens" FOREIGN KEY ("apiTokenId")
+	REFERENCES "apiTokens"("apiTokenId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+COMMIT;
@@ -0,0 +1,39 @@
+-- moves news."tagIds" array into "newsTags" relation
+BEGIN;
+
+CREATE TABLE "newsTags" (
+	"newsId" int4 NOT NULL,
+	"tagId" int4 NOT NULL,
+	"position" int4 NOT NULL DEFAULT 0,
+	PRIMARY KEY("newsId","tagId")
+);
+
+CREATE INDEX "IX_FK_newsTags_tagId" ON "newsTags" USING BTREE (
+	"tagId"
+);
+
+ALTER TABLE "newsTags" ADD CONSTRAINT "Ref_newsTags_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsTags" ADD CONSTRAINT "Ref_newsTags_to_tags" FOREIGN KEY ("tagId")
+	REFERENCES "tags"("tagId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+-- stale tag ids are dropped, duplicates keep the first position
+INSERT INTO "newsTags" ("newsId", "tagId", "position")
+SELECT n."newsId", nt."tagId", min(nt."position")
+FROM "news" n
+	CROSS JOIN LATERAL unnest(n."tagIds") WITH ORDINALITY AS nt("tagId", "position")
+	JOIN "tags" t ON t."tagId" = nt."tagId"
+GROUP BY n."newsId", nt."tagId";
+
+ALTER TABLE "news" DROP COLUMN "tagIds";
+
+COMMIT;
@@ -0,0 +1,70 @@
+-- adds "authors" and "newsAuthors", authors are deduplicated from news."author" strings
+BEGIN;
+
+CREATE TABLE "authors" (
+	"authorId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"name" varchar(128) NOT NULL,
+	"alias" varchar(128) NOT NULL,
+	"bio" text,
+	"avatarHash" varchar(40),
+	"statusId" int4 NOT NULL,
+	"version" int4 NOT NULL DEFAULT 1,
+	PRIMARY KEY("authorId")
+);
+
+CREATE UNIQUE INDEX "IX_authors_alias" ON "authors" USING BTREE (
+	"alias"
+) WHERE "statusId" <> 3;
+
+CREATE TABLE "newsAuthors" (
+	"newsId" int4 NOT NULL,
+	"authorId" int4 NOT NULL,
+	"position" int4 NOT NULL DEFAULT 0,
+	PRIMARY KEY("newsId","authorId")
+);
+
+CREATE INDEX "IX_FK_newsAuthors_authorId" ON "newsAuthors" USING BTREE (
+	"authorId"
+);
+
+ALTER TABLE "authors" ADD CONSTRAINT "Ref_authors_to_statuses" FOREIGN KEY ("statusId")
+	REFERENCES "statuses"("statusId")
+	MATCH SIMPLE
+	ON DELETE NO ACTION
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsAuthors" ADD CONSTRAINT "Ref_newsAuthors_to_news" FOREIGN KEY ("newsId")
+	REFERENCES "news"("newsId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "newsAuthors" ADD CONSTRAINT "Ref_newsAuthors_to_authors" FOREIGN KEY ("authorId")
+	REFERENCES "authors"("authorId")
+	MATCH SIMPLE
+	ON DELETE RESTRICT
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+-- the most frequent spelling of case-insensitive equal names becomes the author name
+INSERT INTO "authors" ("name", "alias", "statusId")
+SELECT mode() WITHIN GROUP (ORDER BY trim(n."author")), '', 1
+FROM "news" n
+WHERE trim(n."author") <> ''
+GROUP BY lower(trim(n."author"));
+
+-- alias is a latin slug of the name, non-latin or duplicate slugs get author id suffix
+UPDATE "authors" SET "alias" = trim(BOTH '-' FROM regexp_replace(lower("name"), '[^a-z0-9]+', '-', 'g'));
+
+UPDATE "authors" a SET "alias" = concat_ws('-', nullif(a."alias", ''), 'author', a."authorId")
+WHERE a."alias" = ''
+	OR EXISTS (SELECT 1 FROM "authors" b WHERE b."alias" = a."alias" AND b."authorId" < a."authorId");
+
+INSERT INTO "newsAuthors" ("newsId", "authorId", "position")
+SELECT n."newsId", a."authorId", 1
+FROM "news" n
+	JOIN "authors" a ON lower(a."name") = lower(trim(n."author"));
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- adds "parentCategoryId" for nested categories, purged parent makes its subcategories roots
+BEGIN;
+
+ALTER TABLE "categories" ADD COLUMN "parentCategoryId" int4;
+
+ALTER TABLE "categories" ADD CONSTRAINT "categories_parentCategoryId_check" CHECK ("parentCategoryId" <> "categoryId");
+
+CREATE INDEX "IX_FK_categories_parentCategoryId" ON "categories" USING BTREE (
+	"parentCategoryId"
+);
+
+ALTER TABLE "categories" ADD CONSTRAINT "Ref_categories_to_categories" FOREIGN KEY ("parentCategoryId")
+	REFERENCES "categories"("categoryId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+COMMIT;
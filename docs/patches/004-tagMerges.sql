@@ -0,0 +1,48 @@
+-- adds unique "alias" to tags and "tagMerges" for merged tags
+BEGIN;
+
+ALTER TABLE "tags" ADD COLUMN "alias" varchar(128);
+
+-- alias is a latin slug of the title, non-latin or duplicate slugs get tag id suffix
+UPDATE "tags" SET "alias" = trim(BOTH '-' FROM regexp_replace(lower("title"), '[^a-z0-9]+', '-', 'g'));
+
+UPDATE "tags" t SET "alias" = concat_ws('-', nullif(t."alias", ''), 'tag', t."tagId")
+WHERE t."alias" = ''
+	OR EXISTS (SELECT 1 FROM "tags" o WHERE o."alias" = t."alias" AND o."tagId" < t."tagId");
+
+ALTER TABLE "tags" ALTER COLUMN "alias" SET NOT NULL;
+
+CREATE UNIQUE INDEX "IX_tags_alias" ON "tags" USING BTREE (
+	"alias"
+) WHERE "statusId" <> 3;
+
+CREATE TABLE "tagMerges" (
+	"tagMergeId" int4 NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+	"sourceTagId" int4 NOT NULL,
+	"sourceTitle" varchar(128) NOT NULL,
+	"targetTagId" int4 NOT NULL,
+	"userId" int4,
+	"mergedAt" timestamp with time zone NOT NULL DEFAULT now(),
+	PRIMARY KEY("tagMergeId"),
+	CONSTRAINT "tagMerges_sourceTagId_key" UNIQUE("sourceTagId")
+);
+
+CREATE INDEX "IX_FK_tagMerges_targetTagId" ON "tagMerges" USING BTREE (
+	"targetTagId"
+);
+
+ALTER TABLE "tagMerges" ADD CONSTRAINT "Ref_tagMerges_to_tags" FOREIGN KEY ("targetTagId")
+	REFERENCES "tags"("tagId")
+	MATCH SIMPLE
+	ON DELETE CASCADE
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+ALTER TABLE "tagMerges" ADD CONSTRAINT "Ref_tagMerges_to_users" FOREIGN KEY ("userId")
+	REFERENCES "users"("userId")
+	MATCH SIMPLE
+	ON DELETE SET NULL
+	ON UPDATE NO ACTION
+	NOT DEFERRABLE;
+
+COMMIT;
@@ -2,13 +2,22 @@ package app
 
 import (
 	"context"
+	"errors"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"apisrv/pkg/newsportal"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/health"
+	"apisrv/pkg/mail"
+	"apisrv/pkg/migrate"
+	"apisrv/pkg/scheduler"
+	"apisrv/pkg/timeout"
+	"apisrv/pkg/tracing"
 	"apisrv/pkg/vt"
 
 	"github.com/go-pg/pg/v10"
@@ -20,60 +29,103 @@ import (
 )
 
 type Config struct {
-	Database *pg.Options
-	Server   struct {
-		Host      string
-		Port      int
-		IsDevel   bool
-		EnableVFS bool
-	}
-	Sentry struct {
+	Database  *pg.Options
+	Replicas  db.ReplicaConfig
+	SlowQuery db.SlowQueryConfig
+	Server    ServerConfig
+	Sentry    struct {
 		Environment string
 		DSN         string
 	}
-	VFS vfs.Config
+	VFS       vfs.Config
+	Auth      vt.AuthConfig
+	Mail      mail.Config
+	Audit     vt.AuditConfig
+	Trash     vt.TrashConfig
+	Scheduler scheduler.Config
+	Migrate   migrate.Config
+	Tracing   tracing.Config
+	Log       embedlog.Config
+	Timeout   timeout.Config
+	Cache     newsportal.CacheConfig
+}
+
+type ServerConfig struct {
+	Host            string
+	Port            int
+	IsDevel         bool
+	EnableVFS       bool
+	Verbose         bool          // enables debug output, could be changed on reload
+	CORSOrigins     []string      // allowed origins, all origins are allowed if empty, could be changed on reload
+	ShutdownTimeout time.Duration // time for draining requests and stopping workers on shutdown, default 5s
+	ShutdownDelay   time.Duration // delay between failing readiness check and stopping http listener, part of ShutdownTimeout
 }
 
 type App struct {
 	embedlog.Logger
-	appName string
-	cfg     Config
-	dbo     db.DB
-	dbc     *pg.DB
-	nr      db.NewsRepo
-	echo    *echo.Echo
-	nm      *newsportal.Manager
-	vtsrv   zenrpc.Server
+	appName   string
+	cfg       Config
+	dbo       db.DB
+	dbc       *pg.DB
+	replicas  *db.Replicas
+	queries   *db.QueryMonitor
+	nr        db.NewsRepo
+	echo      *echo.Echo
+	nm        *newsportal.Manager
+	mailer    *mail.Mailer
+	vtsrv     zenrpc.Server
+	scheduler *scheduler.Scheduler
+
+	ctx    context.Context // root context, cancelled at the end of shutdown
+	cancel context.CancelFunc
+	hooks  []Hook
+
+	verbose     bool // verbose flag from command line, it could not be disabled by reload
+	logLevels   *embedlog.Levels
+	corsOrigins atomic.Pointer[[]string]
+	reloadMu    sync.Mutex
+
+	build        BuildInfo
+	health       *health.Registry
+	workers      workers
+	shuttingDown atomic.Bool
+	poolTimeouts atomic.Uint32 // pool timeouts at previous pool check
 }
 
-func New(appName string, verbose bool, cfg Config, dbo db.DB, dbc *pg.DB) *App {
+func New(appName string, verbose bool, cfg Config, dbo db.DB, dbc *pg.DB, build BuildInfo) *App {
 	a := &App{
-		appName: appName,
-		cfg:     cfg,
-		dbo:     dbo,
-		dbc:     dbc,
-		echo:    echo.New(),
+		appName:  appName,
+		cfg:      cfg,
+		dbo:      dbo.Primary(), // vt and workers read their own writes, so replicas are not used for them
+		dbc:      dbc,
+		replicas: dbo.Replicas(),
+		echo:     echo.New(),
+		verbose:  verbose,
+		build:    build,
+		health:   health.NewRegistry(0),
 	}
-	a.nr = db.NewNewsRepo(a.dbc)
-	a.nm = newsportal.NewManager(a.nr)
-	a.SetStdLoggers(verbose)
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+	a.nr = db.NewNewsRepo(dbo) // public api reads from replicas
+	a.nm = newsportal.NewManager(a.nr).WithCache(cfg.Cache)
+	a.setLoggers()
+	a.setCORSOrigins(cfg.Server.CORSOrigins)
+	a.setQueryMonitor()
 	a.echo.HideBanner = true
 	a.echo.HidePort = true
 	_, mask, _ := net.ParseCIDR("0.0.0.0/0")
 	a.echo.IPExtractor = echo.ExtractIPFromRealIPHeader(echo.TrustIPRange(mask))
-	a.nm = newsportal.NewManager(a.nr)
-	a.vtsrv = vt.New(a.dbo, a.Logger, a.cfg.Server.IsDevel)
-	return a
-}
+	a.nm = newsportal.NewManager(a.nr).WithCache(cfg.Cache)
+	a.mailer = mail.NewMailer(mail.NewSender(a.cfg.Mail, a.Component("mail")), a.cfg.Mail)
+	a.scheduler = scheduler.New(appName, a.dbo, a.Component("scheduler"), a.cfg.Scheduler)
+	a.scheduler.OnChange(a.auditSchedulerEvent)
+	a.vtsrv = vt.New(a.dbo, a.Component("vt"), a.cfg.Server.IsDevel, a.cfg.Auth, a.mailer, a.cfg.Timeout)
+
+	// db is closed after all other hooks are stopped
+	a.Append(Hook{Name: "db", OnStop: func(context.Context) error {
+		return errors.Join(a.dbc.Close(), a.replicas.Close())
+	}})
 
-// Run is a function that runs application.
-func (a *App) Run() error {
-	a.registerMetrics()
-	a.registerHandlers()
-	a.registerDebugHandlers()
-	a.registerAPIHandlers()
-	a.registerVTApiHandlers()
-	return a.runHTTPServer(a.cfg.Server.Host, a.cfg.Server.Port)
+	return a
 }
 
 // VTTypeScriptClient returns TypeScript client for VT.
@@ -82,13 +134,3 @@ func (a *App) VTTypeScriptClient() ([]byte, error) {
 	tsSettings := typescript.Settings{ExcludedNamespace: []string{NSVFS}, WithClasses: true}
 	return gen.TSCustomClient(tsSettings).Generate()
 }
-
-// Shutdown is a function that gracefully stops HTTP server.
-func (a *App) Shutdown(timeout time.Duration) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	if err := a.echo.Shutdown(ctx); err != nil {
-		a.Errorf("shutting down server err=%q", err)
-	}
-}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestApp_Run(t *testing.T) {
+	Convey("Test App lifecycle with in-process server", t, func() {
+		var cfg Config
+		cfg.Database = &pg.Options{Addr: "127.0.0.1:1"} // db is not required for lifecycle
+		cfg.Server.Host = "127.0.0.1"
+		cfg.Server.ShutdownTimeout = 5 * time.Second
+
+		dbc := pg.Connect(cfg.Database)
+		a := New("apptest", false, cfg, db.New(dbc), dbc, BuildInfo{Version: "test"})
+
+		var (
+			mu     sync.Mutex
+			events []string
+		)
+		record := func(e string) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		}
+
+		started := make(chan struct{})
+		a.Append(Hook{
+			Name:    "test",
+			OnStart: func(context.Context) error { record("start test"); close(started); return nil },
+			OnStop:  func(context.Context) error { record("stop test"); return nil },
+		})
+
+		// slow handler emulates in-flight rpc request
+		inFlight, release := make(chan struct{}), make(chan struct{})
+		a.echo.GET("/slow", func(c echo.Context) error {
+			close(inFlight)
+			<-release
+			record("request done")
+			return c.String(http.StatusOK, "done")
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() { runErr <- a.Run(ctx) }()
+		<-started
+
+		// request is sent before shutdown and must be served
+		respCh := make(chan string, 1)
+		go func() {
+			resp, err := http.Get("http://" + a.Addr().String() + "/slow")
+			if err != nil {
+				respCh <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(resp.Body)
+			respCh <- string(b)
+		}()
+		<-inFlight
+
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		So(a.shuttingDown.Load(), ShouldBeTrue)
+
+		close(release)
+		So(<-respCh, ShouldEqual, "done")
+		So(<-runErr, ShouldBeNil)
+		So(a.ctx.Err(), ShouldNotBeNil)
+
+		mu.Lock()
+		defer mu.Unlock()
+		So(events, ShouldResemble, []string{"start test", "request done", "stop test"})
+
+		Convey("Pool is closed", func() {
+			err := dbc.Ping(context.Background())
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "pg: database is closed")
+		})
+	})
+}
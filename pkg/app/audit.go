@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"apisrv/pkg/vt"
+)
+
+const auditCleanInterval = time.Hour
+
+// runAuditCleaner removes audit records older than retention period until ctx is cancelled.
+// Current iteration uses root context, so it is finished on shutdown if timeout allows.
+func (a *App) runAuditCleaner(ctx context.Context) {
+	if a.cfg.Audit.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(auditCleanInterval)
+	defer ticker.Stop()
+
+	w := a.workers.start("auditCleaner", auditCleanInterval)
+	defer w.stop()
+
+	for {
+		w.beat()
+		if n, err := vt.CleanAuditLog(a.ctx, a.dbo, a.cfg.Audit); err != nil {
+			a.Error(ctx, "clean audit log", "err", err)
+		} else if n > 0 {
+			a.Info(ctx, "removed outdated audit records", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+
+	"apisrv/pkg/newsportal"
+)
+
+// runCacheListener flushes cache of public API on notifications sent by "cache flush" command until ctx is cancelled.
+// Listener reconnects to DB by itself, notifications sent while it is disconnected are lost.
+func (a *App) runCacheListener(ctx context.Context) {
+	if a.cfg.Cache.TTL <= 0 {
+		return
+	}
+
+	ln := a.dbc.Listen(ctx, newsportal.CacheFlushChannel)
+	defer func() {
+		if err := ln.Close(); err != nil {
+			a.Error(ctx, "close cache listener", "err", err)
+		}
+	}()
+
+	ch := ln.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+			a.nm.FlushCache()
+			a.Info(ctx, "public api cache is flushed")
+		}
+	}
+}
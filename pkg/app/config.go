@@ -0,0 +1,242 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"apisrv/pkg/config"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mail"
+	"apisrv/pkg/tracing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/getsentry/sentry-go"
+)
+
+// EnvPrefix is a prefix of environment variables which override config keys, e.g. NEWSPORTAL_SERVER_PORT.
+const EnvPrefix = "NEWSPORTAL"
+
+// reloadableKeys are config keys which are applied by Reload without restart.
+var reloadableKeys = map[string]struct{}{
+	"Server.Verbose":     {},
+	"Server.CORSOrigins": {},
+	"Log.Level":          {},
+	"Log.Levels":         {},
+}
+
+// LoadConfig decodes config file and applies environment overrides to it.
+// Metadata of decoded file is returned for checking unknown keys.
+func LoadConfig(path string) (Config, toml.MetaData, error) {
+	var cfg Config
+	meta, err := toml.DecodeFile(path, &cfg)
+	if err != nil {
+		return cfg, meta, err
+	}
+
+	if _, err = config.ApplyEnv(EnvPrefix, &cfg, os.LookupEnv); err != nil {
+		return cfg, meta, err
+	}
+
+	return cfg, meta, nil
+}
+
+// Validate checks config and returns all found problems joined in one error.
+func (c Config) Validate() error {
+	var errs []error
+	add := func(key, format string, v ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, v...)))
+	}
+
+	if c.Database == nil {
+		add("Database", "section is required")
+	} else if c.Database.Addr != "" {
+		if _, port, err := net.SplitHostPort(c.Database.Addr); err != nil {
+			add("Database.Addr", "%v", err)
+		} else if !isValidPort(port) {
+			add("Database.Addr", "invalid port %q", port)
+		}
+	}
+
+	for _, addr := range c.Replicas.Addrs {
+		if _, port, err := net.SplitHostPort(addr); err != nil {
+			add("Replicas.Addrs", "%v", err)
+		} else if !isValidPort(port) {
+			add("Replicas.Addrs", "invalid port %q", port)
+		}
+	}
+	if c.Replicas.MaxLag < 0 {
+		add("Replicas.MaxLag", "must not be negative")
+	}
+	if c.Replicas.CheckInterval < 0 {
+		add("Replicas.CheckInterval", "must not be negative")
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		add("Server.Port", "must be in range 1-65535, got %d", c.Server.Port)
+	}
+
+	if c.Server.ShutdownTimeout < 0 {
+		add("Server.ShutdownTimeout", "must not be negative")
+	} else if c.Server.ShutdownDelay < 0 || c.Server.ShutdownDelay >= c.Server.shutdownTimeout() {
+		add("Server.ShutdownDelay", "must be less than shutdown timeout %v", c.Server.shutdownTimeout())
+	}
+
+	for _, o := range c.Server.CORSOrigins {
+		if o == "*" {
+			continue
+		}
+		if u, err := url.Parse(o); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+			add("Server.CORSOrigins", "invalid origin %q, expected * or scheme://host[:port]", o)
+		}
+	}
+
+	if c.Sentry.DSN != "" {
+		if _, err := sentry.NewDsn(c.Sentry.DSN); err != nil {
+			add("Sentry.DSN", "%v", err)
+		}
+	}
+
+	if c.Server.EnableVFS {
+		if err := isDir(c.VFS.Path); err != nil {
+			add("VFS.Path", "%v", err)
+		}
+	}
+
+	if err := c.Auth.Hasher.Validate(); err != nil {
+		add("Auth.Hasher", "%v", err)
+	}
+
+	switch c.Mail.Sender {
+	case "", mail.SenderLog:
+	case mail.SenderSMTP:
+		if c.Mail.SMTP.Host == "" {
+			add("Mail.SMTP.Host", "is required for smtp sender")
+		}
+	case mail.SenderFile:
+		if err := isDir(c.Mail.Dir); err != nil {
+			add("Mail.Dir", "%v", err)
+		}
+	default:
+		add("Mail.Sender", "unknown sender %q", c.Mail.Sender)
+	}
+
+	switch c.Tracing.Exporter {
+	case "", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	case tracing.ExporterFile:
+		if c.Tracing.File == "" {
+			add("Tracing.File", "is required for file exporter")
+		}
+	default:
+		add("Tracing.Exporter", "unknown exporter %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		add("Tracing.SampleRatio", "must be in range 0-1, got %v", c.Tracing.SampleRatio)
+	}
+
+	if err := c.Log.Validate(); err != nil {
+		add("Log", "%v", err)
+	}
+
+	if err := c.Timeout.Validate(); err != nil {
+		add("Timeout.Methods", "%v", err)
+	}
+
+	if c.Cache.TTL < 0 {
+		add("Cache.TTL", "must not be negative")
+	}
+
+	if c.Audit.Retention < 0 {
+		add("Audit.Retention", "must not be negative")
+	}
+	if c.Trash.Retention < 0 {
+		add("Trash.Retention", "must not be negative")
+	}
+
+	return errors.Join(errs...)
+}
+
+// Print writes effective config to w, values of secrets are redacted.
+func (c Config) Print(w io.Writer) error {
+	for _, f := range config.Fields(EnvPrefix, c) {
+		if _, err := fmt.Fprintf(w, "%s = %s\n", f.Key, f.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reload applies reloadable settings of new config, other changed keys are reported as requiring restart.
+func (a *App) Reload(cfg Config) {
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	for _, key := range config.Changed(a.cfg, cfg) {
+		if _, ok := reloadableKeys[key]; !ok {
+			a.Warn(a.ctx, "config key was changed, restart is required to apply it", "key", key)
+			continue
+		}
+
+		a.Info(a.ctx, "config key was reloaded", "key", key)
+	}
+
+	a.cfg.Server.Verbose = cfg.Server.Verbose
+	a.cfg.Server.CORSOrigins = cfg.Server.CORSOrigins
+	a.cfg.Log.Level, a.cfg.Log.Levels = cfg.Log.Level, cfg.Log.Levels
+	if err := a.logLevels.Set(cfg.Log, a.verbose || cfg.Server.Verbose); err != nil {
+		a.Error(a.ctx, "set log levels", "err", err)
+	}
+	a.setCORSOrigins(cfg.Server.CORSOrigins)
+}
+
+// setCORSOrigins sets origins allowed by CORS middleware, all origins are allowed by default.
+func (a *App) setCORSOrigins(origins []string) {
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+
+	a.corsOrigins.Store(&origins)
+}
+
+// allowOrigin checks that origin is allowed by CORS middleware.
+func (a *App) allowOrigin(origin string) (bool, error) {
+	for _, o := range *a.corsOrigins.Load() {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// setLoggers sets structured logger of app, debug records are written in verbose mode.
+func (a *App) setLoggers() {
+	a.logLevels = embedlog.NewLevels(a.cfg.Log, a.verbose || a.cfg.Server.Verbose)
+	a.Logger = embedlog.New(os.Stdout, a.cfg.Log.Format, a.logLevels)
+}
+
+func isValidPort(port string) bool {
+	p, err := strconv.Atoi(port)
+	return err == nil && p > 0 && p <= 65535
+}
+
+func isDir(path string) error {
+	if path == "" {
+		return errors.New("is required")
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	} else if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	return nil
+}
@@ -1,11 +1,11 @@
 package app
 
 import (
-	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 
 	"apisrv/pkg/rpc"
+	"apisrv/pkg/tracing"
 
 	sentryecho "github.com/getsentry/sentry-go/echo"
 	"github.com/labstack/echo/v4"
@@ -16,18 +16,14 @@ import (
 	"github.com/vmkteam/zenrpc/v2"
 )
 
-// runHTTPServer is a function that starts http listener using labstack/echo.
-func (a *App) runHTTPServer(host string, port int) error {
-	listenAddress := fmt.Sprintf("%s:%d", host, port)
-	a.Printf("starting http listener at http://%s\n", listenAddress)
-	return a.echo.Start(listenAddress)
-}
-
 func (a *App) registerHandlers() {
+	// trace all requests except probes and metrics
+	a.echo.Use(tracing.EchoMiddleware("/healthz", "/readyz", "/metrics"))
+
 	a.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
-		AllowHeaders: []string{"Authorization", "Authorization2", "Origin", "X-Requested-With", "Content-Type", "Accept", "Platform", "Version"},
+		AllowOriginFunc: a.allowOrigin,
+		AllowMethods:    []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
+		AllowHeaders:    []string{"Authorization", "Authorization2", "X-Api-Token", "Origin", "X-Requested-With", "Content-Type", "Accept", "Platform", "Version"},
 	}))
 
 	// sentry middleware
@@ -56,7 +52,7 @@ func (a *App) registerDebugHandlers() {
 		// test postgresql connection
 		err := a.dbo.Ping(c.Request().Context())
 		if err != nil {
-			a.Errorf("failed to check db connection err=%q", err)
+			a.Error(c.Request().Context(), "failed to check db connection", "err", err)
 			return c.String(http.StatusInternalServerError, "DB error")
 		}
 		return c.String(http.StatusOK, "OK")
@@ -64,7 +60,7 @@ func (a *App) registerDebugHandlers() {
 }
 
 func (a *App) registerAPIHandlers() {
-	srv := rpc.New(a.dbo, a.Logger, a.cfg.Server.IsDevel, a.nm)
+	srv := rpc.New(a.dbo, a.Component("rpc"), a.cfg.Server.IsDevel, a.nm, a.cfg.Timeout)
 	gen := rpcgen.FromSMD(srv.SMD())
 	a.echo.Any("/v1/rpc/", zm.EchoHandler(zm.XRequestID(srv)))
 	a.echo.Any("/v1/rpc/doc/", echo.WrapHandler(http.HandlerFunc(zenrpc.SMDBoxHandler)))
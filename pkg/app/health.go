@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"apisrv/pkg/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BuildInfo describes running binary, it is exposed by /version endpoint.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"goVersion"`
+	BuildTime string `json:"buildTime,omitempty"`
+}
+
+// workerStallTimeout is added to doubled worker interval before worker is reported as stalled.
+const workerStallTimeout = time.Minute
+
+// worker tracks background goroutine for liveness check.
+type worker struct {
+	interval time.Duration
+	running  atomic.Bool
+	lastRun  atomic.Int64 // unix nano
+}
+
+// beat marks start of worker iteration.
+func (w *worker) beat() { w.lastRun.Store(time.Now().UnixNano()) }
+
+// stop marks worker as exited.
+func (w *worker) stop() { w.running.Store(false) }
+
+// workers are background goroutines of App.
+type workers struct {
+	mu   sync.Mutex
+	list map[string]*worker
+}
+
+// start registers running worker with given name and iteration interval.
+func (ws *workers) start(name string, interval time.Duration) *worker {
+	w := &worker{interval: interval}
+	w.running.Store(true)
+	w.beat()
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.list == nil {
+		ws.list = make(map[string]*worker)
+	}
+	ws.list[name] = w
+
+	return w
+}
+
+// check fails if any worker exited or did not start new iteration in time.
+func (ws *workers) check(_ context.Context) (map[string]interface{}, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	details := make(map[string]interface{}, len(ws.list))
+	var failed []string
+	for name, w := range ws.list {
+		age := time.Since(time.Unix(0, w.lastRun.Load()))
+		state := "running"
+		if !w.running.Load() {
+			state = "stopped"
+		} else if age > 2*w.interval+workerStallTimeout {
+			state = "stalled"
+		}
+
+		details[name] = map[string]interface{}{"state": state, "lastRun": age.Truncate(time.Second).String() + " ago"}
+		if state != "running" {
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return details, fmt.Errorf("workers are not running: %v", failed)
+	}
+
+	return details, nil
+}
+
+// Health returns registry of liveness and readiness checks. Additional checks could be added to it.
+func (a *App) Health() *health.Registry {
+	return a.health
+}
+
+// registerHealthChecks adds default checks of App dependencies.
+func (a *App) registerHealthChecks() {
+	a.health.Add(health.Liveness, "workers", a.workers.check)
+	a.health.Add(health.Readiness, "shutdown", a.checkShutdown)
+	a.health.Add(health.Readiness, "db", a.checkDB)
+	a.health.Add(health.Readiness, "dbPool", a.checkDBPool)
+	if len(a.replicas.List()) > 0 {
+		a.health.Add(health.Readiness, "replicas", a.checkReplicas)
+	}
+
+	if a.cfg.Server.EnableVFS {
+		a.health.Add(health.Readiness, "vfs", a.checkVFS)
+	}
+}
+
+// registerHealthHandlers adds /healthz, /readyz and /version endpoints.
+func (a *App) registerHealthHandlers() {
+	a.echo.GET("/healthz", echo.WrapHandler(a.health.Handler(health.Liveness)))
+	a.echo.GET("/readyz", echo.WrapHandler(a.health.Handler(health.Readiness)))
+	a.echo.GET("/version", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, a.build)
+	})
+}
+
+// checkShutdown fails after App.Shutdown was called, so balancer stops sending new requests.
+func (a *App) checkShutdown(_ context.Context) (map[string]interface{}, error) {
+	if a.shuttingDown.Load() {
+		return nil, errors.New("shutting down")
+	}
+
+	return nil, nil
+}
+
+// checkDB pings DB and reports latency.
+func (a *App) checkDB(ctx context.Context) (map[string]interface{}, error) {
+	start := time.Now()
+	err := a.dbo.Ping(ctx)
+
+	return map[string]interface{}{"latency": time.Since(start).String()}, err
+}
+
+// checkDBPool reports pool usage and fails if pool is exhausted and requests wait for connections with timeouts.
+func (a *App) checkDBPool(_ context.Context) (map[string]interface{}, error) {
+	stats := a.dbc.PoolStats()
+	size := a.dbc.Options().PoolSize
+	inUse := int(stats.TotalConns) - int(stats.IdleConns)
+	timeouts := stats.Timeouts - a.poolTimeouts.Swap(stats.Timeouts)
+
+	details := map[string]interface{}{
+		"poolSize":   size,
+		"totalConns": stats.TotalConns,
+		"idleConns":  stats.IdleConns,
+		"inUse":      inUse,
+		"timeouts":   timeouts,
+	}
+	if size > 0 {
+		details["saturation"] = float64(inUse) / float64(size)
+	}
+
+	if size > 0 && inUse >= size && timeouts > 0 {
+		return details, fmt.Errorf("connection pool is exhausted, %d timeouts since last check", timeouts)
+	}
+
+	return details, nil
+}
+
+// checkVFS checks that VFS storage is writable.
+func (a *App) checkVFS(_ context.Context) (map[string]interface{}, error) {
+	f, err := os.CreateTemp(a.cfg.VFS.Path, ".healthz-*")
+	if err != nil {
+		return nil, err
+	}
+
+	name := f.Name()
+	if err = f.Close(); err != nil {
+		return nil, err
+	}
+
+	return nil, os.Remove(name)
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultShutdownTimeout = 5 * time.Second
+
+// Hook is a component of App which is started before http server and stopped after it.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error // optional, ctx is App root context
+	OnStop  func(ctx context.Context) error // optional, ctx deadline is the rest of shutdown timeout
+}
+
+// Append registers hook. Hooks are started in registration order and stopped in reverse order,
+// hooks added before Run are stopped after background workers.
+func (a *App) Append(h Hook) {
+	a.hooks = append(a.hooks, h)
+}
+
+// Addr returns address of http listener, it is nil until Run starts listening.
+func (a *App) Addr() net.Addr {
+	return a.echo.ListenerAddr()
+}
+
+// Run starts hooks and http server and blocks until ctx is cancelled or server fails.
+// Then App is shut down: readiness check starts failing, in-flight requests are drained,
+// hooks are stopped in reverse order and root context is cancelled, all within Server.ShutdownTimeout.
+func (a *App) Run(ctx context.Context) error {
+	a.registerMetrics()
+	a.registerHandlers()
+	a.registerHealthChecks()
+	a.registerHealthHandlers()
+	a.registerDebugHandlers()
+	a.registerAPIHandlers()
+	a.registerVTApiHandlers()
+
+	a.Append(a.workerHook("scheduler", a.runScheduler))
+	a.Append(a.workerHook("auditCleaner", a.runAuditCleaner))
+	a.Append(a.workerHook("trashPurger", a.runTrashPurger))
+	a.Append(a.workerHook("cacheListener", a.runCacheListener))
+	if len(a.replicas.List()) > 0 {
+		a.Append(a.workerHook("replicaChecker", a.runReplicaChecker))
+	}
+
+	// listen before starting hooks to fail fast if address is in use
+	addr := net.JoinHostPort(a.cfg.Server.Host, strconv.Itoa(a.cfg.Server.Port))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Join(err, a.shutdown(0))
+	}
+	a.echo.Listener = ln
+
+	started, err := a.startHooks()
+	if err != nil {
+		return errors.Join(err, a.shutdown(started))
+	}
+
+	srvErr := make(chan error, 1)
+	go func() {
+		a.Info(ctx, "starting http listener", "addr", a.Addr().String())
+		srvErr <- a.echo.Start("")
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err = <-srvErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+	}
+
+	return errors.Join(err, a.shutdown(len(a.hooks)))
+}
+
+// startHooks calls OnStart of hooks in order and returns count of started hooks.
+func (a *App) startHooks() (int, error) {
+	for i, h := range a.hooks {
+		if h.OnStart == nil {
+			continue
+		}
+
+		if err := h.OnStart(a.ctx); err != nil {
+			return i, fmt.Errorf("start %s: %w", h.Name, err)
+		}
+	}
+
+	return len(a.hooks), nil
+}
+
+// shutdown stops http server and first started hooks within shutdown timeout and cancels root context.
+func (a *App) shutdown(started int) error {
+	a.shuttingDown.Store(true)
+	defer a.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.shutdownTimeout())
+	defer cancel()
+
+	// give balancers time to notice failing readiness check
+	if d := a.cfg.Server.ShutdownDelay; d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+		}
+	}
+
+	var errs []error
+	if err := a.echo.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("stop http server: %w", err))
+	}
+
+	for i := started - 1; i >= 0; i-- {
+		h := a.hooks[i]
+		if h.OnStop == nil {
+			continue
+		}
+
+		if err := h.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", h.Name, err))
+		} else {
+			a.Info(ctx, "stopped", "hook", h.Name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// workerHook returns hook which runs fn in background until hook is stopped.
+// On stop ctx of fn is cancelled and hook waits for fn to return.
+func (a *App) workerHook(name string, fn func(ctx context.Context)) Hook {
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			ctx, cancel = context.WithCancel(ctx)
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+func (s ServerConfig) shutdownTimeout() time.Duration {
+	if s.ShutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return s.ShutdownTimeout
+}
@@ -5,8 +5,10 @@ import (
 	"strconv"
 	"time"
 
+	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
 
+	"github.com/go-pg/pg/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -18,7 +20,7 @@ func (a *App) registerMetrics() {
 		Namespace: a.appName,
 		Subsystem: "log",
 		Name:      "events_total",
-		Help:      "Log events distributions.",
+		Help:      "Written log records by level.",
 	}, []string{"type"})
 
 	embedlog.SetStatLogEvents(statLogEvents)
@@ -28,7 +30,34 @@ func (a *App) registerMetrics() {
 	// add db conn metrics
 	metrics := NewConnectionPoolMetrics(a.appName)
 	prometheus.MustRegister(metrics)
-	metrics.ObserveRegularly(context.Background(), a.dbc, "default")
+	var stopObserving context.CancelFunc
+	a.Append(Hook{
+		Name: "poolMetrics",
+		OnStart: func(ctx context.Context) error {
+			ctx, stopObserving = context.WithCancel(ctx)
+			if err := metrics.ObserveRegularly(ctx, a.dbc, "default"); err != nil {
+				return err
+			}
+
+			for _, r := range a.replicas.List() {
+				if err := metrics.ObserveRegularly(ctx, r.DB, r.Name); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			stopObserving()
+			return nil
+		},
+	})
+
+	a.registerReplicaMetrics()
+	prometheus.MustRegister(a.queries)
+
+	// add scheduler metrics
+	prometheus.MustRegister(a.scheduler)
 
 	a.echo.Use(httpMetrics(a.appName))
 	a.echo.Any("/metrics", echo.WrapHandler(promhttp.Handler()))
@@ -70,3 +99,16 @@ func httpMetrics(appName string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// setQueryMonitor adds query hook which records durations of all queries and logs slow ones.
+// Plans of slow queries are logged only in devel mode, because they are built with query parameters.
+func (a *App) setQueryMonitor() {
+	var explainDB *pg.DB
+	if a.cfg.Server.IsDevel {
+		explainDB = a.dbc
+	}
+
+	a.queries = db.NewQueryMonitor(a.appName, a.Component("db"), a.cfg.SlowQuery, explainDB)
+	a.dbc.AddQueryHook(a.queries)
+	a.replicas.AddQueryHook(a.queries)
+}
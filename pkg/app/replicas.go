@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// runReplicaChecker updates lag and health of replicas until ctx is cancelled.
+// Unhealthy replicas are not used for reads until they are back.
+func (a *App) runReplicaChecker(ctx context.Context) {
+	interval := a.replicas.Config().Interval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	w := a.workers.start("replicaChecker", interval)
+	defer w.stop()
+
+	for {
+		w.beat()
+		if err := a.replicas.Check(ctx); err != nil {
+			a.Warn(ctx, "check replicas", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkReplicas reports state of replicas, it never fails because reads fall back to primary.
+func (a *App) checkReplicas(_ context.Context) (map[string]interface{}, error) {
+	details := make(map[string]interface{})
+	for _, r := range a.replicas.List() {
+		details[r.Name] = map[string]interface{}{"healthy": r.Healthy(), "lag": r.Lag().String()}
+	}
+
+	return details, nil
+}
+
+// registerReplicaMetrics adds replication lag gauge labelled by replica.
+func (a *App) registerReplicaMetrics() {
+	for _, r := range a.replicas.List() {
+		r := r
+		prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   a.appName,
+			Subsystem:   "postgres",
+			Name:        "replica_lag_seconds",
+			Help:        "Replication lag of replica on last check.",
+			ConstLabels: prometheus.Labels{"replica": r.Name},
+		}, func() float64 { return r.Lag().Seconds() }))
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/scheduler"
+)
+
+const schedulerNamespace = "scheduler"
+
+// runScheduler publishes and unpublishes scheduled news until ctx is cancelled.
+// Current iteration uses root context, so it is finished on shutdown if timeout allows.
+func (a *App) runScheduler(ctx context.Context) {
+	ticker := time.NewTicker(a.scheduler.Interval())
+	defer ticker.Stop()
+
+	w := a.workers.start("scheduler", a.scheduler.Interval())
+	defer w.stop()
+
+	for {
+		w.beat()
+		if _, err := a.scheduler.Process(a.ctx); err != nil {
+			a.Error(ctx, "process scheduled news", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// auditSchedulerEvent stores news change made by scheduler in audit log.
+func (a *App) auditSchedulerEvent(ctx context.Context, e scheduler.Event) {
+	entityType := "news"
+	rec := &db.AuditLog{
+		Namespace:  schedulerNamespace,
+		Method:     string(e.Action),
+		EntityType: &entityType,
+		EntityID:   &e.NewsID,
+		Before:     map[string]interface{}{"state": e.From},
+		After:      map[string]interface{}{"state": e.To},
+	}
+
+	if _, err := db.NewCommonRepo(a.dbo).AddAuditLog(ctx, rec); err != nil {
+		a.Error(ctx, "add scheduler audit log", "newsId", e.NewsID, "err", err)
+	}
+}
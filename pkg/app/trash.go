@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"apisrv/pkg/vt"
+)
+
+const trashPurgeInterval = time.Hour
+
+// runTrashPurger permanently removes entities deleted before retention period until ctx is cancelled.
+// Current iteration uses root context, so it is finished on shutdown if timeout allows.
+func (a *App) runTrashPurger(ctx context.Context) {
+	if a.cfg.Trash.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	w := a.workers.start("trashPurger", trashPurgeInterval)
+	defer w.stop()
+
+	for {
+		w.beat()
+		purged, inUse, err := vt.PurgeTrash(a.ctx, a.dbo, a.Logger, a.cfg.Trash)
+		if err != nil {
+			a.Error(ctx, "purge trash", "err", err)
+		}
+		if purged > 0 || inUse > 0 {
+			a.Info(ctx, "purged outdated trash items", "count", purged, "skippedInUse", inUse)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
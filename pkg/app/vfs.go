@@ -12,7 +12,7 @@ import (
 	zm "github.com/vmkteam/zenrpc-middleware"
 )
 
-const NSVFS = "vfs"
+const NSVFS = vt.NSVFS
 
 // RegisterVFS register VFS handler and RPC service
 func (a *App) RegisterVFS(cfg vfs.Config) error {
@@ -0,0 +1,287 @@
+// Package config applies environment overrides to config structs and prints them with secrets redacted.
+//
+// Every exported field of config struct has a dotted key, e.g. Server.Port or Mail.SMTP.Password,
+// and an environment variable made of prefix and upper-cased key parts joined by underscore,
+// e.g. NEWSPORTAL_SERVER_PORT or NEWSPORTAL_MAIL_SMTP_PASSWORD.
+// String fields could be read from file set by variable with _FILE suffix, e.g. NEWSPORTAL_DATABASE_PASSWORD_FILE.
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fileSuffix = "_FILE"
+	redacted   = "******"
+	maxDepth   = 8 // nested structs deeper than maxDepth are skipped, it protects from recursive types
+)
+
+// secretNames are parts of field names which values are hidden by Fields.
+var secretNames = []string{"password", "secret", "token", "dsn"}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// LookupFunc returns value of environment variable, e.g. os.LookupEnv.
+type LookupFunc func(key string) (string, bool)
+
+// Field is a config value with its key.
+type Field struct {
+	Key    string // dotted key, e.g. Server.Port
+	Env    string // environment variable, e.g. NEWSPORTAL_SERVER_PORT
+	Value  string // formatted value, values of secrets are redacted by Fields
+	Secret bool
+}
+
+// IsSecret checks that field with given key contains secret.
+func IsSecret(key string) bool {
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	for _, s := range secretNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EnvName returns environment variable name for dotted key.
+func EnvName(prefix, key string) string {
+	return strings.ToUpper(prefix + "_" + strings.ReplaceAll(key, ".", "_"))
+}
+
+// ApplyEnv sets fields of struct pointed by v from environment variables and returns keys of changed fields.
+// Nil pointers to nested structs are allocated only if any of their fields is set.
+func ApplyEnv(prefix string, v interface{}, lookup LookupFunc) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config must be a pointer to struct, got %T", v)
+	}
+
+	var keys []string
+	err := applyEnv(prefix, "", rv.Elem(), lookup, &keys)
+	return keys, err
+}
+
+func applyEnv(prefix, path string, v reflect.Value, lookup LookupFunc, keys *[]string) error {
+	if strings.Count(path, ".") >= maxDepth {
+		return nil
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		key := joinKey(path, sf.Name)
+		fv := v.Field(i)
+		switch {
+		case fv.Kind() == reflect.Struct:
+			if err := applyEnv(prefix, key, fv, lookup, keys); err != nil {
+				return err
+			}
+			continue
+		case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct:
+			target := fv
+			if fv.IsNil() {
+				target = reflect.New(fv.Type().Elem())
+			}
+
+			n := len(*keys)
+			if err := applyEnv(prefix, key, target.Elem(), lookup, keys); err != nil {
+				return err
+			} else if fv.IsNil() && len(*keys) > n {
+				fv.Set(target)
+			}
+			continue
+		case !isScalar(fv.Type()):
+			continue
+		}
+
+		env := EnvName(prefix, key)
+		value, ok := lookup(env)
+		if file, fok := lookup(env + fileSuffix); fok && fv.Kind() == reflect.String {
+			if ok {
+				return fmt.Errorf("%s: both %s and %s are set", key, env, env+fileSuffix)
+			}
+
+			b, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			value, ok = strings.TrimRight(string(b), "\r\n"), true
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := setValue(fv, value); err != nil {
+			return fmt.Errorf("%s: invalid value of %s: %w", key, env, err)
+		}
+		*keys = append(*keys, key)
+	}
+
+	return nil
+}
+
+// Fields returns all scalar fields of struct v with formatted values. Values of secrets are redacted.
+// Fields of nil nested structs are skipped.
+func Fields(prefix string, v interface{}) []Field {
+	var r []Field
+	fields(prefix, "", reflect.Indirect(reflect.ValueOf(v)), &r, true)
+	return r
+}
+
+// Changed returns keys of scalar fields which values differ in old and new structs of the same type.
+func Changed(old, new interface{}) []string {
+	var of, nf []Field
+	fields("", "", reflect.Indirect(reflect.ValueOf(old)), &of, false)
+	fields("", "", reflect.Indirect(reflect.ValueOf(new)), &nf, false)
+
+	values := make(map[string]string, len(of))
+	for _, f := range of {
+		values[f.Key] = f.Value
+	}
+
+	var r []string
+	for _, f := range nf {
+		if v, ok := values[f.Key]; !ok || v != f.Value {
+			r = append(r, f.Key)
+		}
+		delete(values, f.Key)
+	}
+
+	for _, f := range of {
+		if _, ok := values[f.Key]; ok {
+			r = append(r, f.Key)
+		}
+	}
+
+	return r
+}
+
+func fields(prefix, path string, v reflect.Value, r *[]Field, redact bool) {
+	if v.Kind() != reflect.Struct || strings.Count(path, ".") >= maxDepth {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		key := joinKey(path, sf.Name)
+		fv := v.Field(i)
+		switch {
+		case fv.Kind() == reflect.Struct:
+			fields(prefix, key, fv, r, redact)
+			continue
+		case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct:
+			if !fv.IsNil() {
+				fields(prefix, key, fv.Elem(), r, redact)
+			}
+			continue
+		case !isScalar(fv.Type()):
+			continue
+		}
+
+		f := Field{Key: key, Env: EnvName(prefix, key), Value: formatValue(fv), Secret: IsSecret(key)}
+		if redact && f.Secret && f.Value != "" {
+			f.Value = redacted
+		}
+		*r = append(*r, f)
+	}
+}
+
+func joinKey(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// isScalar checks that values of type t could be set from environment.
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+
+	return false
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		v.Set(reflect.ValueOf(list).Convert(v.Type()))
+	}
+
+	return nil
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	} else if v.Kind() == reflect.Slice {
+		list := make([]string, v.Len())
+		for i := range list {
+			list[i] = v.Index(i).String()
+		}
+		return strings.Join(list, ",")
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testDB struct {
+	Addr     string
+	Password string
+	Timeout  time.Duration
+}
+
+type testConfig struct {
+	Database *testDB
+	Server   struct {
+		Port    int
+		IsDevel bool
+		Origins []string
+	}
+	Sentry struct {
+		DSN string
+	}
+	Hook func()
+}
+
+func TestApplyEnv(t *testing.T) {
+	Convey("Test ApplyEnv", t, func() {
+		env := map[string]string{}
+		lookup := func(key string) (string, bool) {
+			v, ok := env[key]
+			return v, ok
+		}
+
+		Convey("Scalar and nested fields", func() {
+			env["APP_SERVER_PORT"] = "8080"
+			env["APP_SERVER_ISDEVEL"] = "true"
+			env["APP_SERVER_ORIGINS"] = "https://a.test, https://b.test"
+			env["APP_DATABASE_TIMEOUT"] = "5s"
+
+			var cfg testConfig
+			keys, err := ApplyEnv("APP", &cfg, lookup)
+			So(err, ShouldBeNil)
+			So(keys, ShouldResemble, []string{"Database.Timeout", "Server.Port", "Server.IsDevel", "Server.Origins"})
+			So(cfg.Database, ShouldNotBeNil)
+			So(cfg.Database.Timeout, ShouldEqual, 5*time.Second)
+			So(cfg.Server.Port, ShouldEqual, 8080)
+			So(cfg.Server.IsDevel, ShouldBeTrue)
+			So(cfg.Server.Origins, ShouldResemble, []string{"https://a.test", "https://b.test"})
+		})
+
+		Convey("Nil nested struct is kept without env", func() {
+			var cfg testConfig
+			keys, err := ApplyEnv("APP", &cfg, lookup)
+			So(err, ShouldBeNil)
+			So(keys, ShouldBeEmpty)
+			So(cfg.Database, ShouldBeNil)
+		})
+
+		Convey("Secret from file", func() {
+			file := filepath.Join(t.TempDir(), "password")
+			So(os.WriteFile(file, []byte("s3cret\n"), 0600), ShouldBeNil)
+			env["APP_DATABASE_PASSWORD_FILE"] = file
+
+			cfg := testConfig{Database: &testDB{Addr: "localhost:5432"}}
+			_, err := ApplyEnv("APP", &cfg, lookup)
+			So(err, ShouldBeNil)
+			So(cfg.Database.Password, ShouldEqual, "s3cret")
+			So(cfg.Database.Addr, ShouldEqual, "localhost:5432")
+
+			Convey("Value and file are ambiguous", func() {
+				env["APP_DATABASE_PASSWORD"] = "other"
+				_, err := ApplyEnv("APP", &cfg, lookup)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("Invalid value", func() {
+			env["APP_SERVER_PORT"] = "http"
+			var cfg testConfig
+			_, err := ApplyEnv("APP", &cfg, lookup)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "APP_SERVER_PORT")
+		})
+	})
+}
+
+func TestFields(t *testing.T) {
+	Convey("Test Fields", t, func() {
+		var cfg testConfig
+		cfg.Server.Port = 8080
+		cfg.Sentry.DSN = "https://key@sentry.test/1"
+
+		So(Fields("APP", &cfg), ShouldResemble, []Field{
+			{Key: "Server.Port", Env: "APP_SERVER_PORT", Value: "8080"},
+			{Key: "Server.IsDevel", Env: "APP_SERVER_ISDEVEL", Value: "false"},
+			{Key: "Server.Origins", Env: "APP_SERVER_ORIGINS", Value: ""},
+			{Key: "Sentry.DSN", Env: "APP_SENTRY_DSN", Value: redacted, Secret: true},
+		})
+
+		Convey("Changed", func() {
+			next := cfg
+			next.Server.Port = 8081
+			next.Database = &testDB{}
+			So(Changed(cfg, next), ShouldResemble, []string{"Database.Addr", "Database.Password", "Database.Timeout", "Server.Port"})
+			So(Changed(cfg, cfg), ShouldBeEmpty)
+		})
+	})
+}
@@ -20,13 +20,25 @@ func NewCommonRepo(db orm.DB) CommonRepo {
 	return CommonRepo{
 		db: db,
 		filters: map[string][]Filter{
-			Tables.User.Name: {StatusFilter},
+			Tables.APIToken.Name:  {StatusFilter},
+			Tables.AuditLog.Name:  {},
+			Tables.TrashItem.Name: {},
+			Tables.User.Name:      {StatusFilter},
+			Tables.UserToken.Name: {},
 		},
 		sort: map[string][]SortField{
-			Tables.User.Name: {{Column: Columns.User.CreatedAt, Direction: SortDesc}},
+			Tables.APIToken.Name:  {{Column: Columns.APIToken.CreatedAt, Direction: SortDesc}},
+			Tables.AuditLog.Name:  {{Column: Columns.AuditLog.CreatedAt, Direction: SortDesc}},
+			Tables.TrashItem.Name: {{Column: Columns.TrashItem.DeletedAt, Direction: SortDesc}},
+			Tables.User.Name:      {{Column: Columns.User.CreatedAt, Direction: SortDesc}},
+			Tables.UserToken.Name: {{Column: Columns.UserToken.CreatedAt, Direction: SortDesc}},
 		},
 		join: map[string][]string{
-			Tables.User.Name: {TableColumns},
+			Tables.APIToken.Name:  {TableColumns, Columns.APIToken.User},
+			Tables.AuditLog.Name:  {TableColumns, Columns.AuditLog.User, Columns.AuditLog.APIToken},
+			Tables.TrashItem.Name: {TableColumns, Columns.TrashItem.User, Columns.TrashItem.APIToken},
+			Tables.User.Name:      {TableColumns},
+			Tables.UserToken.Name: {TableColumns, Columns.UserToken.User},
 		},
 	}
 }
@@ -50,6 +62,202 @@ func (cr CommonRepo) WithEnabledOnly() CommonRepo {
 	return cr
 }
 
+/*** APIToken ***/
+
+// FullAPIToken returns full joins with all columns
+func (cr CommonRepo) FullAPIToken() OpFunc {
+	return WithColumns(cr.join[Tables.APIToken.Name]...)
+}
+
+// DefaultAPITokenSort returns default sort.
+func (cr CommonRepo) DefaultAPITokenSort() OpFunc {
+	return WithSort(cr.sort[Tables.APIToken.Name]...)
+}
+
+// APITokenByID is a function that returns APIToken by ID(s) or nil.
+func (cr CommonRepo) APITokenByID(ctx context.Context, id int, ops ...OpFunc) (*APIToken, error) {
+	return cr.OneAPIToken(ctx, &APITokenSearch{ID: &id}, ops...)
+}
+
+// OneAPIToken is a function that returns one APIToken by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneAPIToken(ctx context.Context, search *APITokenSearch, ops ...OpFunc) (*APIToken, error) {
+	obj := &APIToken{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.APIToken.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// APITokensByFilters returns APIToken list.
+func (cr CommonRepo) APITokensByFilters(ctx context.Context, search *APITokenSearch, pager Pager, ops ...OpFunc) (apiTokens []APIToken, err error) {
+	err = buildQuery(ctx, cr.db, &apiTokens, search, cr.filters[Tables.APIToken.Name], pager, ops...).Select()
+	return
+}
+
+// CountAPITokens returns count
+func (cr CommonRepo) CountAPITokens(ctx context.Context, search *APITokenSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &APIToken{}, search, cr.filters[Tables.APIToken.Name], PagerOne, ops...).Count()
+}
+
+// AddAPIToken adds APIToken to DB.
+func (cr CommonRepo) AddAPIToken(ctx context.Context, apiToken *APIToken, ops ...OpFunc) (*APIToken, error) {
+	q := cr.db.ModelContext(ctx, apiToken)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.APIToken.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return apiToken, err
+}
+
+// UpdateAPIToken updates APIToken in DB.
+func (cr CommonRepo) UpdateAPIToken(ctx context.Context, apiToken *APIToken, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, apiToken).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.APIToken.ID, Columns.APIToken.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteAPIToken set statusId to deleted in DB.
+func (cr CommonRepo) DeleteAPIToken(ctx context.Context, id int) (deleted bool, err error) {
+	apiToken := &APIToken{ID: id, StatusID: StatusDeleted}
+
+	return cr.UpdateAPIToken(ctx, apiToken, WithColumns(Columns.APIToken.StatusID))
+}
+
+/*** AuditLog ***/
+
+// FullAuditLog returns full joins with all columns
+func (cr CommonRepo) FullAuditLog() OpFunc {
+	return WithColumns(cr.join[Tables.AuditLog.Name]...)
+}
+
+// DefaultAuditLogSort returns default sort.
+func (cr CommonRepo) DefaultAuditLogSort() OpFunc {
+	return WithSort(cr.sort[Tables.AuditLog.Name]...)
+}
+
+// AuditLogByID is a function that returns AuditLog by ID(s) or nil.
+func (cr CommonRepo) AuditLogByID(ctx context.Context, id int, ops ...OpFunc) (*AuditLog, error) {
+	return cr.OneAuditLog(ctx, &AuditLogSearch{ID: &id}, ops...)
+}
+
+// OneAuditLog is a function that returns one AuditLog by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneAuditLog(ctx context.Context, search *AuditLogSearch, ops ...OpFunc) (*AuditLog, error) {
+	obj := &AuditLog{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.AuditLog.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// AuditLogsByFilters returns AuditLog list.
+func (cr CommonRepo) AuditLogsByFilters(ctx context.Context, search *AuditLogSearch, pager Pager, ops ...OpFunc) (auditLogs []AuditLog, err error) {
+	err = buildQuery(ctx, cr.db, &auditLogs, search, cr.filters[Tables.AuditLog.Name], pager, ops...).Select()
+	return
+}
+
+// CountAuditLogs returns count
+func (cr CommonRepo) CountAuditLogs(ctx context.Context, search *AuditLogSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &AuditLog{}, search, cr.filters[Tables.AuditLog.Name], PagerOne, ops...).Count()
+}
+
+// AddAuditLog adds AuditLog to DB.
+func (cr CommonRepo) AddAuditLog(ctx context.Context, auditLog *AuditLog, ops ...OpFunc) (*AuditLog, error) {
+	q := cr.db.ModelContext(ctx, auditLog)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.AuditLog.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return auditLog, err
+}
+
+/*** TrashItem ***/
+
+// FullTrashItem returns full joins with all columns
+func (cr CommonRepo) FullTrashItem() OpFunc {
+	return WithColumns(cr.join[Tables.TrashItem.Name]...)
+}
+
+// DefaultTrashItemSort returns default sort.
+func (cr CommonRepo) DefaultTrashItemSort() OpFunc {
+	return WithSort(cr.sort[Tables.TrashItem.Name]...)
+}
+
+// TrashItemByID is a function that returns TrashItem by ID(s) or nil.
+func (cr CommonRepo) TrashItemByID(ctx context.Context, id int, ops ...OpFunc) (*TrashItem, error) {
+	return cr.OneTrashItem(ctx, &TrashItemSearch{ID: &id}, ops...)
+}
+
+// OneTrashItem is a function that returns one TrashItem by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneTrashItem(ctx context.Context, search *TrashItemSearch, ops ...OpFunc) (*TrashItem, error) {
+	obj := &TrashItem{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.TrashItem.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// TrashItemsByFilters returns TrashItem list.
+func (cr CommonRepo) TrashItemsByFilters(ctx context.Context, search *TrashItemSearch, pager Pager, ops ...OpFunc) (trashItems []TrashItem, err error) {
+	err = buildQuery(ctx, cr.db, &trashItems, search, cr.filters[Tables.TrashItem.Name], pager, ops...).Select()
+	return
+}
+
+// CountTrashItems returns count
+func (cr CommonRepo) CountTrashItems(ctx context.Context, search *TrashItemSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &TrashItem{}, search, cr.filters[Tables.TrashItem.Name], PagerOne, ops...).Count()
+}
+
+// AddTrashItem adds TrashItem to DB.
+func (cr CommonRepo) AddTrashItem(ctx context.Context, trashItem *TrashItem, ops ...OpFunc) (*TrashItem, error) {
+	q := cr.db.ModelContext(ctx, trashItem)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.TrashItem.DeletedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return trashItem, err
+}
+
+// DeleteTrashItem deletes TrashItem from DB.
+func (cr CommonRepo) DeleteTrashItem(ctx context.Context, id int) (deleted bool, err error) {
+	trashItem := &TrashItem{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, trashItem).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
 /*** User ***/
 
 // FullUser returns full joins with all columns
@@ -125,3 +333,84 @@ func (cr CommonRepo) DeleteUser(ctx context.Context, id int) (deleted bool, err
 
 	return cr.UpdateUser(ctx, user, WithColumns(Columns.User.StatusID))
 }
+
+/*** UserToken ***/
+
+// FullUserToken returns full joins with all columns
+func (cr CommonRepo) FullUserToken() OpFunc {
+	return WithColumns(cr.join[Tables.UserToken.Name]...)
+}
+
+// DefaultUserTokenSort returns default sort.
+func (cr CommonRepo) DefaultUserTokenSort() OpFunc {
+	return WithSort(cr.sort[Tables.UserToken.Name]...)
+}
+
+// UserTokenByID is a function that returns UserToken by ID(s) or nil.
+func (cr CommonRepo) UserTokenByID(ctx context.Context, id int, ops ...OpFunc) (*UserToken, error) {
+	return cr.OneUserToken(ctx, &UserTokenSearch{ID: &id}, ops...)
+}
+
+// OneUserToken is a function that returns one UserToken by filters. It could return pg.ErrMultiRows.
+func (cr CommonRepo) OneUserToken(ctx context.Context, search *UserTokenSearch, ops ...OpFunc) (*UserToken, error) {
+	obj := &UserToken{}
+	err := buildQuery(ctx, cr.db, obj, search, cr.filters[Tables.UserToken.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// UserTokensByFilters returns UserToken list.
+func (cr CommonRepo) UserTokensByFilters(ctx context.Context, search *UserTokenSearch, pager Pager, ops ...OpFunc) (userTokens []UserToken, err error) {
+	err = buildQuery(ctx, cr.db, &userTokens, search, cr.filters[Tables.UserToken.Name], pager, ops...).Select()
+	return
+}
+
+// CountUserTokens returns count
+func (cr CommonRepo) CountUserTokens(ctx context.Context, search *UserTokenSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, cr.db, &UserToken{}, search, cr.filters[Tables.UserToken.Name], PagerOne, ops...).Count()
+}
+
+// AddUserToken adds UserToken to DB.
+func (cr CommonRepo) AddUserToken(ctx context.Context, userToken *UserToken, ops ...OpFunc) (*UserToken, error) {
+	q := cr.db.ModelContext(ctx, userToken)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.UserToken.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return userToken, err
+}
+
+// UpdateUserToken updates UserToken in DB.
+func (cr CommonRepo) UpdateUserToken(ctx context.Context, userToken *UserToken, ops ...OpFunc) (bool, error) {
+	q := cr.db.ModelContext(ctx, userToken).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.UserToken.ID, Columns.UserToken.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteUserToken deletes UserToken from DB.
+func (cr CommonRepo) DeleteUserToken(ctx context.Context, id int) (deleted bool, err error) {
+	userToken := &UserToken{ID: id}
+
+	res, err := cr.db.ModelContext(ctx, userToken).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
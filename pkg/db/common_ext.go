@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"time"
+
+	"github.com/go-pg/pg/v10"
 )
 
 // AuthenticateUser update authKey and last activity while user login/logout
@@ -32,3 +34,133 @@ func (cr CommonRepo) EnabledUserByLogin(ctx context.Context, login string) (*Use
 func (cr CommonRepo) UpdateUserPassword(ctx context.Context, dbu *User) (bool, error) {
 	return cr.UpdateUser(ctx, dbu, WithColumns(Columns.User.Password, Columns.User.AuthKey))
 }
+
+const (
+	UserTokenInvite = "invite"
+	UserTokenReset  = "reset"
+)
+
+// EnabledUserByLoginOrEmail returns enabled user with given login or email.
+func (cr CommonRepo) EnabledUserByLoginOrEmail(ctx context.Context, login string) (*User, error) {
+	s := StatusEnabled
+	search := &UserSearch{StatusID: &s}
+	search.With(`(?.? = ? OR lower(?.?) = lower(?))`,
+		pg.Ident(Tables.User.Alias), pg.Ident(Columns.User.Login), login,
+		pg.Ident(Tables.User.Alias), pg.Ident(Columns.User.Email), login,
+	)
+	return cr.OneUser(ctx, search)
+}
+
+// UserByEmail returns not deleted user by email in any case.
+func (cr CommonRepo) UserByEmail(ctx context.Context, email string) (*User, error) {
+	search := &UserSearch{}
+	search.With(`lower(?.?) = lower(?)`, pg.Ident(Tables.User.Alias), pg.Ident(Columns.User.Email), email)
+	return cr.OneUser(ctx, search)
+}
+
+// ActiveUserToken returns not used and not expired UserToken by token hash with User.
+func (cr CommonRepo) ActiveUserToken(ctx context.Context, tokenHash string) (*UserToken, error) {
+	search := &UserTokenSearch{Token: &tokenHash}
+	search.With(`?.? IS NULL`, pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.UsedAt))
+	search.With(`?.? > now()`, pg.Ident(Tables.UserToken.Alias), pg.Ident(Columns.UserToken.ExpiresAt))
+	return cr.OneUserToken(ctx, search, cr.FullUserToken())
+}
+
+// UseUserToken marks active UserToken as used by token hash. It returns nil if token is already used or expired,
+// so concurrent requests could not use the same token twice.
+func (cr CommonRepo) UseUserToken(ctx context.Context, tokenHash string) (*UserToken, error) {
+	ut := &UserToken{}
+	res, err := cr.db.ModelContext(ctx, ut).
+		Set(`? = now()`, pg.Ident(Columns.UserToken.UsedAt)).
+		Where(`? = ?`, pg.Ident(Columns.UserToken.Token), tokenHash).
+		Where(`? IS NULL`, pg.Ident(Columns.UserToken.UsedAt)).
+		Where(`? > now()`, pg.Ident(Columns.UserToken.ExpiresAt)).
+		Returning("*").
+		Update()
+	if err != nil {
+		return nil, err
+	} else if res.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	return ut, nil
+}
+
+// UseUserTokens marks all active tokens of the user as used.
+func (cr CommonRepo) UseUserTokens(ctx context.Context, userID int) error {
+	_, err := cr.db.ModelContext(ctx, &UserToken{}).
+		Set(`? = now()`, pg.Ident(Columns.UserToken.UsedAt)).
+		Where(`? = ?`, pg.Ident(Columns.UserToken.UserID), userID).
+		Where(`? IS NULL`, pg.Ident(Columns.UserToken.UsedAt)).
+		Update()
+	return err
+}
+
+// EnabledAPITokenByHash returns enabled and not expired APIToken of enabled User by token hash.
+func (cr CommonRepo) EnabledAPITokenByHash(ctx context.Context, tokenHash string) (*APIToken, error) {
+	s := StatusEnabled
+	search := &APITokenSearch{Token: &tokenHash, StatusID: &s}
+	search.With(`(?.? IS NULL OR ?.? > now())`,
+		pg.Ident(Tables.APIToken.Alias), pg.Ident(Columns.APIToken.ExpiresAt),
+		pg.Ident(Tables.APIToken.Alias), pg.Ident(Columns.APIToken.ExpiresAt),
+	)
+	search.With(`?.? IN (SELECT ? FROM ? WHERE ? = ?)`,
+		pg.Ident(Tables.APIToken.Alias), pg.Ident(Columns.APIToken.UserID),
+		pg.Ident(Columns.User.ID), pg.Ident(Tables.User.Name), pg.Ident(Columns.User.StatusID), StatusEnabled,
+	)
+	return cr.OneAPIToken(ctx, search, cr.FullAPIToken())
+}
+
+// UpdateAPITokenUsage updates last usage time of APIToken.
+func (cr CommonRepo) UpdateAPITokenUsage(ctx context.Context, token *APIToken) (bool, error) {
+	now := time.Now()
+	token.LastUsedAt = &now
+	return cr.UpdateAPIToken(ctx, token, WithColumns(Columns.APIToken.LastUsedAt))
+}
+
+// DeleteAuditLogsBefore removes AuditLog records created before given time.
+func (cr CommonRepo) DeleteAuditLogsBefore(ctx context.Context, before time.Time) (int, error) {
+	res, err := cr.db.ModelContext(ctx, &AuditLog{}).
+		Where(`?.? < ?`, pg.Ident(Tables.AuditLog.Alias), pg.Ident(Columns.AuditLog.CreatedAt), before).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// UpdateUserVersion updates User only if its version was not changed since User had been read and increments the version.
+// Creation and last activity time are not updated. It returns false if User was changed by someone else.
+func (cr CommonRepo) UpdateUserVersion(ctx context.Context, user *User) (bool, error) {
+	version := user.Version
+	user.Version++
+	ok, err := cr.UpdateUser(ctx, user,
+		WithoutColumns(Columns.User.ID, Columns.User.CreatedAt, Columns.User.LastActivityAt),
+		withVersion(Columns.User.Version, version),
+	)
+	if err != nil || !ok {
+		user.Version = version
+	}
+	return ok, err
+}
+
+// RestoreUser sets status of deleted User.
+func (cr CommonRepo) RestoreUser(ctx context.Context, id, statusID int) (bool, error) {
+	return restoreDeleted(ctx, cr.db, (*User)(nil), Columns.User.ID, id, statusID)
+}
+
+// PurgeUser removes deleted User. Its api and email tokens are removed by ON DELETE CASCADE,
+// references in revisions, transitions, tag merges, audit logs and trash are set to NULL by schema.
+func (cr CommonRepo) PurgeUser(ctx context.Context, id int) (bool, error) {
+	return purgeDeleted(ctx, cr.db, (*User)(nil), Columns.User.ID, id)
+}
+
+// DeleteTrashItemsByEntity removes all trash records of the entity.
+func (cr CommonRepo) DeleteTrashItemsByEntity(ctx context.Context, entityType string, entityID int) error {
+	_, err := cr.db.ModelContext(ctx, (*TrashItem)(nil)).
+		Where(`? = ?`, pg.Ident(Columns.TrashItem.EntityType), entityType).
+		Where(`? = ?`, pg.Ident(Columns.TrashItem.EntityID), entityID).
+		Delete()
+	return err
+}
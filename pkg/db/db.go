@@ -4,8 +4,10 @@ package db
 
 import (
 	"context"
+	"errors"
 	"hash/crc64"
 	"reflect"
+	"time"
 
 	"github.com/go-pg/pg/v10"
 	"github.com/go-pg/pg/v10/orm"
@@ -19,14 +21,48 @@ type DB struct {
 	embedlog.Logger
 
 	crcTable *crc64.Table
+	replicas *Replicas
 }
 
 // New is a function that returns DB as wrapper on postgres connection.
 func New(db *pg.DB) DB {
-	d := DB{DB: db, crcTable: crc64.MakeTable(crc64.ECMA)}
+	return DB{DB: db, crcTable: crc64.MakeTable(crc64.ECMA)}
+}
+
+// WithReplicas returns DB which sends read-only queries of repositories to healthy replicas.
+// Writes and queries in transactions are sent to primary.
+func (db DB) WithReplicas(rs *Replicas) DB {
+	db.replicas = rs
+	return db
+}
+
+// Primary returns DB which sends all queries to primary.
+func (db DB) Primary() DB {
+	db.replicas = nil
+	return db
+}
+
+// Replicas returns replicas of DB or nil.
+func (db DB) Replicas() *Replicas {
+	return db.replicas
+}
+
+// reader returns healthy replica for read-only query or primary.
+func (db DB) reader() orm.DB {
+	if r := db.replicas.pick(); r != nil {
+		return r
+	}
 
-	d.SetStdLoggers(true)
-	return d
+	return db.DB
+}
+
+// primary returns connection of primary for DB with replicas.
+func primary(db orm.DB) orm.DB {
+	if d, ok := db.(DB); ok {
+		return d.Primary()
+	}
+
+	return db
 }
 
 // Version is a function that returns Postgres version.
@@ -39,6 +75,38 @@ func (db *DB) Version() (string, error) {
 	return v, nil
 }
 
+// statementTimeoutGap is added to statement timeout of transaction, so query is cancelled by context deadline first.
+// Statement timeout only guards database when cancel request is not delivered.
+const statementTimeoutGap = 100 * time.Millisecond
+
+// RunInTransaction runs fn in transaction. If ctx has deadline, statement timeout of transaction is limited by it.
+// Queries outside of transactions get no statement timeout, they are cancelled by cancel request on ctx deadline only.
+func (db DB) RunInTransaction(ctx context.Context, fn func(*pg.Tx) error) error {
+	return db.DB.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if err := setStatementTimeout(ctx, tx); err != nil {
+			return err
+		}
+
+		return fn(tx)
+	})
+}
+
+// setStatementTimeout sets statement timeout of transaction by deadline of ctx.
+func setStatementTimeout(ctx context.Context, tx *pg.Tx) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	left := time.Until(deadline)
+	if left <= 0 {
+		return context.DeadlineExceeded
+	}
+
+	_, err := tx.ExecContext(ctx, "SET LOCAL statement_timeout = ?", (left + statementTimeoutGap).Milliseconds())
+	return err
+}
+
 // runInTransaction runs chain of functions in transaction until first error
 func (db *DB) runInTransaction(ctx context.Context, fns ...func(*pg.Tx) error) error {
 	return db.RunInTransaction(ctx, func(tx *pg.Tx) error {
@@ -70,9 +138,44 @@ func (db *DB) RunInLock(ctx context.Context, lockName string, fns ...func(*pg.Tx
 	})
 }
 
-// buildQuery applies all functions to orm query.
+// ErrEntityInUse is returned when deleted entity could not be purged because other rows reference it.
+var ErrEntityInUse = errors.New("entity is in use")
+
+// restoreDeleted sets status of deleted row and increments its version.
+func restoreDeleted(ctx context.Context, db orm.DB, model interface{}, pk string, id, statusID int) (bool, error) {
+	res, err := db.ModelContext(ctx, model).
+		Set(`? = ?`, pg.Ident(StatusFilter.Field), statusID).
+		Set(`? = ? + 1`, pg.Ident(versionColumn), pg.Ident(versionColumn)).
+		Where(`? = ?`, pg.Ident(pk), id).
+		Where(`? = ?`, pg.Ident(StatusFilter.Field), StatusDeleted).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// purgeDeleted removes deleted row from DB.
+func purgeDeleted(ctx context.Context, db orm.DB, model interface{}, pk string, id int) (bool, error) {
+	res, err := db.ModelContext(ctx, model).
+		Where(`? = ?`, pg.Ident(pk), id).
+		Where(`? = ?`, pg.Ident(StatusFilter.Field), StatusDeleted).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// buildQuery applies all functions to orm query. Query of DB with replicas is sent to healthy replica.
 func buildQuery(ctx context.Context, db orm.DB, model interface{}, search Searcher, filters []Filter, pager Pager, ops ...OpFunc) *orm.Query {
-	q := db.ModelContext(ctx, model)
+	if d, ok := db.(DB); ok {
+		db = d.reader()
+	}
+
+	q := db.ModelContext(withCallerOperation(ctx), model)
 	for _, filter := range filters {
 		filter.Apply(q)
 	}
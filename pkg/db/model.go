@@ -9,19 +9,69 @@ import (
 )
 
 var Columns = struct {
+	APIToken struct {
+		ID, UserID, Title, Token, Prefix, Scopes, CreatedAt, ExpiresAt, LastUsedAt, StatusID string
+
+		User string
+	}
+	AuditLog struct {
+		ID, CreatedAt, UserID, APITokenID, Namespace, Method, EntityType, EntityID, Before, After, RequestID, IP string
+
+		User, APIToken string
+	}
+	Author struct {
+		ID, Name, Alias, Bio, AvatarHash, StatusID, Version string
+	}
 	Category struct {
-		ID, Title, OrderNumber, Alias, StatusID string
+		ID, Title, ParentCategoryID, OrderNumber, Alias, StatusID, Version string
+
+		ParentCategory string
 	}
 	News struct {
-		ID, Title, CategoryID, Foreword, Content, TagIDs, Author, PublishedAt, StatusID string
+		ID, Title, CategoryID, Foreword, Content, Author, PublishedAt, UnpublishAt, StatusID, State, Version string
+
+		Category, Tags, Authors string
+	}
+	NewsAuthor struct {
+		NewsID, AuthorID, Position string
+
+		News, Author string
+	}
+	NewsRevision struct {
+		ID, NewsID, Revision, UserID, CreatedAt, Title, CategoryID, Foreword, Content, TagIDs, AuthorIDs, Author, PublishedAt, UnpublishAt, StatusID string
+
+		News, User string
+	}
+	NewsTag struct {
+		NewsID, TagID, Position string
+
+		News, Tag string
+	}
+	NewsTransition struct {
+		ID, NewsID, UserID, APITokenID, FromState, ToState, Reason, CreatedAt string
 
-		Category string
+		News, User, APIToken string
 	}
 	Tag struct {
-		ID, Title, StatusID string
+		ID, Title, Alias, StatusID, Version string
+	}
+	TagMerge struct {
+		ID, SourceTagID, SourceTitle, TargetTagID, UserID, MergedAt string
+
+		TargetTag, User string
+	}
+	TrashItem struct {
+		ID, EntityType, EntityID, Title, PreviousStatusID, DeletedAt, UserID, APITokenID string
+
+		User, APIToken string
 	}
 	User struct {
-		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID string
+		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID, Email, Role, Version string
+	}
+	UserToken struct {
+		ID, UserID, Type, Token, CreatedAt, ExpiresAt, UsedAt string
+
+		User string
 	}
 	VfsFile struct {
 		ID, FolderID, Title, Path, Params, IsFavorite, MimeType, FileSize, FileExists, CreatedAt, StatusID string
@@ -34,41 +84,201 @@ var Columns = struct {
 		ParentFolder string
 	}
 }{
+	APIToken: struct {
+		ID, UserID, Title, Token, Prefix, Scopes, CreatedAt, ExpiresAt, LastUsedAt, StatusID string
+
+		User string
+	}{
+		ID:         "apiTokenId",
+		UserID:     "userId",
+		Title:      "title",
+		Token:      "token",
+		Prefix:     "prefix",
+		Scopes:     "scopes",
+		CreatedAt:  "createdAt",
+		ExpiresAt:  "expiresAt",
+		LastUsedAt: "lastUsedAt",
+		StatusID:   "statusId",
+
+		User: "User",
+	},
+	AuditLog: struct {
+		ID, CreatedAt, UserID, APITokenID, Namespace, Method, EntityType, EntityID, Before, After, RequestID, IP string
+
+		User, APIToken string
+	}{
+		ID:         "auditLogId",
+		CreatedAt:  "createdAt",
+		UserID:     "userId",
+		APITokenID: "apiTokenId",
+		Namespace:  "namespace",
+		Method:     "method",
+		EntityType: "entityType",
+		EntityID:   "entityId",
+		Before:     "before",
+		After:      "after",
+		RequestID:  "requestId",
+		IP:         "ip",
+
+		User:     "User",
+		APIToken: "APIToken",
+	},
+	Author: struct {
+		ID, Name, Alias, Bio, AvatarHash, StatusID, Version string
+	}{
+		ID:         "authorId",
+		Name:       "name",
+		Alias:      "alias",
+		Bio:        "bio",
+		AvatarHash: "avatarHash",
+		StatusID:   "statusId",
+		Version:    "version",
+	},
 	Category: struct {
-		ID, Title, OrderNumber, Alias, StatusID string
+		ID, Title, ParentCategoryID, OrderNumber, Alias, StatusID, Version string
+
+		ParentCategory string
 	}{
-		ID:          "categoryId",
-		Title:       "title",
-		OrderNumber: "orderNumber",
-		Alias:       "alias",
-		StatusID:    "statusId",
+		ID:               "categoryId",
+		Title:            "title",
+		ParentCategoryID: "parentCategoryId",
+		OrderNumber:      "orderNumber",
+		Alias:            "alias",
+		StatusID:         "statusId",
+		Version:          "version",
+
+		ParentCategory: "ParentCategory",
 	},
 	News: struct {
-		ID, Title, CategoryID, Foreword, Content, TagIDs, Author, PublishedAt, StatusID string
+		ID, Title, CategoryID, Foreword, Content, Author, PublishedAt, UnpublishAt, StatusID, State, Version string
 
-		Category string
+		Category, Tags, Authors string
 	}{
 		ID:          "newsId",
 		Title:       "title",
 		CategoryID:  "categoryId",
 		Foreword:    "foreword",
 		Content:     "content",
-		TagIDs:      "tagIds",
 		Author:      "author",
 		PublishedAt: "publishedAt",
+		UnpublishAt: "unpublishAt",
 		StatusID:    "statusId",
+		State:       "state",
+		Version:     "version",
 
 		Category: "Category",
+		Tags:     "Tags",
+		Authors:  "Authors",
+	},
+	NewsAuthor: struct {
+		NewsID, AuthorID, Position string
+
+		News, Author string
+	}{
+		NewsID:   "newsId",
+		AuthorID: "authorId",
+		Position: "position",
+
+		News:   "News",
+		Author: "Author",
+	},
+	NewsRevision: struct {
+		ID, NewsID, Revision, UserID, CreatedAt, Title, CategoryID, Foreword, Content, TagIDs, AuthorIDs, Author, PublishedAt, UnpublishAt, StatusID string
+
+		News, User string
+	}{
+		ID:          "newsRevisionId",
+		NewsID:      "newsId",
+		Revision:    "revision",
+		UserID:      "userId",
+		CreatedAt:   "createdAt",
+		Title:       "title",
+		CategoryID:  "categoryId",
+		Foreword:    "foreword",
+		Content:     "content",
+		TagIDs:      "tagIds",
+		AuthorIDs:   "authorIds",
+		Author:      "author",
+		PublishedAt: "publishedAt",
+		UnpublishAt: "unpublishAt",
+		StatusID:    "statusId",
+
+		News: "News",
+		User: "User",
+	},
+	NewsTag: struct {
+		NewsID, TagID, Position string
+
+		News, Tag string
+	}{
+		NewsID:   "newsId",
+		TagID:    "tagId",
+		Position: "position",
+
+		News: "News",
+		Tag:  "Tag",
+	},
+	NewsTransition: struct {
+		ID, NewsID, UserID, APITokenID, FromState, ToState, Reason, CreatedAt string
+
+		News, User, APIToken string
+	}{
+		ID:         "newsTransitionId",
+		NewsID:     "newsId",
+		UserID:     "userId",
+		APITokenID: "apiTokenId",
+		FromState:  "fromState",
+		ToState:    "toState",
+		Reason:     "reason",
+		CreatedAt:  "createdAt",
+
+		News:     "News",
+		User:     "User",
+		APIToken: "APIToken",
 	},
 	Tag: struct {
-		ID, Title, StatusID string
+		ID, Title, Alias, StatusID, Version string
 	}{
 		ID:       "tagId",
 		Title:    "title",
+		Alias:    "alias",
 		StatusID: "statusId",
+		Version:  "version",
+	},
+	TagMerge: struct {
+		ID, SourceTagID, SourceTitle, TargetTagID, UserID, MergedAt string
+
+		TargetTag, User string
+	}{
+		ID:          "tagMergeId",
+		SourceTagID: "sourceTagId",
+		SourceTitle: "sourceTitle",
+		TargetTagID: "targetTagId",
+		UserID:      "userId",
+		MergedAt:    "mergedAt",
+
+		TargetTag: "TargetTag",
+		User:      "User",
+	},
+	TrashItem: struct {
+		ID, EntityType, EntityID, Title, PreviousStatusID, DeletedAt, UserID, APITokenID string
+
+		User, APIToken string
+	}{
+		ID:               "trashItemId",
+		EntityType:       "entityType",
+		EntityID:         "entityId",
+		Title:            "title",
+		PreviousStatusID: "previousStatusId",
+		DeletedAt:        "deletedAt",
+		UserID:           "userId",
+		APITokenID:       "apiTokenId",
+
+		User:     "User",
+		APIToken: "APIToken",
 	},
 	User: struct {
-		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID string
+		ID, CreatedAt, Login, Password, AuthKey, LastActivityAt, StatusID, Email, Role, Version string
 	}{
 		ID:             "userId",
 		CreatedAt:      "createdAt",
@@ -77,6 +287,24 @@ var Columns = struct {
 		AuthKey:        "authKey",
 		LastActivityAt: "lastActivityAt",
 		StatusID:       "statusId",
+		Email:          "email",
+		Role:           "role",
+		Version:        "version",
+	},
+	UserToken: struct {
+		ID, UserID, Type, Token, CreatedAt, ExpiresAt, UsedAt string
+
+		User string
+	}{
+		ID:        "userTokenId",
+		UserID:    "userId",
+		Type:      "type",
+		Token:     "token",
+		CreatedAt: "createdAt",
+		ExpiresAt: "expiresAt",
+		UsedAt:    "usedAt",
+
+		User: "User",
 	},
 	VfsFile: struct {
 		ID, FolderID, Title, Path, Params, IsFavorite, MimeType, FileSize, FileExists, CreatedAt, StatusID string
@@ -114,18 +342,48 @@ var Columns = struct {
 }
 
 var Tables = struct {
+	APIToken struct {
+		Name, Alias string
+	}
+	AuditLog struct {
+		Name, Alias string
+	}
+	Author struct {
+		Name, Alias string
+	}
 	Category struct {
 		Name, Alias string
 	}
 	News struct {
 		Name, Alias string
 	}
+	NewsAuthor struct {
+		Name, Alias string
+	}
+	NewsRevision struct {
+		Name, Alias string
+	}
+	NewsTag struct {
+		Name, Alias string
+	}
+	NewsTransition struct {
+		Name, Alias string
+	}
 	Tag struct {
 		Name, Alias string
 	}
+	TagMerge struct {
+		Name, Alias string
+	}
+	TrashItem struct {
+		Name, Alias string
+	}
 	User struct {
 		Name, Alias string
 	}
+	UserToken struct {
+		Name, Alias string
+	}
 	VfsFile struct {
 		Name, Alias string
 	}
@@ -133,6 +391,24 @@ var Tables = struct {
 		Name, Alias string
 	}
 }{
+	APIToken: struct {
+		Name, Alias string
+	}{
+		Name:  "apiTokens",
+		Alias: "t",
+	},
+	AuditLog: struct {
+		Name, Alias string
+	}{
+		Name:  "auditLogs",
+		Alias: "t",
+	},
+	Author: struct {
+		Name, Alias string
+	}{
+		Name:  "authors",
+		Alias: "t",
+	},
 	Category: struct {
 		Name, Alias string
 	}{
@@ -145,18 +421,60 @@ var Tables = struct {
 		Name:  "news",
 		Alias: "t",
 	},
+	NewsAuthor: struct {
+		Name, Alias string
+	}{
+		Name:  "newsAuthors",
+		Alias: "na",
+	},
+	NewsRevision: struct {
+		Name, Alias string
+	}{
+		Name:  "newsRevisions",
+		Alias: "t",
+	},
+	NewsTag: struct {
+		Name, Alias string
+	}{
+		Name:  "newsTags",
+		Alias: "nt",
+	},
+	NewsTransition: struct {
+		Name, Alias string
+	}{
+		Name:  "newsTransitions",
+		Alias: "t",
+	},
 	Tag: struct {
 		Name, Alias string
 	}{
 		Name:  "tags",
 		Alias: "t",
 	},
+	TagMerge: struct {
+		Name, Alias string
+	}{
+		Name:  "tagMerges",
+		Alias: "t",
+	},
+	TrashItem: struct {
+		Name, Alias string
+	}{
+		Name:  "trashItems",
+		Alias: "t",
+	},
 	User: struct {
 		Name, Alias string
 	}{
 		Name:  "users",
 		Alias: "t",
 	},
+	UserToken: struct {
+		Name, Alias string
+	}{
+		Name:  "userTokens",
+		Alias: "t",
+	},
 	VfsFile: struct {
 		Name, Alias string
 	}{
@@ -171,30 +489,151 @@ var Tables = struct {
 	},
 }
 
+type APIToken struct {
+	tableName struct{} `pg:"apiTokens,alias:t,discard_unknown_columns"`
+
+	ID         int        `pg:"apiTokenId,pk"`
+	UserID     int        `pg:"userId,use_zero"`
+	Title      string     `pg:"title,use_zero"`
+	Token      string     `pg:"token,use_zero"`
+	Prefix     string     `pg:"prefix,use_zero"`
+	Scopes     []string   `pg:"scopes,array,use_zero"`
+	CreatedAt  time.Time  `pg:"createdAt,use_zero"`
+	ExpiresAt  *time.Time `pg:"expiresAt"`
+	LastUsedAt *time.Time `pg:"lastUsedAt"`
+	StatusID   int        `pg:"statusId,use_zero"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type AuditLog struct {
+	tableName struct{} `pg:"auditLogs,alias:t,discard_unknown_columns"`
+
+	ID         int                    `pg:"auditLogId,pk"`
+	CreatedAt  time.Time              `pg:"createdAt,use_zero"`
+	UserID     *int                   `pg:"userId"`
+	APITokenID *int                   `pg:"apiTokenId"`
+	Namespace  string                 `pg:"namespace,use_zero"`
+	Method     string                 `pg:"method,use_zero"`
+	EntityType *string                `pg:"entityType"`
+	EntityID   *int                   `pg:"entityId"`
+	Before     map[string]interface{} `pg:"before"`
+	After      map[string]interface{} `pg:"after"`
+	RequestID  *string                `pg:"requestId"`
+	IP         *string                `pg:"ip"`
+
+	User     *User     `pg:"fk:userId,rel:has-one"`
+	APIToken *APIToken `pg:"fk:apiTokenId,rel:has-one"`
+}
+
+type Author struct {
+	tableName struct{} `pg:"authors,alias:t,discard_unknown_columns"`
+
+	ID         int     `pg:"authorId,pk"`
+	Name       string  `pg:"name,use_zero"`
+	Alias      string  `pg:"alias,use_zero"`
+	Bio        *string `pg:"bio"`
+	AvatarHash *string `pg:"avatarHash"`
+	StatusID   int     `pg:"statusId,use_zero"`
+	Version    int     `pg:"version"`
+}
+
 type Category struct {
 	tableName struct{} `pg:"categories,alias:t,discard_unknown_columns"`
 
-	ID          int    `pg:"categoryId,pk"`
-	Title       string `pg:"title,use_zero"`
-	OrderNumber *int   `pg:"orderNumber"`
-	Alias       string `pg:"alias,use_zero"`
-	StatusID    int    `pg:"statusId,use_zero"`
+	ID               int    `pg:"categoryId,pk"`
+	Title            string `pg:"title,use_zero"`
+	ParentCategoryID *int   `pg:"parentCategoryId"`
+	OrderNumber      *int   `pg:"orderNumber"`
+	Alias            string `pg:"alias,use_zero"`
+	StatusID         int    `pg:"statusId,use_zero"`
+	Version          int    `pg:"version"`
+
+	ParentCategory *Category `pg:"fk:parentCategoryId,rel:has-one"`
 }
 
 type News struct {
 	tableName struct{} `pg:"news,alias:t,discard_unknown_columns"`
 
-	ID          int       `pg:"newsId,pk"`
-	Title       string    `pg:"title,use_zero"`
-	CategoryID  int       `pg:"categoryId,use_zero"`
-	Foreword    string    `pg:"foreword,use_zero"`
-	Content     *string   `pg:"content"`
-	TagIDs      []int     `pg:"tagIds,array,use_zero"`
-	Author      string    `pg:"author,use_zero"`
-	PublishedAt time.Time `pg:"publishedAt,use_zero"`
-	StatusID    int       `pg:"statusId,use_zero"`
+	ID          int        `pg:"newsId,pk"`
+	Title       string     `pg:"title,use_zero"`
+	CategoryID  int        `pg:"categoryId,use_zero"`
+	Foreword    string     `pg:"foreword,use_zero"`
+	Content     *string    `pg:"content"`
+	TagIDs      []int      `pg:"-"`
+	AuthorIDs   []int      `pg:"-"`
+	Author      string     `pg:"author,use_zero"`
+	PublishedAt time.Time  `pg:"publishedAt,use_zero"`
+	UnpublishAt *time.Time `pg:"unpublishAt"`
+	StatusID    int        `pg:"statusId,use_zero"`
+	State       string     `pg:"state,use_zero"`
+	Version     int        `pg:"version"`
 
 	Category *Category `pg:"fk:categoryId,rel:has-one"`
+	Tags     []Tag     `pg:"many2many:newsTags,fk:newsId,join_fk:tagId"`
+	Authors  []Author  `pg:"many2many:newsAuthors,fk:newsId,join_fk:authorId"`
+}
+
+type NewsAuthor struct {
+	tableName struct{} `pg:"newsAuthors,alias:na,discard_unknown_columns"`
+
+	NewsID   int `pg:"newsId,pk"`
+	AuthorID int `pg:"authorId,pk"`
+	Position int `pg:"position,use_zero"`
+
+	News   *News   `pg:"fk:newsId,rel:has-one"`
+	Author *Author `pg:"fk:authorId,rel:has-one"`
+}
+
+type NewsRevision struct {
+	tableName struct{} `pg:"newsRevisions,alias:t,discard_unknown_columns"`
+
+	ID          int        `pg:"newsRevisionId,pk"`
+	NewsID      int        `pg:"newsId,use_zero"`
+	Revision    int        `pg:"revision,use_zero"`
+	UserID      *int       `pg:"userId"`
+	CreatedAt   time.Time  `pg:"createdAt,use_zero"`
+	Title       string     `pg:"title,use_zero"`
+	CategoryID  int        `pg:"categoryId,use_zero"`
+	Foreword    string     `pg:"foreword,use_zero"`
+	Content     *string    `pg:"content"`
+	TagIDs      []int      `pg:"tagIds,array,use_zero"`
+	AuthorIDs   []int      `pg:"authorIds,array,use_zero"`
+	Author      string     `pg:"author,use_zero"`
+	PublishedAt time.Time  `pg:"publishedAt,use_zero"`
+	UnpublishAt *time.Time `pg:"unpublishAt"`
+	StatusID    int        `pg:"statusId,use_zero"`
+
+	News *News `pg:"fk:newsId,rel:has-one"`
+	User *User `pg:"fk:userId,rel:has-one"`
+}
+
+type NewsTag struct {
+	tableName struct{} `pg:"newsTags,alias:nt,discard_unknown_columns"`
+
+	NewsID   int `pg:"newsId,pk"`
+	TagID    int `pg:"tagId,pk"`
+	Position int `pg:"position,use_zero"`
+
+	News *News `pg:"fk:newsId,rel:has-one"`
+	Tag  *Tag  `pg:"fk:tagId,rel:has-one"`
+}
+
+type NewsTransition struct {
+	tableName struct{} `pg:"newsTransitions,alias:t,discard_unknown_columns"`
+
+	ID         int       `pg:"newsTransitionId,pk"`
+	NewsID     int       `pg:"newsId,use_zero"`
+	UserID     *int      `pg:"userId"`
+	APITokenID *int      `pg:"apiTokenId"`
+	FromState  string    `pg:"fromState,use_zero"`
+	ToState    string    `pg:"toState,use_zero"`
+	Reason     *string   `pg:"reason"`
+	CreatedAt  time.Time `pg:"createdAt,use_zero"`
+
+	News     *News     `pg:"fk:newsId,rel:has-one"`
+	User     *User     `pg:"fk:userId,rel:has-one"`
+	APIToken *APIToken `pg:"fk:apiTokenId,rel:has-one"`
 }
 
 type Tag struct {
@@ -202,7 +641,39 @@ type Tag struct {
 
 	ID       int    `pg:"tagId,pk"`
 	Title    string `pg:"title,use_zero"`
+	Alias    string `pg:"alias,use_zero"`
 	StatusID int    `pg:"statusId,use_zero"`
+	Version  int    `pg:"version"`
+}
+
+type TagMerge struct {
+	tableName struct{} `pg:"tagMerges,alias:t,discard_unknown_columns"`
+
+	ID          int       `pg:"tagMergeId,pk"`
+	SourceTagID int       `pg:"sourceTagId,use_zero"`
+	SourceTitle string    `pg:"sourceTitle,use_zero"`
+	TargetTagID int       `pg:"targetTagId,use_zero"`
+	UserID      *int      `pg:"userId"`
+	MergedAt    time.Time `pg:"mergedAt,use_zero"`
+
+	TargetTag *Tag  `pg:"fk:targetTagId,rel:has-one"`
+	User      *User `pg:"fk:userId,rel:has-one"`
+}
+
+type TrashItem struct {
+	tableName struct{} `pg:"trashItems,alias:t,discard_unknown_columns"`
+
+	ID               int       `pg:"trashItemId,pk"`
+	EntityType       string    `pg:"entityType,use_zero"`
+	EntityID         int       `pg:"entityId,use_zero"`
+	Title            string    `pg:"title,use_zero"`
+	PreviousStatusID int       `pg:"previousStatusId,use_zero"`
+	DeletedAt        time.Time `pg:"deletedAt,use_zero"`
+	UserID           *int      `pg:"userId"`
+	APITokenID       *int      `pg:"apiTokenId"`
+
+	User     *User     `pg:"fk:userId,rel:has-one"`
+	APIToken *APIToken `pg:"fk:apiTokenId,rel:has-one"`
 }
 
 type User struct {
@@ -215,6 +686,23 @@ type User struct {
 	AuthKey        string     `pg:"authKey,use_zero"`
 	LastActivityAt *time.Time `pg:"lastActivityAt"`
 	StatusID       int        `pg:"statusId,use_zero"`
+	Email          *string    `pg:"email"`
+	Role           string     `pg:"role,use_zero"`
+	Version        int        `pg:"version"`
+}
+
+type UserToken struct {
+	tableName struct{} `pg:"userTokens,alias:t,discard_unknown_columns"`
+
+	ID        int        `pg:"userTokenId,pk"`
+	UserID    int        `pg:"userId,use_zero"`
+	Type      string     `pg:"type,use_zero"`
+	Token     string     `pg:"token,use_zero"`
+	CreatedAt time.Time  `pg:"createdAt,use_zero"`
+	ExpiresAt time.Time  `pg:"expiresAt,use_zero"`
+	UsedAt    *time.Time `pg:"usedAt"`
+
+	User *User `pg:"fk:userId,rel:has-one"`
 }
 
 type VfsFile struct {
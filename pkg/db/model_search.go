@@ -52,17 +52,218 @@ type Searcher interface {
 	WithApply(a applier)
 }
 
+type APITokenSearch struct {
+	search
+
+	ID         *int
+	UserID     *int
+	Title      *string
+	Token      *string
+	Prefix     *string
+	CreatedAt  *time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	StatusID   *int
+	IDs        []int
+	TitleILike *string
+}
+
+func (ats *APITokenSearch) Apply(query *orm.Query) *orm.Query {
+	if ats == nil {
+		return query
+	}
+	if ats.ID != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.ID, ats.ID)
+	}
+	if ats.UserID != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.UserID, ats.UserID)
+	}
+	if ats.Title != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.Title, ats.Title)
+	}
+	if ats.Token != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.Token, ats.Token)
+	}
+	if ats.Prefix != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.Prefix, ats.Prefix)
+	}
+	if ats.CreatedAt != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.CreatedAt, ats.CreatedAt)
+	}
+	if ats.ExpiresAt != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.ExpiresAt, ats.ExpiresAt)
+	}
+	if ats.LastUsedAt != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.LastUsedAt, ats.LastUsedAt)
+	}
+	if ats.StatusID != nil {
+		ats.where(query, Tables.APIToken.Alias, Columns.APIToken.StatusID, ats.StatusID)
+	}
+	if len(ats.IDs) > 0 {
+		Filter{Columns.APIToken.ID, ats.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if ats.TitleILike != nil {
+		Filter{Columns.APIToken.Title, *ats.TitleILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	ats.apply(query)
+
+	return query
+}
+
+func (ats *APITokenSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ats == nil {
+			return query, nil
+		}
+		return ats.Apply(query), nil
+	}
+}
+
+type AuditLogSearch struct {
+	search
+
+	ID            *int
+	UserID        *int
+	APITokenID    *int
+	Namespace     *string
+	Method        *string
+	EntityType    *string
+	EntityID      *int
+	RequestID     *string
+	IP            *string
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+	IDs           []int
+}
+
+func (als *AuditLogSearch) Apply(query *orm.Query) *orm.Query {
+	if als == nil {
+		return query
+	}
+	if als.ID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.ID, als.ID)
+	}
+	if als.UserID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.UserID, als.UserID)
+	}
+	if als.APITokenID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.APITokenID, als.APITokenID)
+	}
+	if als.Namespace != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.Namespace, als.Namespace)
+	}
+	if als.Method != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.Method, als.Method)
+	}
+	if als.EntityType != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.EntityType, als.EntityType)
+	}
+	if als.EntityID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.EntityID, als.EntityID)
+	}
+	if als.RequestID != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.RequestID, als.RequestID)
+	}
+	if als.IP != nil {
+		als.where(query, Tables.AuditLog.Alias, Columns.AuditLog.IP, als.IP)
+	}
+	if als.CreatedAtFrom != nil {
+		Filter{Columns.AuditLog.CreatedAt, *als.CreatedAtFrom, SearchTypeGE, false}.Apply(query)
+	}
+	if als.CreatedAtTo != nil {
+		Filter{Columns.AuditLog.CreatedAt, *als.CreatedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+	if len(als.IDs) > 0 {
+		Filter{Columns.AuditLog.ID, als.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	als.apply(query)
+
+	return query
+}
+
+func (als *AuditLogSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if als == nil {
+			return query, nil
+		}
+		return als.Apply(query), nil
+	}
+}
+
+type AuthorSearch struct {
+	search
+
+	ID         *int
+	Name       *string
+	Alias      *string
+	Bio        *string
+	AvatarHash *string
+	StatusID   *int
+	IDs        []int
+	NotID      *int
+	NameILike  *string
+}
+
+func (as *AuthorSearch) Apply(query *orm.Query) *orm.Query {
+	if as == nil {
+		return query
+	}
+	if as.ID != nil {
+		as.where(query, Tables.Author.Alias, Columns.Author.ID, as.ID)
+	}
+	if as.Name != nil {
+		as.where(query, Tables.Author.Alias, Columns.Author.Name, as.Name)
+	}
+	if as.Alias != nil {
+		as.where(query, Tables.Author.Alias, Columns.Author.Alias, as.Alias)
+	}
+	if as.Bio != nil {
+		as.where(query, Tables.Author.Alias, Columns.Author.Bio, as.Bio)
+	}
+	if as.AvatarHash != nil {
+		as.where(query, Tables.Author.Alias, Columns.Author.AvatarHash, as.AvatarHash)
+	}
+	if as.StatusID != nil {
+		as.where(query, Tables.Author.Alias, Columns.Author.StatusID, as.StatusID)
+	}
+	if len(as.IDs) > 0 {
+		Filter{Columns.Author.ID, as.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if as.NotID != nil {
+		Filter{Columns.Author.ID, *as.NotID, SearchTypeEquals, true}.Apply(query)
+	}
+	if as.NameILike != nil {
+		Filter{Columns.Author.Name, *as.NameILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	as.apply(query)
+
+	return query
+}
+
+func (as *AuthorSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if as == nil {
+			return query, nil
+		}
+		return as.Apply(query), nil
+	}
+}
+
 type CategorySearch struct {
 	search
 
-	ID          *int
-	Title       *string
-	OrderNumber *int
-	Alias       *string
-	StatusID    *int
-	IDs         []int
-	NotID       *int
-	TitleILike  *string
+	ID               *int
+	Title            *string
+	ParentCategoryID *int
+	OrderNumber      *int
+	Alias            *string
+	StatusID         *int
+	IDs              []int
+	NotID            *int
+	TitleILike       *string
 }
 
 func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
@@ -75,6 +276,9 @@ func (cs *CategorySearch) Apply(query *orm.Query) *orm.Query {
 	if cs.Title != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.Title, cs.Title)
 	}
+	if cs.ParentCategoryID != nil {
+		cs.where(query, Tables.Category.Alias, Columns.Category.ParentCategoryID, cs.ParentCategoryID)
+	}
 	if cs.OrderNumber != nil {
 		cs.where(query, Tables.Category.Alias, Columns.Category.OrderNumber, cs.OrderNumber)
 	}
@@ -111,20 +315,27 @@ func (cs *CategorySearch) Q() applier {
 type NewsSearch struct {
 	search
 
-	ID            *int
-	Title         *string
-	CategoryID    *int
-	Foreword      *string
-	Content       *string
-	Author        *string
-	PublishedAt   *time.Time
-	StatusID      *int
-	IDs           []int
-	TitleILike    *string
-	ForewordILike *string
-	ContentILike  *string
-	AuthorILike   *string
-	TagIDsILike   *int
+	ID             *int
+	Title          *string
+	CategoryID     *int
+	Foreword       *string
+	Content        *string
+	Author         *string
+	PublishedAt    *time.Time
+	StatusID       *int
+	State          *string
+	IDs            []int
+	TitleILike     *string
+	ForewordILike  *string
+	ContentILike   *string
+	AuthorILike    *string
+	TagID          *int
+	TagIDs         []int
+	AuthorID       *int
+	CategoryTreeID *int
+	States         []string
+	PublishedAtTo  *time.Time
+	UnpublishAtTo  *time.Time
 }
 
 func (ns *NewsSearch) Apply(query *orm.Query) *orm.Query {
@@ -155,6 +366,9 @@ func (ns *NewsSearch) Apply(query *orm.Query) *orm.Query {
 	if ns.StatusID != nil {
 		ns.where(query, Tables.News.Alias, Columns.News.StatusID, ns.StatusID)
 	}
+	if ns.State != nil {
+		ns.where(query, Tables.News.Alias, Columns.News.State, ns.State)
+	}
 	if len(ns.IDs) > 0 {
 		Filter{Columns.News.ID, ns.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -170,8 +384,26 @@ func (ns *NewsSearch) Apply(query *orm.Query) *orm.Query {
 	if ns.AuthorILike != nil {
 		Filter{Columns.News.Author, *ns.AuthorILike, SearchTypeILike, false}.Apply(query)
 	}
-	if ns.TagIDsILike != nil {
-		Filter{Columns.News.TagIDs, *ns.TagIDsILike, SearchTypeArrayContains, false}.Apply(query)
+	if ns.TagID != nil {
+		ns.whereTags(query, []int{*ns.TagID})
+	}
+	if len(ns.TagIDs) > 0 {
+		ns.whereTags(query, ns.TagIDs)
+	}
+	if ns.AuthorID != nil {
+		ns.whereAuthor(query, *ns.AuthorID)
+	}
+	if ns.CategoryTreeID != nil {
+		ns.whereCategoryTree(query, *ns.CategoryTreeID)
+	}
+	if len(ns.States) > 0 {
+		Filter{Columns.News.State, ns.States, SearchTypeArray, false}.Apply(query)
+	}
+	if ns.PublishedAtTo != nil {
+		Filter{Columns.News.PublishedAt, *ns.PublishedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+	if ns.UnpublishAtTo != nil {
+		Filter{Columns.News.UnpublishAt, *ns.UnpublishAtTo, SearchTypeLE, false}.Apply(query)
 	}
 
 	ns.apply(query)
@@ -188,14 +420,157 @@ func (ns *NewsSearch) Q() applier {
 	}
 }
 
+type NewsRevisionSearch struct {
+	search
+
+	ID          *int
+	NewsID      *int
+	Revision    *int
+	UserID      *int
+	CreatedAt   *time.Time
+	Title       *string
+	CategoryID  *int
+	Foreword    *string
+	Content     *string
+	Author      *string
+	PublishedAt *time.Time
+	UnpublishAt *time.Time
+	StatusID    *int
+	IDs         []int
+}
+
+func (nrs *NewsRevisionSearch) Apply(query *orm.Query) *orm.Query {
+	if nrs == nil {
+		return query
+	}
+	if nrs.ID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.ID, nrs.ID)
+	}
+	if nrs.NewsID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.NewsID, nrs.NewsID)
+	}
+	if nrs.Revision != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Revision, nrs.Revision)
+	}
+	if nrs.UserID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.UserID, nrs.UserID)
+	}
+	if nrs.CreatedAt != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.CreatedAt, nrs.CreatedAt)
+	}
+	if nrs.Title != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Title, nrs.Title)
+	}
+	if nrs.CategoryID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.CategoryID, nrs.CategoryID)
+	}
+	if nrs.Foreword != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Foreword, nrs.Foreword)
+	}
+	if nrs.Content != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Content, nrs.Content)
+	}
+	if nrs.Author != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.Author, nrs.Author)
+	}
+	if nrs.PublishedAt != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.PublishedAt, nrs.PublishedAt)
+	}
+	if nrs.UnpublishAt != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.UnpublishAt, nrs.UnpublishAt)
+	}
+	if nrs.StatusID != nil {
+		nrs.where(query, Tables.NewsRevision.Alias, Columns.NewsRevision.StatusID, nrs.StatusID)
+	}
+	if len(nrs.IDs) > 0 {
+		Filter{Columns.NewsRevision.ID, nrs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	nrs.apply(query)
+
+	return query
+}
+
+func (nrs *NewsRevisionSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if nrs == nil {
+			return query, nil
+		}
+		return nrs.Apply(query), nil
+	}
+}
+
+type NewsTransitionSearch struct {
+	search
+
+	ID         *int
+	NewsID     *int
+	UserID     *int
+	APITokenID *int
+	FromState  *string
+	ToState    *string
+	Reason     *string
+	CreatedAt  *time.Time
+	IDs        []int
+}
+
+func (nts *NewsTransitionSearch) Apply(query *orm.Query) *orm.Query {
+	if nts == nil {
+		return query
+	}
+	if nts.ID != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.ID, nts.ID)
+	}
+	if nts.NewsID != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.NewsID, nts.NewsID)
+	}
+	if nts.UserID != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.UserID, nts.UserID)
+	}
+	if nts.APITokenID != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.APITokenID, nts.APITokenID)
+	}
+	if nts.FromState != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.FromState, nts.FromState)
+	}
+	if nts.ToState != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.ToState, nts.ToState)
+	}
+	if nts.Reason != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.Reason, nts.Reason)
+	}
+	if nts.CreatedAt != nil {
+		nts.where(query, Tables.NewsTransition.Alias, Columns.NewsTransition.CreatedAt, nts.CreatedAt)
+	}
+	if len(nts.IDs) > 0 {
+		Filter{Columns.NewsTransition.ID, nts.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	nts.apply(query)
+
+	return query
+}
+
+func (nts *NewsTransitionSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if nts == nil {
+			return query, nil
+		}
+		return nts.Apply(query), nil
+	}
+}
+
 type TagSearch struct {
 	search
 
 	ID         *int
 	Title      *string
+	Alias      *string
 	StatusID   *int
 	IDs        []int
+	NotID      *int
 	TitleILike *string
+	TitleFold  *string
 }
 
 func (ts *TagSearch) Apply(query *orm.Query) *orm.Query {
@@ -208,15 +583,24 @@ func (ts *TagSearch) Apply(query *orm.Query) *orm.Query {
 	if ts.Title != nil {
 		ts.where(query, Tables.Tag.Alias, Columns.Tag.Title, ts.Title)
 	}
+	if ts.Alias != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.Alias, ts.Alias)
+	}
 	if ts.StatusID != nil {
 		ts.where(query, Tables.Tag.Alias, Columns.Tag.StatusID, ts.StatusID)
 	}
 	if len(ts.IDs) > 0 {
 		Filter{Columns.Tag.ID, ts.IDs, SearchTypeArray, false}.Apply(query)
 	}
+	if ts.NotID != nil {
+		Filter{Columns.Tag.ID, *ts.NotID, SearchTypeEquals, true}.Apply(query)
+	}
 	if ts.TitleILike != nil {
 		Filter{Columns.Tag.Title, *ts.TitleILike, SearchTypeILike, false}.Apply(query)
 	}
+	if ts.TitleFold != nil {
+		ts.whereTitleFold(query, *ts.TitleFold)
+	}
 
 	ts.apply(query)
 
@@ -232,6 +616,126 @@ func (ts *TagSearch) Q() applier {
 	}
 }
 
+type TagMergeSearch struct {
+	search
+
+	ID          *int
+	SourceTagID *int
+	SourceTitle *string
+	TargetTagID *int
+	UserID      *int
+	MergedAt    *time.Time
+	IDs         []int
+}
+
+func (tms *TagMergeSearch) Apply(query *orm.Query) *orm.Query {
+	if tms == nil {
+		return query
+	}
+	if tms.ID != nil {
+		tms.where(query, Tables.TagMerge.Alias, Columns.TagMerge.ID, tms.ID)
+	}
+	if tms.SourceTagID != nil {
+		tms.where(query, Tables.TagMerge.Alias, Columns.TagMerge.SourceTagID, tms.SourceTagID)
+	}
+	if tms.SourceTitle != nil {
+		tms.where(query, Tables.TagMerge.Alias, Columns.TagMerge.SourceTitle, tms.SourceTitle)
+	}
+	if tms.TargetTagID != nil {
+		tms.where(query, Tables.TagMerge.Alias, Columns.TagMerge.TargetTagID, tms.TargetTagID)
+	}
+	if tms.UserID != nil {
+		tms.where(query, Tables.TagMerge.Alias, Columns.TagMerge.UserID, tms.UserID)
+	}
+	if tms.MergedAt != nil {
+		tms.where(query, Tables.TagMerge.Alias, Columns.TagMerge.MergedAt, tms.MergedAt)
+	}
+	if len(tms.IDs) > 0 {
+		Filter{Columns.TagMerge.ID, tms.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	tms.apply(query)
+
+	return query
+}
+
+func (tms *TagMergeSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if tms == nil {
+			return query, nil
+		}
+		return tms.Apply(query), nil
+	}
+}
+
+type TrashItemSearch struct {
+	search
+
+	ID               *int
+	EntityType       *string
+	EntityID         *int
+	Title            *string
+	PreviousStatusID *int
+	DeletedAt        *time.Time
+	UserID           *int
+	APITokenID       *int
+	IDs              []int
+	TitleILike       *string
+	DeletedAtTo      *time.Time
+}
+
+func (tis *TrashItemSearch) Apply(query *orm.Query) *orm.Query {
+	if tis == nil {
+		return query
+	}
+	if tis.ID != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.ID, tis.ID)
+	}
+	if tis.EntityType != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.EntityType, tis.EntityType)
+	}
+	if tis.EntityID != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.EntityID, tis.EntityID)
+	}
+	if tis.Title != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.Title, tis.Title)
+	}
+	if tis.PreviousStatusID != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.PreviousStatusID, tis.PreviousStatusID)
+	}
+	if tis.DeletedAt != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.DeletedAt, tis.DeletedAt)
+	}
+	if tis.UserID != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.UserID, tis.UserID)
+	}
+	if tis.APITokenID != nil {
+		tis.where(query, Tables.TrashItem.Alias, Columns.TrashItem.APITokenID, tis.APITokenID)
+	}
+	if len(tis.IDs) > 0 {
+		Filter{Columns.TrashItem.ID, tis.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if tis.TitleILike != nil {
+		Filter{Columns.TrashItem.Title, *tis.TitleILike, SearchTypeILike, false}.Apply(query)
+	}
+	if tis.DeletedAtTo != nil {
+		Filter{Columns.TrashItem.DeletedAt, *tis.DeletedAtTo, SearchTypeLE, false}.Apply(query)
+	}
+
+	tis.apply(query)
+
+	return query
+}
+
+func (tis *TrashItemSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if tis == nil {
+			return query, nil
+		}
+		return tis.Apply(query), nil
+	}
+}
+
 type UserSearch struct {
 	search
 
@@ -242,6 +746,8 @@ type UserSearch struct {
 	AuthKey            *string
 	LastActivityAt     *time.Time
 	StatusID           *int
+	Email              *string
+	Role               *string
 	IDs                []int
 	NotID              *int
 	LoginILike         *string
@@ -249,6 +755,7 @@ type UserSearch struct {
 	AuthKeyILike       *string
 	LastActivityAtFrom *time.Time
 	LastActivityAtTo   *time.Time
+	EmailILike         *string
 }
 
 func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
@@ -276,6 +783,12 @@ func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
 	if us.StatusID != nil {
 		us.where(query, Tables.User.Alias, Columns.User.StatusID, us.StatusID)
 	}
+	if us.Email != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Email, us.Email)
+	}
+	if us.Role != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Role, us.Role)
+	}
 	if len(us.IDs) > 0 {
 		Filter{Columns.User.ID, us.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -297,6 +810,9 @@ func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
 	if us.LastActivityAtTo != nil {
 		Filter{Columns.User.LastActivityAt, *us.LastActivityAtTo, SearchTypeLE, false}.Apply(query)
 	}
+	if us.EmailILike != nil {
+		Filter{Columns.User.Email, *us.EmailILike, SearchTypeILike, false}.Apply(query)
+	}
 
 	us.apply(query)
 
@@ -312,6 +828,62 @@ func (us *UserSearch) Q() applier {
 	}
 }
 
+type UserTokenSearch struct {
+	search
+
+	ID        *int
+	UserID    *int
+	Type      *string
+	Token     *string
+	CreatedAt *time.Time
+	ExpiresAt *time.Time
+	UsedAt    *time.Time
+	IDs       []int
+}
+
+func (uts *UserTokenSearch) Apply(query *orm.Query) *orm.Query {
+	if uts == nil {
+		return query
+	}
+	if uts.ID != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.ID, uts.ID)
+	}
+	if uts.UserID != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.UserID, uts.UserID)
+	}
+	if uts.Type != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.Type, uts.Type)
+	}
+	if uts.Token != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.Token, uts.Token)
+	}
+	if uts.CreatedAt != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.CreatedAt, uts.CreatedAt)
+	}
+	if uts.ExpiresAt != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.ExpiresAt, uts.ExpiresAt)
+	}
+	if uts.UsedAt != nil {
+		uts.where(query, Tables.UserToken.Alias, Columns.UserToken.UsedAt, uts.UsedAt)
+	}
+	if len(uts.IDs) > 0 {
+		Filter{Columns.UserToken.ID, uts.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	uts.apply(query)
+
+	return query
+}
+
+func (uts *UserTokenSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if uts == nil {
+			return query, nil
+		}
+		return uts.Apply(query), nil
+	}
+}
+
 type VfsFileSearch struct {
 	search
 
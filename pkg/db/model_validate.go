@@ -14,6 +14,68 @@ const (
 	ErrWrongValue = "value"
 )
 
+func (at APIToken) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(at.Title) > 128 {
+		errors[Columns.APIToken.Title] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(at.Token) > 64 {
+		errors[Columns.APIToken.Token] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(at.Prefix) > 8 {
+		errors[Columns.APIToken.Prefix] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (al AuditLog) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(al.Namespace) > 32 {
+		errors[Columns.AuditLog.Namespace] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(al.Method) > 64 {
+		errors[Columns.AuditLog.Method] = ErrMaxLength
+	}
+
+	if al.EntityType != nil && utf8.RuneCountInString(*al.EntityType) > 32 {
+		errors[Columns.AuditLog.EntityType] = ErrMaxLength
+	}
+
+	if al.RequestID != nil && utf8.RuneCountInString(*al.RequestID) > 64 {
+		errors[Columns.AuditLog.RequestID] = ErrMaxLength
+	}
+
+	if al.IP != nil && utf8.RuneCountInString(*al.IP) > 64 {
+		errors[Columns.AuditLog.IP] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (a Author) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(a.Name) > 128 {
+		errors[Columns.Author.Name] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(a.Alias) > 128 {
+		errors[Columns.Author.Alias] = ErrMaxLength
+	}
+
+	if a.AvatarHash != nil && utf8.RuneCountInString(*a.AvatarHash) > 40 {
+		errors[Columns.Author.AvatarHash] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
 func (c Category) Validate() (errors map[string]string, valid bool) {
 	errors = map[string]string{}
 
@@ -43,6 +105,42 @@ func (n News) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.News.Author] = ErrMaxLength
 	}
 
+	if utf8.RuneCountInString(n.State) > 16 {
+		errors[Columns.News.State] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (nr NewsRevision) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(nr.Title) > 255 {
+		errors[Columns.NewsRevision.Title] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(nr.Foreword) > 1024 {
+		errors[Columns.NewsRevision.Foreword] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(nr.Author) > 64 {
+		errors[Columns.NewsRevision.Author] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (nt NewsTransition) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(nt.FromState) > 16 {
+		errors[Columns.NewsTransition.FromState] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(nt.ToState) > 16 {
+		errors[Columns.NewsTransition.ToState] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
 
@@ -53,6 +151,34 @@ func (t Tag) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.Tag.Title] = ErrMaxLength
 	}
 
+	if utf8.RuneCountInString(t.Alias) > 128 {
+		errors[Columns.Tag.Alias] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (tm TagMerge) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(tm.SourceTitle) > 128 {
+		errors[Columns.TagMerge.SourceTitle] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (ti TrashItem) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(ti.EntityType) > 32 {
+		errors[Columns.TrashItem.EntityType] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(ti.Title) > 255 {
+		errors[Columns.TrashItem.Title] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
 
@@ -63,7 +189,7 @@ func (u User) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.User.Login] = ErrMaxLength
 	}
 
-	if utf8.RuneCountInString(u.Password) > 64 {
+	if utf8.RuneCountInString(u.Password) > 255 {
 		errors[Columns.User.Password] = ErrMaxLength
 	}
 
@@ -71,6 +197,28 @@ func (u User) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.User.AuthKey] = ErrMaxLength
 	}
 
+	if u.Email != nil && utf8.RuneCountInString(*u.Email) > 255 {
+		errors[Columns.User.Email] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.Role) > 16 {
+		errors[Columns.User.Role] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (ut UserToken) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(ut.Type) > 16 {
+		errors[Columns.UserToken.Type] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(ut.Token) > 64 {
+		errors[Columns.UserToken.Token] = ErrMaxLength
+	}
+
 	return errors, len(errors) == 0
 }
 
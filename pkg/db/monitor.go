@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"apisrv/pkg/embedlog"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultSlowQueryThreshold = time.Second
+	explainTimeout            = 5 * time.Second
+	maxFingerprintLen         = 256
+)
+
+type SlowQueryConfig struct {
+	Threshold time.Duration // queries which take longer are logged, default 1s, negative value disables logging
+	Explain   bool          // log plan of slow select queries, it works only in devel mode
+}
+
+func (c SlowQueryConfig) threshold() time.Duration {
+	if c.Threshold == 0 {
+		return defaultSlowQueryThreshold
+	}
+	return c.Threshold
+}
+
+type operationKey struct{}
+
+// explainKey marks EXPLAIN queries of monitor, so they are not explained again.
+type explainKey struct{}
+
+// WithOperation returns context with operation name. Queries with it are labelled by operation in metrics instead of query fingerprint.
+// Repositories name their read queries by method, e.g. NewsRepo.NewsByFilters, if operation is not set.
+func WithOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// OperationFromContext returns operation name from context or empty string.
+func OperationFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+	return name
+}
+
+// withCallerOperation sets name of caller of function which calls it as operation if it is not set.
+func withCallerOperation(ctx context.Context) context.Context {
+	if OperationFromContext(ctx) != "" {
+		return ctx
+	}
+
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return ctx
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ctx
+	}
+
+	// apisrv/pkg/db.NewsRepo.NewsByFilters -> NewsRepo.NewsByFilters
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return WithOperation(ctx, name)
+}
+
+// QueryMonitor is a query hook which records query durations, logs slow queries and explains them in devel mode.
+type QueryMonitor struct {
+	embedlog.Logger
+	cfg       SlowQueryConfig
+	explainDB *pg.DB // nil if explain is disabled
+	durations *prometheus.HistogramVec
+}
+
+// NewQueryMonitor returns QueryMonitor. If explainDB is not nil and explain is enabled, plans of slow queries are logged.
+func NewQueryMonitor(appName string, logger embedlog.Logger, cfg SlowQueryConfig, explainDB *pg.DB) *QueryMonitor {
+	qm := &QueryMonitor{
+		Logger: logger,
+		cfg:    cfg,
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: appName,
+			Subsystem: "postgres",
+			Name:      "query_duration_seconds",
+			Help:      "Query duration by operation name or normalized query.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"query"}),
+	}
+
+	if cfg.Explain {
+		qm.explainDB = explainDB
+	}
+
+	return qm
+}
+
+// Describe implements prometheus.Collector.
+func (qm *QueryMonitor) Describe(ch chan<- *prometheus.Desc) { qm.durations.Describe(ch) }
+
+// Collect implements prometheus.Collector.
+func (qm *QueryMonitor) Collect(ch chan<- prometheus.Metric) { qm.durations.Collect(ch) }
+
+func (qm *QueryMonitor) BeforeQuery(ctx context.Context, _ *pg.QueryEvent) (context.Context, error) {
+	return ctx, nil
+}
+
+func (qm *QueryMonitor) AfterQuery(ctx context.Context, event *pg.QueryEvent) error {
+	duration := time.Since(event.StartTime)
+
+	query, err := event.UnformattedQuery()
+	if err != nil {
+		return nil
+	}
+	fingerprint := Fingerprint(string(query))
+
+	label := OperationFromContext(ctx)
+	if label == "" {
+		label = fingerprint
+	}
+	qm.durations.WithLabelValues(label).Observe(duration.Seconds())
+
+	if threshold := qm.cfg.threshold(); threshold < 0 || duration < threshold || ctx.Value(explainKey{}) != nil {
+		return nil
+	}
+
+	qm.Warn(ctx, "slow query", "duration", duration, "operation", OperationFromContext(ctx), "query", fingerprint)
+	if qm.explainDB != nil && isSelect(fingerprint) {
+		formatted, err := event.FormattedQuery()
+		if err == nil {
+			go qm.explain(ctx, string(formatted))
+		}
+	}
+
+	return nil
+}
+
+// explain logs plan of query, it is used only in devel mode because plan contains query parameters.
+func (qm *QueryMonitor) explain(ctx context.Context, query string) {
+	ctx, cancel := context.WithTimeout(context.WithValue(context.WithoutCancel(ctx), explainKey{}, true), explainTimeout)
+	defer cancel()
+
+	var plan []string
+	if _, err := qm.explainDB.QueryContext(ctx, &plan, "EXPLAIN ?", pg.Safe(query)); err != nil {
+		qm.Warn(ctx, "explain slow query", "err", err)
+		return
+	}
+
+	qm.Info(ctx, "slow query plan", "query", query, "plan", strings.Join(plan, "\n"))
+}
+
+var (
+	// literalRe matches quoted identifiers, string and numeric literals.
+	literalRe = regexp.MustCompile(`"(?:[^"]|"")*"|'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+	listRe    = regexp.MustCompile(`\?(?:\s*,\s*\?)+`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint returns normalized query: literals are replaced with placeholders, lists of placeholders are collapsed.
+// It does not contain query parameters, so it could be logged and used as metric label.
+func Fingerprint(query string) string {
+	query = literalRe.ReplaceAllStringFunc(query, func(s string) string {
+		if strings.HasPrefix(s, `"`) {
+			return s
+		}
+		return "?"
+	})
+	query = listRe.ReplaceAllString(query, "?")
+	query = strings.TrimSpace(spaceRe.ReplaceAllString(query, " "))
+
+	if len(query) > maxFingerprintLen {
+		query = query[:maxFingerprintLen]
+	}
+
+	return query
+}
+
+// isSelect checks that query only reads data.
+func isSelect(query string) bool {
+	op := queryOperation(query)
+	return op == "SELECT" || op == "WITH"
+}
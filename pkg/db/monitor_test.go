@@ -0,0 +1,81 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"apisrv/pkg/embedlog"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// queryOperationOf is called by repository-like method in tests.
+func queryOperationOf(ctx context.Context) string {
+	return OperationFromContext(withCallerOperation(ctx))
+}
+
+func (nr NewsRepo) testNewsByFilters(ctx context.Context) string {
+	return queryOperationOf(ctx)
+}
+
+func TestFingerprint(t *testing.T) {
+	Convey("Test Fingerprint", t, func() {
+		So(Fingerprint(`SELECT "t"."newsId" FROM "news" AS "t" WHERE ("t"."newsId" IN (1, 2,3)) AND "title" = 'it''s'  LIMIT 10`), ShouldEqual,
+			`SELECT "t"."newsId" FROM "news" AS "t" WHERE ("t"."newsId" IN (?)) AND "title" = ? LIMIT ?`)
+		So(Fingerprint("select pg_advisory_xact_lock(?) -- ?"), ShouldEqual, "select pg_advisory_xact_lock(?) -- ?")
+		So(Fingerprint(`SELECT "t1"."id"`+"\n\t"+`FROM t1 WHERE x > 1.5`), ShouldEqual, `SELECT "t1"."id" FROM t1 WHERE x > ?`)
+	})
+}
+
+func TestOperation(t *testing.T) {
+	Convey("Test query operation", t, func() {
+		Convey("Operation is named by repository method", func() {
+			So(NewsRepo{}.testNewsByFilters(context.Background()), ShouldEqual, "NewsRepo.testNewsByFilters")
+		})
+
+		Convey("Operation from context is not replaced", func() {
+			ctx := WithOperation(context.Background(), "scheduler.process")
+			So(NewsRepo{}.testNewsByFilters(ctx), ShouldEqual, "scheduler.process")
+		})
+	})
+}
+
+func TestQueryMonitor(t *testing.T) {
+	Convey("Test QueryMonitor", t, func() {
+		var buf bytes.Buffer
+		logger := embedlog.New(&buf, embedlog.FormatJSON, &embedlog.Levels{})
+		qm := NewQueryMonitor("test", logger, SlowQueryConfig{Threshold: time.Second}, nil)
+
+		event := func(query string, took time.Duration) *pg.QueryEvent {
+			return &pg.QueryEvent{StartTime: time.Now().Add(-took), Query: query}
+		}
+
+		Convey("Fast query is recorded only in metrics", func() {
+			So(qm.AfterQuery(context.Background(), event("select * from news where id = 5", time.Millisecond)), ShouldBeNil)
+			So(qm.AfterQuery(WithOperation(context.Background(), "NewsRepo.OneNews"), event("select 1", time.Millisecond)), ShouldBeNil)
+
+			So(testutil.CollectAndCount(qm), ShouldEqual, 2)
+			So(buf.Len(), ShouldEqual, 0)
+		})
+
+		Convey("Slow query is logged without parameters", func() {
+			ctx := WithOperation(context.Background(), "NewsRepo.NewsByFilters")
+			So(qm.AfterQuery(ctx, event("select * from news where title = 'secret'", 2*time.Second)), ShouldBeNil)
+
+			So(buf.String(), ShouldContainSubstring, `"msg":"slow query"`)
+			So(buf.String(), ShouldContainSubstring, `"operation":"NewsRepo.NewsByFilters"`)
+			So(buf.String(), ShouldContainSubstring, `where title = ?`)
+			So(buf.String(), ShouldNotContainSubstring, "secret")
+		})
+
+		Convey("Negative threshold disables logging", func() {
+			qm := NewQueryMonitor("test", logger, SlowQueryConfig{Threshold: -1}, nil)
+			So(qm.AfterQuery(context.Background(), event("select 1", time.Hour)), ShouldBeNil)
+			So(buf.Len(), ShouldEqual, 0)
+		})
+	})
+}
@@ -20,19 +20,31 @@ func NewNewsRepo(db orm.DB) NewsRepo {
 	return NewsRepo{
 		db: db,
 		filters: map[string][]Filter{
-			Tables.Category.Name: {StatusFilter},
-			Tables.News.Name:     {StatusFilter},
-			Tables.Tag.Name:      {StatusFilter},
+			Tables.Author.Name:         {StatusFilter},
+			Tables.Category.Name:       {StatusFilter},
+			Tables.News.Name:           {StatusFilter},
+			Tables.NewsRevision.Name:   {},
+			Tables.NewsTransition.Name: {},
+			Tables.Tag.Name:            {StatusFilter},
+			Tables.TagMerge.Name:       {},
 		},
 		sort: map[string][]SortField{
-			Tables.Category.Name: {{Column: Columns.Category.Title, Direction: SortAsc}},
-			Tables.News.Name:     {{Column: Columns.News.Title, Direction: SortAsc}},
-			Tables.Tag.Name:      {{Column: Columns.Tag.Title, Direction: SortAsc}},
+			Tables.Author.Name:         {{Column: Columns.Author.Name, Direction: SortAsc}},
+			Tables.Category.Name:       {{Column: Columns.Category.Title, Direction: SortAsc}},
+			Tables.News.Name:           {{Column: Columns.News.Title, Direction: SortAsc}},
+			Tables.NewsRevision.Name:   {{Column: Columns.NewsRevision.Revision, Direction: SortDesc}},
+			Tables.NewsTransition.Name: {{Column: Columns.NewsTransition.CreatedAt, Direction: SortDesc}},
+			Tables.Tag.Name:            {{Column: Columns.Tag.Title, Direction: SortAsc}},
+			Tables.TagMerge.Name:       {{Column: Columns.TagMerge.MergedAt, Direction: SortDesc}},
 		},
 		join: map[string][]string{
-			Tables.Category.Name: {TableColumns},
-			Tables.News.Name:     {TableColumns, Columns.News.Category},
-			Tables.Tag.Name:      {TableColumns},
+			Tables.Author.Name:         {TableColumns},
+			Tables.Category.Name:       {TableColumns, Columns.Category.ParentCategory},
+			Tables.News.Name:           {TableColumns, Columns.News.Category},
+			Tables.NewsRevision.Name:   {TableColumns, Columns.NewsRevision.User},
+			Tables.NewsTransition.Name: {TableColumns, Columns.NewsTransition.User, Columns.NewsTransition.APIToken},
+			Tables.Tag.Name:            {TableColumns},
+			Tables.TagMerge.Name:       {TableColumns, Columns.TagMerge.TargetTag, Columns.TagMerge.User},
 		},
 	}
 }
@@ -56,6 +68,79 @@ func (nr NewsRepo) WithEnabledOnly() NewsRepo {
 	return nr
 }
 
+/*** Author ***/
+
+// FullAuthor returns full joins with all columns
+func (nr NewsRepo) FullAuthor() OpFunc {
+	return WithColumns(nr.join[Tables.Author.Name]...)
+}
+
+// DefaultAuthorSort returns default sort.
+func (nr NewsRepo) DefaultAuthorSort() OpFunc {
+	return WithSort(nr.sort[Tables.Author.Name]...)
+}
+
+// AuthorByID is a function that returns Author by ID(s) or nil.
+func (nr NewsRepo) AuthorByID(ctx context.Context, id int, ops ...OpFunc) (*Author, error) {
+	return nr.OneAuthor(ctx, &AuthorSearch{ID: &id}, ops...)
+}
+
+// OneAuthor is a function that returns one Author by filters. It could return pg.ErrMultiRows.
+func (nr NewsRepo) OneAuthor(ctx context.Context, search *AuthorSearch, ops ...OpFunc) (*Author, error) {
+	obj := &Author{}
+	err := buildQuery(ctx, nr.db, obj, search, nr.filters[Tables.Author.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// AuthorsByFilters returns Author list.
+func (nr NewsRepo) AuthorsByFilters(ctx context.Context, search *AuthorSearch, pager Pager, ops ...OpFunc) (authors []Author, err error) {
+	err = buildQuery(ctx, nr.db, &authors, search, nr.filters[Tables.Author.Name], pager, ops...).Select()
+	return
+}
+
+// CountAuthors returns count
+func (nr NewsRepo) CountAuthors(ctx context.Context, search *AuthorSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, nr.db, &Author{}, search, nr.filters[Tables.Author.Name], PagerOne, ops...).Count()
+}
+
+// AddAuthor adds Author to DB.
+func (nr NewsRepo) AddAuthor(ctx context.Context, author *Author, ops ...OpFunc) (*Author, error) {
+	q := nr.db.ModelContext(ctx, author)
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return author, err
+}
+
+// UpdateAuthor updates Author in DB.
+func (nr NewsRepo) UpdateAuthor(ctx context.Context, author *Author, ops ...OpFunc) (bool, error) {
+	q := nr.db.ModelContext(ctx, author).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Author.ID)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteAuthor set statusId to deleted in DB.
+func (nr NewsRepo) DeleteAuthor(ctx context.Context, id int) (deleted bool, err error) {
+	author := &Author{ID: id, StatusID: StatusDeleted}
+
+	return nr.UpdateAuthor(ctx, author, WithColumns(Columns.Author.StatusID))
+}
+
 /*** Category ***/
 
 // FullCategory returns full joins with all columns
@@ -202,6 +287,114 @@ func (nr NewsRepo) DeleteNews(ctx context.Context, id int) (deleted bool, err er
 	return nr.UpdateNews(ctx, news, WithColumns(Columns.News.StatusID))
 }
 
+/*** NewsRevision ***/
+
+// FullNewsRevision returns full joins with all columns
+func (nr NewsRepo) FullNewsRevision() OpFunc {
+	return WithColumns(nr.join[Tables.NewsRevision.Name]...)
+}
+
+// DefaultNewsRevisionSort returns default sort.
+func (nr NewsRepo) DefaultNewsRevisionSort() OpFunc {
+	return WithSort(nr.sort[Tables.NewsRevision.Name]...)
+}
+
+// NewsRevisionByID is a function that returns NewsRevision by ID(s) or nil.
+func (nr NewsRepo) NewsRevisionByID(ctx context.Context, id int, ops ...OpFunc) (*NewsRevision, error) {
+	return nr.OneNewsRevision(ctx, &NewsRevisionSearch{ID: &id}, ops...)
+}
+
+// OneNewsRevision is a function that returns one NewsRevision by filters. It could return pg.ErrMultiRows.
+func (nr NewsRepo) OneNewsRevision(ctx context.Context, search *NewsRevisionSearch, ops ...OpFunc) (*NewsRevision, error) {
+	obj := &NewsRevision{}
+	err := buildQuery(ctx, nr.db, obj, search, nr.filters[Tables.NewsRevision.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// NewsRevisionsByFilters returns NewsRevision list.
+func (nr NewsRepo) NewsRevisionsByFilters(ctx context.Context, search *NewsRevisionSearch, pager Pager, ops ...OpFunc) (newsRevisions []NewsRevision, err error) {
+	err = buildQuery(ctx, nr.db, &newsRevisions, search, nr.filters[Tables.NewsRevision.Name], pager, ops...).Select()
+	return
+}
+
+// CountNewsRevisions returns count
+func (nr NewsRepo) CountNewsRevisions(ctx context.Context, search *NewsRevisionSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, nr.db, &NewsRevision{}, search, nr.filters[Tables.NewsRevision.Name], PagerOne, ops...).Count()
+}
+
+// AddNewsRevision adds NewsRevision to DB.
+func (nr NewsRepo) AddNewsRevision(ctx context.Context, newsRevision *NewsRevision, ops ...OpFunc) (*NewsRevision, error) {
+	q := nr.db.ModelContext(ctx, newsRevision)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.NewsRevision.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return newsRevision, err
+}
+
+/*** NewsTransition ***/
+
+// FullNewsTransition returns full joins with all columns
+func (nr NewsRepo) FullNewsTransition() OpFunc {
+	return WithColumns(nr.join[Tables.NewsTransition.Name]...)
+}
+
+// DefaultNewsTransitionSort returns default sort.
+func (nr NewsRepo) DefaultNewsTransitionSort() OpFunc {
+	return WithSort(nr.sort[Tables.NewsTransition.Name]...)
+}
+
+// NewsTransitionByID is a function that returns NewsTransition by ID(s) or nil.
+func (nr NewsRepo) NewsTransitionByID(ctx context.Context, id int, ops ...OpFunc) (*NewsTransition, error) {
+	return nr.OneNewsTransition(ctx, &NewsTransitionSearch{ID: &id}, ops...)
+}
+
+// OneNewsTransition is a function that returns one NewsTransition by filters. It could return pg.ErrMultiRows.
+func (nr NewsRepo) OneNewsTransition(ctx context.Context, search *NewsTransitionSearch, ops ...OpFunc) (*NewsTransition, error) {
+	obj := &NewsTransition{}
+	err := buildQuery(ctx, nr.db, obj, search, nr.filters[Tables.NewsTransition.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// NewsTransitionsByFilters returns NewsTransition list.
+func (nr NewsRepo) NewsTransitionsByFilters(ctx context.Context, search *NewsTransitionSearch, pager Pager, ops ...OpFunc) (newsTransitions []NewsTransition, err error) {
+	err = buildQuery(ctx, nr.db, &newsTransitions, search, nr.filters[Tables.NewsTransition.Name], pager, ops...).Select()
+	return
+}
+
+// CountNewsTransitions returns count
+func (nr NewsRepo) CountNewsTransitions(ctx context.Context, search *NewsTransitionSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, nr.db, &NewsTransition{}, search, nr.filters[Tables.NewsTransition.Name], PagerOne, ops...).Count()
+}
+
+// AddNewsTransition adds NewsTransition to DB.
+func (nr NewsRepo) AddNewsTransition(ctx context.Context, newsTransition *NewsTransition, ops ...OpFunc) (*NewsTransition, error) {
+	q := nr.db.ModelContext(ctx, newsTransition)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.NewsTransition.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return newsTransition, err
+}
+
 /*** Tag ***/
 
 // FullTag returns full joins with all columns
@@ -274,3 +467,57 @@ func (nr NewsRepo) DeleteTag(ctx context.Context, id int) (deleted bool, err err
 
 	return nr.UpdateTag(ctx, tag, WithColumns(Columns.Tag.StatusID))
 }
+
+/*** TagMerge ***/
+
+// FullTagMerge returns full joins with all columns
+func (nr NewsRepo) FullTagMerge() OpFunc {
+	return WithColumns(nr.join[Tables.TagMerge.Name]...)
+}
+
+// DefaultTagMergeSort returns default sort.
+func (nr NewsRepo) DefaultTagMergeSort() OpFunc {
+	return WithSort(nr.sort[Tables.TagMerge.Name]...)
+}
+
+// TagMergeByID is a function that returns TagMerge by ID(s) or nil.
+func (nr NewsRepo) TagMergeByID(ctx context.Context, id int, ops ...OpFunc) (*TagMerge, error) {
+	return nr.OneTagMerge(ctx, &TagMergeSearch{ID: &id}, ops...)
+}
+
+// OneTagMerge is a function that returns one TagMerge by filters. It could return pg.ErrMultiRows.
+func (nr NewsRepo) OneTagMerge(ctx context.Context, search *TagMergeSearch, ops ...OpFunc) (*TagMerge, error) {
+	obj := &TagMerge{}
+	err := buildQuery(ctx, nr.db, obj, search, nr.filters[Tables.TagMerge.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// TagMergesByFilters returns TagMerge list.
+func (nr NewsRepo) TagMergesByFilters(ctx context.Context, search *TagMergeSearch, pager Pager, ops ...OpFunc) (tagMerges []TagMerge, err error) {
+	err = buildQuery(ctx, nr.db, &tagMerges, search, nr.filters[Tables.TagMerge.Name], pager, ops...).Select()
+	return
+}
+
+// CountTagMerges returns count
+func (nr NewsRepo) CountTagMerges(ctx context.Context, search *TagMergeSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, nr.db, &TagMerge{}, search, nr.filters[Tables.TagMerge.Name], PagerOne, ops...).Count()
+}
+
+// AddTagMerge adds TagMerge to DB.
+func (nr NewsRepo) AddTagMerge(ctx context.Context, tagMerge *TagMerge, ops ...OpFunc) (*TagMerge, error) {
+	q := nr.db.ModelContext(ctx, tagMerge)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.TagMerge.MergedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return tagMerge, err
+}
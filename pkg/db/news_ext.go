@@ -0,0 +1,601 @@
+package db
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// AddNewsRevisionFrom saves News snapshot as next revision of the News. It must be called in transaction:
+// News row is locked until the end of transaction, so concurrent revisions of the News get sequential numbers.
+func (nr NewsRepo) AddNewsRevisionFrom(ctx context.Context, news *News, userID *int) (*NewsRevision, error) {
+	_, err := nr.db.ExecContext(ctx, `SELECT 1 FROM ? WHERE ? = ? FOR UPDATE`, pg.Ident(Tables.News.Name), pg.Ident(Columns.News.ID), news.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rev := &NewsRevision{
+		NewsID:      news.ID,
+		UserID:      userID,
+		Title:       news.Title,
+		CategoryID:  news.CategoryID,
+		Foreword:    news.Foreword,
+		Content:     news.Content,
+		TagIDs:      news.TagIDs,
+		AuthorIDs:   news.AuthorIDs,
+		Author:      news.Author,
+		PublishedAt: news.PublishedAt,
+		UnpublishAt: news.UnpublishAt,
+		StatusID:    news.StatusID,
+	}
+
+	_, err = nr.db.ModelContext(ctx, rev).
+		ExcludeColumn(Columns.NewsRevision.CreatedAt).
+		Value(Columns.NewsRevision.Revision, `(SELECT coalesce(max(?), 0) + 1 FROM ? WHERE ? = ?)`,
+			pg.Ident(Columns.NewsRevision.Revision), pg.Ident(Tables.NewsRevision.Name), pg.Ident(Columns.NewsRevision.NewsID), news.ID).
+		Returning("*").
+		Insert()
+
+	return rev, err
+}
+
+// NewsRevisionByNumber returns NewsRevision by News id and revision number or nil.
+func (nr NewsRepo) NewsRevisionByNumber(ctx context.Context, newsID, revision int, ops ...OpFunc) (*NewsRevision, error) {
+	return nr.OneNewsRevision(ctx, &NewsRevisionSearch{NewsID: &newsID, Revision: &revision}, ops...)
+}
+
+// NewsForUpdate returns News according to search params and locks selected rows until the end of transaction.
+// Rows locked by other transactions are skipped. Query is always sent to primary.
+func (nr NewsRepo) NewsForUpdate(ctx context.Context, search *NewsSearch, limit int, ops ...OpFunc) (newsList []News, err error) {
+	err = buildQuery(ctx, primary(nr.db), &newsList, search, nr.filters[Tables.News.Name], Pager{Page: 1, PageSize: limit}, ops...).
+		For("UPDATE OF ? SKIP LOCKED", pg.Ident(Tables.News.Alias)).
+		Select()
+	return
+}
+
+// NewsByIDsForUpdate returns News by ids and locks them in order of ids until the end of transaction.
+// Unlike NewsForUpdate, it waits for rows locked by other transactions. Query is always sent to primary.
+func (nr NewsRepo) NewsByIDsForUpdate(ctx context.Context, ids []int) (newsList []News, err error) {
+	err = buildQuery(ctx, primary(nr.db), &newsList, &NewsSearch{IDs: ids}, nr.filters[Tables.News.Name], PagerNoLimit, WithSort(NewSortField(Columns.News.ID, false))).
+		For("UPDATE OF ?", pg.Ident(Tables.News.Alias)).
+		Select()
+	return
+}
+
+const versionColumn = "version"
+
+// withVersion adds condition on row version for optimistic locking.
+func withVersion(column string, version int) OpFunc {
+	return func(query *orm.Query) {
+		query.Where(`? = ?`, pg.Ident(column), version)
+	}
+}
+
+// UpdateAuthorVersion updates Author only if its version was not changed since Author had been read and increments the version.
+// It returns false if Author was changed by someone else.
+func (nr NewsRepo) UpdateAuthorVersion(ctx context.Context, author *Author) (bool, error) {
+	version := author.Version
+	author.Version++
+	ok, err := nr.UpdateAuthor(ctx, author, WithoutColumns(Columns.Author.ID), withVersion(Columns.Author.Version, version))
+	if err != nil || !ok {
+		author.Version = version
+	}
+	return ok, err
+}
+
+// UpdateCategoryVersion updates Category only if its version was not changed since Category had been read and increments the version.
+// It returns false if Category was changed by someone else.
+func (nr NewsRepo) UpdateCategoryVersion(ctx context.Context, category *Category) (bool, error) {
+	version := category.Version
+	category.Version++
+	ok, err := nr.UpdateCategory(ctx, category, WithoutColumns(Columns.Category.ID), withVersion(Columns.Category.Version, version))
+	if err != nil || !ok {
+		category.Version = version
+	}
+	return ok, err
+}
+
+// UpdateNewsVersion updates News only if its version was not changed since News had been read and increments the version.
+// It returns false if News was changed by someone else.
+func (nr NewsRepo) UpdateNewsVersion(ctx context.Context, news *News) (bool, error) {
+	version := news.Version
+	news.Version++
+	ok, err := nr.UpdateNews(ctx, news, WithoutColumns(Columns.News.ID), withVersion(Columns.News.Version, version))
+	if err != nil || !ok {
+		news.Version = version
+	}
+	return ok, err
+}
+
+// UpdateNewsState saves state and status of News only if its state and version were not changed since News had been read
+// and increments the version. It returns false if News was changed by someone else.
+func (nr NewsRepo) UpdateNewsState(ctx context.Context, news *News, fromState string) (bool, error) {
+	version := news.Version
+	news.Version++
+	ok, err := nr.UpdateNews(ctx, news, WithColumns(Columns.News.State, Columns.News.StatusID, Columns.News.Version),
+		withVersion(Columns.News.Version, version), func(query *orm.Query) {
+			query.Where(`? = ?`, pg.Ident(Columns.News.State), fromState)
+		})
+	if err != nil || !ok {
+		news.Version = version
+	}
+	return ok, err
+}
+
+// UpdateTagVersion updates Tag only if its version was not changed since Tag had been read and increments the version.
+// It returns false if Tag was changed by someone else.
+func (nr NewsRepo) UpdateTagVersion(ctx context.Context, tag *Tag) (bool, error) {
+	version := tag.Version
+	tag.Version++
+	ok, err := nr.UpdateTag(ctx, tag, WithoutColumns(Columns.Tag.ID), withVersion(Columns.Tag.Version, version))
+	if err != nil || !ok {
+		tag.Version = version
+	}
+	return ok, err
+}
+
+// RestoreNews sets status of deleted News.
+func (nr NewsRepo) RestoreNews(ctx context.Context, id, statusID int) (bool, error) {
+	return restoreDeleted(ctx, nr.db, (*News)(nil), Columns.News.ID, id, statusID)
+}
+
+// PurgeNews removes deleted News with its revisions and transitions.
+func (nr NewsRepo) PurgeNews(ctx context.Context, id int) (bool, error) {
+	return purgeDeleted(ctx, nr.db, (*News)(nil), Columns.News.ID, id)
+}
+
+// RestoreCategory sets status of deleted Category.
+func (nr NewsRepo) RestoreCategory(ctx context.Context, id, statusID int) (bool, error) {
+	return restoreDeleted(ctx, nr.db, (*Category)(nil), Columns.Category.ID, id, statusID)
+}
+
+// PurgeCategory removes deleted Category. It returns ErrEntityInUse if any News or child Category,
+// including deleted ones, is in the Category.
+func (nr NewsRepo) PurgeCategory(ctx context.Context, id int) (bool, error) {
+	count, err := nr.CountNewsByCategory(ctx, id)
+	if err != nil {
+		return false, err
+	} else if count > 0 {
+		return false, ErrEntityInUse
+	}
+
+	if count, err = nr.CountCategoriesByParent(ctx, id); err != nil {
+		return false, err
+	} else if count > 0 {
+		return false, ErrEntityInUse
+	}
+
+	return purgeDeleted(ctx, nr.db, (*Category)(nil), Columns.Category.ID, id)
+}
+
+// RestoreTag sets status of deleted Tag.
+func (nr NewsRepo) RestoreTag(ctx context.Context, id, statusID int) (bool, error) {
+	return restoreDeleted(ctx, nr.db, (*Tag)(nil), Columns.Tag.ID, id, statusID)
+}
+
+// PurgeTag removes deleted Tag. It returns ErrEntityInUse if any News, including deleted ones, has the Tag.
+func (nr NewsRepo) PurgeTag(ctx context.Context, id int) (bool, error) {
+	count, err := nr.CountNewsByTag(ctx, id)
+	if err != nil {
+		return false, err
+	} else if count > 0 {
+		return false, ErrEntityInUse
+	}
+
+	return purgeDeleted(ctx, nr.db, (*Tag)(nil), Columns.Tag.ID, id)
+}
+
+// CountNewsByCategory returns count of News in the Category regardless of News status.
+func (nr NewsRepo) CountNewsByCategory(ctx context.Context, categoryID int) (int, error) {
+	return nr.db.ModelContext(ctx, (*News)(nil)).Where(`? = ?`, pg.Ident(Columns.News.CategoryID), categoryID).Count()
+}
+
+// CountCategoriesByParent returns count of child Categories regardless of their status.
+func (nr NewsRepo) CountCategoriesByParent(ctx context.Context, parentID int) (int, error) {
+	return nr.db.ModelContext(ctx, (*Category)(nil)).Where(`? = ?`, pg.Ident(Columns.Category.ParentCategoryID), parentID).Count()
+}
+
+// CountNewsByTag returns count of News with the Tag regardless of News status.
+func (nr NewsRepo) CountNewsByTag(ctx context.Context, tagID int) (int, error) {
+	return nr.db.ModelContext(ctx, (*NewsTag)(nil)).Where(`? = ?`, pg.Ident(Columns.NewsTag.TagID), tagID).Count()
+}
+
+// ReassignNewsCategory moves all News from one Category to another and increments their versions.
+func (nr NewsRepo) ReassignNewsCategory(ctx context.Context, fromID, toID int) (int, error) {
+	res, err := nr.db.ModelContext(ctx, (*News)(nil)).
+		Set(`? = ?`, pg.Ident(Columns.News.CategoryID), toID).
+		Set(`? = ? + 1`, pg.Ident(Columns.News.Version), pg.Ident(Columns.News.Version)).
+		Where(`? = ?`, pg.Ident(Columns.News.CategoryID), fromID).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// StripNewsTag removes the Tag from all News and increments their versions.
+func (nr NewsRepo) StripNewsTag(ctx context.Context, tagID int) (int, error) {
+	_, err := nr.db.ModelContext(ctx, (*News)(nil)).
+		Set(`? = ? + 1`, pg.Ident(Columns.News.Version), pg.Ident(Columns.News.Version)).
+		Where(`? IN (SELECT ? FROM ? WHERE ? = ?)`, pg.Ident(Columns.News.ID),
+			pg.Ident(Columns.NewsTag.NewsID), pg.Ident(Tables.NewsTag.Name), pg.Ident(Columns.NewsTag.TagID), tagID).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := nr.db.ModelContext(ctx, (*NewsTag)(nil)).Where(`? = ?`, pg.Ident(Columns.NewsTag.TagID), tagID).Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+/*** Category tree ***/
+
+// categoryTreeSQL selects id of the Category ?3 and ids of all its descendants, UNION stops on cyclic references.
+const categoryTreeSQL = `WITH RECURSIVE "tree" AS (
+	SELECT ?1 FROM ?0 WHERE ?1 = ?3
+	UNION
+	SELECT "c".?1 FROM ?0 AS "c" JOIN "tree" ON "c".?2 = "tree".?1
+) SELECT ?1 FROM "tree"`
+
+// categoryTree returns subquery of the Category and its descendants ids.
+func categoryTree(categoryID int) *orm.SafeQueryAppender {
+	return pg.SafeQuery(categoryTreeSQL,
+		pg.Ident(Tables.Category.Name), pg.Ident(Columns.Category.ID), pg.Ident(Columns.Category.ParentCategoryID), categoryID,
+	)
+}
+
+// whereCategoryTree adds condition that News is in the Category or in any of its descendants.
+func (ns *NewsSearch) whereCategoryTree(query *orm.Query, categoryID int) {
+	query.Where(`?.? IN (?)`, pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.CategoryID), categoryTree(categoryID))
+}
+
+// CategoryTreeIDs returns id of the Category and ids of all its descendants regardless of status.
+func (nr NewsRepo) CategoryTreeIDs(ctx context.Context, categoryID int) (ids []int, err error) {
+	err = nr.db.ModelContext(ctx, (*Category)(nil)).
+		Column(Columns.Category.ID).
+		Where(`? IN (?)`, pg.Ident(Columns.Category.ID), categoryTree(categoryID)).
+		Select(&ids)
+
+	return
+}
+
+// SetCategoriesOrder sets parent and order numbers starting from 1 in order of ids, versions are incremented for changed Categories only.
+func (nr NewsRepo) SetCategoriesOrder(ctx context.Context, parentID *int, ids []int) error {
+	for i, id := range ids {
+		_, err := nr.db.ModelContext(ctx, (*Category)(nil)).
+			Set(`? = ?`, pg.Ident(Columns.Category.ParentCategoryID), parentID).
+			Set(`? = ?`, pg.Ident(Columns.Category.OrderNumber), i+1).
+			Set(`? = ? + 1`, pg.Ident(Columns.Category.Version), pg.Ident(Columns.Category.Version)).
+			Where(`? = ?`, pg.Ident(Columns.Category.ID), id).
+			Where(`(?, ?) IS DISTINCT FROM (?, ?)`, pg.Ident(Columns.Category.ParentCategoryID), pg.Ident(Columns.Category.OrderNumber), parentID, i+1).
+			Update()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CategoryNode is a Category with its children.
+type CategoryNode struct {
+	Category
+	Children []CategoryNode
+}
+
+// NewCategoryTree builds tree from list of Categories keeping list order for siblings.
+// Categories with parent missing in the list, e.g. deleted one, become roots.
+func NewCategoryTree(list []Category) []CategoryNode {
+	byID := make(map[int]bool, len(list))
+	children := make(map[int][]Category, len(list))
+	for _, c := range list {
+		byID[c.ID] = true
+	}
+
+	var roots []Category
+	for _, c := range list {
+		if c.ParentCategoryID != nil && byID[*c.ParentCategoryID] {
+			children[*c.ParentCategoryID] = append(children[*c.ParentCategoryID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var build func([]Category, map[int]bool) []CategoryNode
+	build = func(list []Category, visited map[int]bool) []CategoryNode {
+		nodes := make([]CategoryNode, 0, len(list))
+		for _, c := range list {
+			if visited[c.ID] {
+				continue
+			}
+			visited[c.ID] = true
+			nodes = append(nodes, CategoryNode{Category: c, Children: build(children[c.ID], visited)})
+		}
+		return nodes
+	}
+
+	return build(roots, make(map[int]bool, len(list)))
+}
+
+/*** NewsTag ***/
+
+func init() {
+	// many2many relations News.Tags and News.Authors require registered join tables
+	orm.RegisterTable((*NewsTag)(nil))
+	orm.RegisterTable((*NewsAuthor)(nil))
+}
+
+// whereTags adds condition that News has any of the Tags.
+func (ns *NewsSearch) whereTags(query *orm.Query, tagIDs []int) {
+	query.Where(`EXISTS (SELECT 1 FROM ? AS ? WHERE ?.? = ?.? AND ?.? IN (?))`,
+		pg.Ident(Tables.NewsTag.Name), pg.Ident(Tables.NewsTag.Alias),
+		pg.Ident(Tables.NewsTag.Alias), pg.Ident(Columns.NewsTag.NewsID), pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.ID),
+		pg.Ident(Tables.NewsTag.Alias), pg.Ident(Columns.NewsTag.TagID), pg.In(tagIDs),
+	)
+}
+
+// WithTags loads News Tags ordered by their position.
+func (nr NewsRepo) WithTags() OpFunc {
+	return func(query *orm.Query) {
+		query.Relation(Columns.News.Tags, func(q *orm.Query) (*orm.Query, error) {
+			return q.OrderExpr(`?.? ASC`, pg.Ident(Tables.NewsTag.Alias), pg.Ident(Columns.NewsTag.Position)), nil
+		})
+	}
+}
+
+// NewsTagsByNewsIDs returns links of News to Tags ordered by News and position.
+func (nr NewsRepo) NewsTagsByNewsIDs(ctx context.Context, newsIDs []int, ops ...OpFunc) (newsTags []NewsTag, err error) {
+	if len(newsIDs) == 0 {
+		return nil, nil
+	}
+
+	q := nr.db.ModelContext(ctx, &newsTags).
+		Where(`?.? IN (?)`, pg.Ident(Tables.NewsTag.Alias), pg.Ident(Columns.NewsTag.NewsID), pg.In(newsIDs)).
+		OrderExpr(`?.?, ?.?`, pg.Ident(Tables.NewsTag.Alias), pg.Ident(Columns.NewsTag.NewsID), pg.Ident(Tables.NewsTag.Alias), pg.Ident(Columns.NewsTag.Position))
+	applyOps(q, ops...)
+	err = q.Select()
+
+	return
+}
+
+// FillNewsTagIDs sets TagIDs of News from newsTags in one query.
+func (nr NewsRepo) FillNewsTagIDs(ctx context.Context, newsList ...*News) error {
+	ids := make([]int, 0, len(newsList))
+	byID := make(map[int]*News, len(newsList))
+	for _, news := range newsList {
+		news.TagIDs = []int{}
+		ids = append(ids, news.ID)
+		byID[news.ID] = news
+	}
+
+	newsTags, err := nr.NewsTagsByNewsIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, nt := range newsTags {
+		if news, ok := byID[nt.NewsID]; ok {
+			news.TagIDs = append(news.TagIDs, nt.TagID)
+		}
+	}
+
+	return nil
+}
+
+// SetNewsTags replaces Tags of News keeping order of tagIDs.
+func (nr NewsRepo) SetNewsTags(ctx context.Context, newsID int, tagIDs []int) error {
+	_, err := nr.db.ModelContext(ctx, (*NewsTag)(nil)).Where(`? = ?`, pg.Ident(Columns.NewsTag.NewsID), newsID).Delete()
+	if err != nil || len(tagIDs) == 0 {
+		return err
+	}
+
+	newsTags := make([]NewsTag, 0, len(tagIDs))
+	for i, tagID := range tagIDs {
+		newsTags = append(newsTags, NewsTag{NewsID: newsID, TagID: tagID, Position: i + 1})
+	}
+
+	_, err = nr.db.ModelContext(ctx, &newsTags).OnConflict("DO NOTHING").Insert()
+	return err
+}
+
+/*** NewsAuthor ***/
+
+// whereAuthor adds condition that the Author is one of News authors.
+func (ns *NewsSearch) whereAuthor(query *orm.Query, authorID int) {
+	query.Where(`EXISTS (SELECT 1 FROM ? AS ? WHERE ?.? = ?.? AND ?.? = ?)`,
+		pg.Ident(Tables.NewsAuthor.Name), pg.Ident(Tables.NewsAuthor.Alias),
+		pg.Ident(Tables.NewsAuthor.Alias), pg.Ident(Columns.NewsAuthor.NewsID), pg.Ident(Tables.News.Alias), pg.Ident(Columns.News.ID),
+		pg.Ident(Tables.NewsAuthor.Alias), pg.Ident(Columns.NewsAuthor.AuthorID), authorID,
+	)
+}
+
+// NewsAuthorsByNewsIDs returns links of News to Authors ordered by News and position.
+func (nr NewsRepo) NewsAuthorsByNewsIDs(ctx context.Context, newsIDs []int, ops ...OpFunc) (newsAuthors []NewsAuthor, err error) {
+	if len(newsIDs) == 0 {
+		return nil, nil
+	}
+
+	q := nr.db.ModelContext(ctx, &newsAuthors).
+		Where(`?.? IN (?)`, pg.Ident(Tables.NewsAuthor.Alias), pg.Ident(Columns.NewsAuthor.NewsID), pg.In(newsIDs)).
+		OrderExpr(`?.?, ?.?`, pg.Ident(Tables.NewsAuthor.Alias), pg.Ident(Columns.NewsAuthor.NewsID), pg.Ident(Tables.NewsAuthor.Alias), pg.Ident(Columns.NewsAuthor.Position))
+	applyOps(q, ops...)
+	err = q.Select()
+
+	return
+}
+
+// FillNewsAuthorIDs sets AuthorIDs of News from newsAuthors in one query.
+func (nr NewsRepo) FillNewsAuthorIDs(ctx context.Context, newsList ...*News) error {
+	ids := make([]int, 0, len(newsList))
+	byID := make(map[int]*News, len(newsList))
+	for _, news := range newsList {
+		news.AuthorIDs = []int{}
+		ids = append(ids, news.ID)
+		byID[news.ID] = news
+	}
+
+	newsAuthors, err := nr.NewsAuthorsByNewsIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, na := range newsAuthors {
+		if news, ok := byID[na.NewsID]; ok {
+			news.AuthorIDs = append(news.AuthorIDs, na.AuthorID)
+		}
+	}
+
+	return nil
+}
+
+// SetNewsAuthors replaces Authors of News keeping order of authorIDs, the first Author is the main one.
+func (nr NewsRepo) SetNewsAuthors(ctx context.Context, newsID int, authorIDs []int) error {
+	_, err := nr.db.ModelContext(ctx, (*NewsAuthor)(nil)).Where(`? = ?`, pg.Ident(Columns.NewsAuthor.NewsID), newsID).Delete()
+	if err != nil || len(authorIDs) == 0 {
+		return err
+	}
+
+	newsAuthors := make([]NewsAuthor, 0, len(authorIDs))
+	for i, authorID := range authorIDs {
+		newsAuthors = append(newsAuthors, NewsAuthor{NewsID: newsID, AuthorID: authorID, Position: i + 1})
+	}
+
+	_, err = nr.db.ModelContext(ctx, &newsAuthors).OnConflict("DO NOTHING").Insert()
+	return err
+}
+
+// CountNewsByAuthor returns count of News of the Author regardless of News status.
+func (nr NewsRepo) CountNewsByAuthor(ctx context.Context, authorID int) (int, error) {
+	return nr.db.ModelContext(ctx, (*NewsAuthor)(nil)).Where(`? = ?`, pg.Ident(Columns.NewsAuthor.AuthorID), authorID).Count()
+}
+
+// RestoreAuthor sets status of deleted Author.
+func (nr NewsRepo) RestoreAuthor(ctx context.Context, id, statusID int) (bool, error) {
+	return restoreDeleted(ctx, nr.db, (*Author)(nil), Columns.Author.ID, id, statusID)
+}
+
+// PurgeAuthor removes deleted Author. It returns ErrEntityInUse if any News, including deleted ones, has the Author.
+func (nr NewsRepo) PurgeAuthor(ctx context.Context, id int) (bool, error) {
+	count, err := nr.CountNewsByAuthor(ctx, id)
+	if err != nil {
+		return false, err
+	} else if count > 0 {
+		return false, ErrEntityInUse
+	}
+
+	return purgeDeleted(ctx, nr.db, (*Author)(nil), Columns.Author.ID, id)
+}
+
+/*** TagMerge ***/
+
+// whereTitleFold adds condition that Tag title equals to the given one ignoring case and surrounding spaces.
+func (ts *TagSearch) whereTitleFold(query *orm.Query, title string) {
+	query.Where(`lower(trim(?.?)) = lower(trim(?))`, pg.Ident(Tables.Tag.Alias), pg.Ident(Columns.Tag.Title), title)
+}
+
+// MergeNewsTags replaces source Tags of News with the target Tag and increments versions of changed News.
+// Target Tag takes the first position of replaced Tags unless News already has it.
+// Changed News regardless of status are locked until the end of transaction and returned with new versions.
+func (nr NewsRepo) MergeNewsTags(ctx context.Context, sourceIDs []int, targetID int) ([]News, error) {
+	var newsList []News
+	err := nr.db.ModelContext(ctx, &newsList).
+		Where(`? IN (SELECT ? FROM ? WHERE ? IN (?))`, pg.Ident(Columns.News.ID),
+			pg.Ident(Columns.NewsTag.NewsID), pg.Ident(Tables.NewsTag.Name), pg.Ident(Columns.NewsTag.TagID), pg.In(sourceIDs)).
+		OrderExpr(`?`, pg.Ident(Columns.News.ID)).
+		For("UPDATE").
+		Select()
+	if err != nil || len(newsList) == 0 {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(newsList))
+	for i := range newsList {
+		newsList[i].Version++
+		ids = append(ids, newsList[i].ID)
+	}
+
+	_, err = nr.db.ModelContext(ctx, (*News)(nil)).
+		Set(`? = ? + 1`, pg.Ident(Columns.News.Version), pg.Ident(Columns.News.Version)).
+		Where(`? IN (?)`, pg.Ident(Columns.News.ID), pg.In(ids)).
+		Update()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = nr.db.ExecContext(ctx, `INSERT INTO ? (?, ?, ?) SELECT ?, ?, min(?) FROM ? WHERE ? IN (?) GROUP BY ? ON CONFLICT DO NOTHING`,
+		pg.Ident(Tables.NewsTag.Name), pg.Ident(Columns.NewsTag.NewsID), pg.Ident(Columns.NewsTag.TagID), pg.Ident(Columns.NewsTag.Position),
+		pg.Ident(Columns.NewsTag.NewsID), targetID, pg.Ident(Columns.NewsTag.Position), pg.Ident(Tables.NewsTag.Name),
+		pg.Ident(Columns.NewsTag.TagID), pg.In(sourceIDs), pg.Ident(Columns.NewsTag.NewsID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = nr.db.ModelContext(ctx, (*NewsTag)(nil)).Where(`? IN (?)`, pg.Ident(Columns.NewsTag.TagID), pg.In(sourceIDs)).Delete()
+	if err != nil {
+		return nil, err
+	}
+
+	return newsList, nil
+}
+
+// TagsForUpdate returns Tags by ids and locks them in order of ids until the end of transaction. Query is always sent to primary.
+func (nr NewsRepo) TagsForUpdate(ctx context.Context, ids []int) (tags []Tag, err error) {
+	err = buildQuery(ctx, primary(nr.db), &tags, &TagSearch{IDs: ids}, nr.filters[Tables.Tag.Name], PagerNoLimit, WithSort(NewSortField(Columns.Tag.ID, false))).
+		For("UPDATE OF ?", pg.Ident(Tables.Tag.Alias)).
+		Select()
+	return
+}
+
+// RedirectTagMerges points earlier merges into source Tags to the target Tag, so merge chains resolve in one step.
+func (nr NewsRepo) RedirectTagMerges(ctx context.Context, sourceIDs []int, targetID int) error {
+	_, err := nr.db.ModelContext(ctx, (*TagMerge)(nil)).
+		Set(`? = ?`, pg.Ident(Columns.TagMerge.TargetTagID), targetID).
+		Where(`? IN (?)`, pg.Ident(Columns.TagMerge.TargetTagID), pg.In(sourceIDs)).
+		Update()
+
+	return err
+}
+
+// RemoveTags removes Tags from DB regardless of status, Tags must not be used by News.
+func (nr NewsRepo) RemoveTags(ctx context.Context, ids []int) error {
+	_, err := nr.db.ModelContext(ctx, (*Tag)(nil)).Where(`? IN (?)`, pg.Ident(Columns.Tag.ID), pg.In(ids)).Delete()
+	return err
+}
+
+// ResolveTagIDs replaces ids of merged Tags with ids of Tags they were merged into, other ids are kept as is.
+func (nr NewsRepo) ResolveTagIDs(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	var merges []TagMerge
+	err := nr.db.ModelContext(ctx, &merges).
+		Column(Columns.TagMerge.SourceTagID, Columns.TagMerge.TargetTagID).
+		Where(`? IN (?)`, pg.Ident(Columns.TagMerge.SourceTagID), pg.In(ids)).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[int]int, len(merges))
+	for _, m := range merges {
+		targets[m.SourceTagID] = m.TargetTagID
+	}
+
+	resolved := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if target, ok := targets[id]; ok {
+			id = target
+		}
+		resolved = append(resolved, id)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+const (
+	defaultReplicaMaxLag        = 10 * time.Second
+	defaultReplicaCheckInterval = 5 * time.Second
+)
+
+// replicaLagQuery returns replication lag in seconds, it is zero if replica replayed all received WAL.
+const replicaLagQuery = `select case
+	when not pg_is_in_recovery() or pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0
+	else coalesce(extract(epoch from now() - pg_last_xact_replay_timestamp()), 0)
+end`
+
+// ReplicaConfig describes read-only replicas, they use credentials and pool options of primary database.
+type ReplicaConfig struct {
+	Addrs         []string      // host:port of replicas
+	MaxLag        time.Duration // replica with bigger replication lag is not used, default 10s
+	CheckInterval time.Duration // interval of lag checks, default 5s
+}
+
+func (c ReplicaConfig) maxLag() time.Duration {
+	if c.MaxLag <= 0 {
+		return defaultReplicaMaxLag
+	}
+	return c.MaxLag
+}
+
+// Interval returns interval of lag checks.
+func (c ReplicaConfig) Interval() time.Duration {
+	if c.CheckInterval <= 0 {
+		return defaultReplicaCheckInterval
+	}
+	return c.CheckInterval
+}
+
+// Replica is a connection to read-only replica with its replication state.
+type Replica struct {
+	Name string
+	DB   *pg.DB
+
+	lag     atomic.Int64 // nanoseconds
+	healthy atomic.Bool
+}
+
+// Lag returns replication lag of last check.
+func (r *Replica) Lag() time.Duration { return time.Duration(r.lag.Load()) }
+
+// Healthy returns true if replica was available and its lag was acceptable on last check.
+func (r *Replica) Healthy() bool { return r.healthy.Load() }
+
+// Replicas is a set of replicas. Replicas are not used until first successful check.
+type Replicas struct {
+	cfg  ReplicaConfig
+	list []*Replica
+	next atomic.Uint32
+}
+
+// NewReplicas returns replicas connected with options of primary where address is replaced.
+func NewReplicas(primary *pg.Options, cfg ReplicaConfig) *Replicas {
+	rs := &Replicas{cfg: cfg}
+	for i, addr := range cfg.Addrs {
+		opts := *primary
+		opts.Addr = addr
+		rs.list = append(rs.list, &Replica{Name: "replica" + strconv.Itoa(i+1), DB: pg.Connect(&opts)})
+	}
+
+	return rs
+}
+
+// List returns all replicas.
+func (rs *Replicas) List() []*Replica {
+	if rs == nil {
+		return nil
+	}
+	return rs.list
+}
+
+// Config returns config of replicas.
+func (rs *Replicas) Config() ReplicaConfig {
+	if rs == nil {
+		return ReplicaConfig{}
+	}
+	return rs.cfg
+}
+
+// AddQueryHook adds query hook to all replicas.
+func (rs *Replicas) AddQueryHook(hook pg.QueryHook) {
+	for _, r := range rs.List() {
+		r.DB.AddQueryHook(hook)
+	}
+}
+
+// Check updates lag and health of all replicas and returns errors of unavailable replicas.
+func (rs *Replicas) Check(ctx context.Context) error {
+	var errs []error
+	for _, r := range rs.List() {
+		var lag float64
+		_, err := r.DB.QueryOneContext(ctx, pg.Scan(&lag), replicaLagQuery)
+		if err != nil {
+			r.healthy.Store(false)
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, err))
+			continue
+		}
+
+		r.lag.Store(int64(lag * float64(time.Second)))
+		r.healthy.Store(r.Lag() <= rs.cfg.maxLag())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes connections of all replicas.
+func (rs *Replicas) Close() error {
+	var errs []error
+	for _, r := range rs.List() {
+		errs = append(errs, r.DB.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// pick returns next healthy replica in round-robin order or nil.
+func (rs *Replicas) pick() *pg.DB {
+	n := len(rs.List())
+	if n == 0 {
+		return nil
+	}
+
+	start := int(rs.next.Add(1))
+	for i := 0; i < n; i++ {
+		if r := rs.list[(start+i)%n]; r.Healthy() {
+			return r.DB
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReplicas(t *testing.T) {
+	Convey("Test routing of reads to replicas", t, func() {
+		opts := &pg.Options{Addr: "primary:5432", User: "postgres", Database: "newsportal"}
+		primaryConn := pg.Connect(opts)
+		rs := NewReplicas(opts, ReplicaConfig{Addrs: []string{"replica1:5432", "replica2:5432"}})
+		defer func() { _ = primaryConn.Close(); _ = rs.Close() }()
+
+		dbo := New(primaryConn).WithReplicas(rs)
+		r1, r2 := rs.List()[0], rs.List()[1]
+		queryDB := func(db DB) interface{} { return db.reader() }
+
+		Convey("Replicas use options of primary", func() {
+			So(r1.Name, ShouldEqual, "replica1")
+			So(r1.DB.Options().Addr, ShouldEqual, "replica1:5432")
+			So(r1.DB.Options().Database, ShouldEqual, "newsportal")
+		})
+
+		Convey("Primary is used until replicas are checked", func() {
+			So(queryDB(dbo), ShouldEqual, primaryConn)
+		})
+
+		Convey("Healthy replicas are used in turn", func() {
+			r1.healthy.Store(true)
+			r2.healthy.Store(true)
+
+			first, second := queryDB(dbo), queryDB(dbo)
+			So(first, ShouldNotEqual, second)
+			So([]interface{}{r1.DB, r2.DB}, ShouldContain, first)
+			So([]interface{}{r1.DB, r2.DB}, ShouldContain, second)
+
+			Convey("Unhealthy replica is skipped", func() {
+				r2.healthy.Store(false)
+				So(queryDB(dbo), ShouldEqual, r1.DB)
+				So(queryDB(dbo), ShouldEqual, r1.DB)
+			})
+
+			Convey("Primary DB and locking reads do not use replicas", func() {
+				So(queryDB(dbo.Primary()), ShouldEqual, primaryConn)
+				So(queryDB(primary(dbo).(DB)), ShouldEqual, primaryConn)
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanKey is a key of query span in pg.QueryEvent stash.
+type spanKey struct{}
+
+// QueryTracer is a query hook which starts span for each query.
+// Query is recorded without parameters, so values of user data are not exported.
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+func (qt QueryTracer) BeforeQuery(ctx context.Context, event *pg.QueryEvent) (context.Context, error) {
+	query, err := event.UnformattedQuery()
+	if err != nil {
+		return ctx, nil
+	}
+
+	operation := queryOperation(string(query))
+	ctx, span := qt.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(string(query)),
+		),
+	)
+
+	if event.Stash == nil {
+		event.Stash = make(map[interface{}]interface{})
+	}
+	event.Stash[spanKey{}] = span
+
+	return ctx, nil
+}
+
+func (qt QueryTracer) AfterQuery(_ context.Context, event *pg.QueryEvent) error {
+	span, ok := event.Stash[spanKey{}].(trace.Span)
+	if !ok {
+		return nil
+	}
+	defer span.End()
+
+	if event.Err != nil && !errors.Is(event.Err, pg.ErrNoRows) {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	} else if event.Result != nil {
+		span.SetAttributes(attribute.Int("db.rows_affected", event.Result.RowsAffected()))
+	}
+
+	return nil
+}
+
+// queryOperation returns first keyword of query, e.g. SELECT.
+func queryOperation(query string) string {
+	query = strings.TrimSpace(query)
+	if i := strings.IndexAny(query, " \n\t("); i > 0 {
+		query = query[:i]
+	}
+
+	if query == "" {
+		return "QUERY"
+	}
+	return strings.ToUpper(query)
+}
+
+func NewQueryTracer() QueryTracer {
+	return QueryTracer{tracer: otel.Tracer("apisrv/pkg/db")}
+}
@@ -0,0 +1,192 @@
+package embedlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	zm "github.com/vmkteam/zenrpc-middleware"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const componentKey = "component"
+
+type Config struct {
+	Format string   // text (default) or json
+	Level  string   // debug, info (default), warn or error
+	Levels []string // levels of components which override Level, e.g. scheduler=debug
+}
+
+// Validate checks format and levels.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Format != "" && c.Format != FormatText && c.Format != FormatJSON {
+		errs = append(errs, fmt.Errorf("unknown format %q", c.Format))
+	}
+
+	if _, _, err := c.parse(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// parse returns default level and levels of components.
+func (c Config) parse() (slog.Level, map[string]slog.Level, error) {
+	def, err := parseLevel(c.Level)
+	if err != nil {
+		return def, nil, err
+	}
+
+	components := make(map[string]slog.Level, len(c.Levels))
+	for _, s := range c.Levels {
+		name, lvl, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return def, nil, fmt.Errorf("invalid component level %q, expected component=level", s)
+		}
+
+		if components[name], err = parseLevel(lvl); err != nil {
+			return def, nil, err
+		}
+	}
+
+	return def, components, nil
+}
+
+func parseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return l, fmt.Errorf("unknown level %q", s)
+	}
+
+	return l, nil
+}
+
+// Levels holds minimal levels of records by component. It could be changed at runtime.
+// Zero value allows records with info level and above.
+type Levels struct {
+	mu         sync.RWMutex
+	def        slog.Level
+	components map[string]slog.Level
+}
+
+// NewLevels returns Levels from config. Default levels are used if config is invalid, it should be checked by Config.Validate.
+func NewLevels(cfg Config, verbose bool) *Levels {
+	lv := &Levels{}
+	if err := lv.Set(cfg, verbose); err != nil {
+		_ = lv.Set(Config{}, verbose)
+	}
+
+	return lv
+}
+
+// Set applies levels from config, default level is debug if verbose is set.
+func (lv *Levels) Set(cfg Config, verbose bool) error {
+	def, components, err := cfg.parse()
+	if err != nil {
+		return err
+	}
+	if verbose {
+		def = slog.LevelDebug
+	}
+
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	lv.def, lv.components = def, components
+
+	return nil
+}
+
+// Level returns minimal level of component.
+func (lv *Levels) Level(component string) slog.Level {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+
+	if l, ok := lv.components[component]; ok {
+		return l
+	}
+
+	return lv.def
+}
+
+// handler filters records by levels of component, adds request attributes from context and counts written records.
+type handler struct {
+	next      slog.Handler
+	levels    *Levels
+	component string
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	if h.levels == nil {
+		return level >= slog.LevelInfo
+	}
+
+	return level >= h.levels.Level(h.component)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+
+	if statLogEvents != nil {
+		statLogEvents.WithLabelValues(strings.ToLower(r.Level.String())).Inc()
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.next = h.next.WithAttrs(attrs)
+	return &c
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	return &c
+}
+
+func (h *handler) withComponent(name string) *handler {
+	c := *h
+	c.component = name
+	c.next = h.next.WithAttrs([]slog.Attr{slog.String(componentKey, name)})
+	return &c
+}
+
+type attrsKey struct{}
+
+// WithAttrs returns context with request-scoped attributes, they are added to all records logged with this context.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	prev, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return context.WithValue(ctx, attrsKey{}, append(prev[:len(prev):len(prev)], attrs...))
+}
+
+// RPCMiddleware adds request id, ip and method of rpc call to context, so they are logged with records of the call.
+func RPCMiddleware() zenrpc.MiddlewareFunc {
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			name := method
+			if ns := zenrpc.NamespaceFromContext(ctx); ns != "" {
+				name = ns + "." + method
+			}
+
+			ctx = WithAttrs(ctx,
+				slog.String("requestId", zm.XRequestIDFromContext(ctx)),
+				slog.String("ip", zm.IPFromContext(ctx)),
+				slog.String("method", name),
+			)
+
+			return h(ctx, method, params)
+		}
+	}
+}
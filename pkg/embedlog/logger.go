@@ -1,57 +1,134 @@
+// Package embedlog provides leveled structured logger based on log/slog for embedding into services and apps.
 package embedlog
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
+	"io"
+	"log/slog"
+	"runtime"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
 var statLogEvents *prometheus.CounterVec
 
-// SetStatLogEvents sets prometheus counter for error and debug log events.
+// SetStatLogEvents sets prometheus counter of written log records, it is labelled by level.
 func SetStatLogEvents(stat *prometheus.CounterVec) {
 	statLogEvents = stat
 }
 
-// Logger is a struct for embedding std loggers.
+// Logger is a struct for embedding structured logger. Zero value discards all records.
 type Logger struct {
-	warn, log *log.Logger
+	h slog.Handler
 }
 
-// Printf prints message to Stdout (app.log variable) if a.verbose is set.
-func (l Logger) Printf(format string, v ...interface{}) {
-	if l.log != nil {
-		if err := l.log.Output(2, fmt.Sprintf(format, v...)); err != nil {
+// New returns Logger which writes records to w in text (default) or json format.
+// Records below level of component from levels are dropped.
+func New(w io.Writer, format string, levels *Levels) Logger {
+	opts := &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug}
 
-			if statLogEvents != nil {
-				statLogEvents.WithLabelValues("debug").Inc()
-			}
-		}
+	var next slog.Handler
+	if format == FormatJSON {
+		next = slog.NewJSONHandler(w, opts)
+	} else {
+		next = slog.NewTextHandler(w, opts)
+	}
+
+	return Logger{h: &handler{next: next, levels: levels}}
+}
+
+// Component returns Logger with component attribute, level of component is used for filtering its records.
+func (l Logger) Component(name string) Logger {
+	switch h := l.h.(type) {
+	case nil:
+		return l
+	case *handler:
+		return Logger{h: h.withComponent(name)}
+	default:
+		return l.With(slog.String(componentKey, name))
+	}
+}
+
+// With returns Logger which adds attrs to all records.
+func (l Logger) With(attrs ...slog.Attr) Logger {
+	if l.h == nil {
+		return l
+	}
+
+	return Logger{h: l.h.WithAttrs(attrs)}
+}
+
+// Slog returns slog.Logger with the same output, e.g. for third party libraries.
+func (l Logger) Slog() *slog.Logger {
+	if l.h == nil {
+		return slog.New(discardHandler{})
 	}
+
+	return slog.New(l.h)
+}
+
+// Debug logs message with debug level, args are key-value pairs or slog.Attr.
+func (l Logger) Debug(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelDebug, msg, args...)
+}
+
+// Info logs message with info level.
+func (l Logger) Info(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, args...)
+}
+
+// Warn logs message with warn level.
+func (l Logger) Warn(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, args...)
 }
 
-// Errorf prints message to Stderr (l.warn variable).
-func (l Logger) Errorf(format string, v ...interface{}) {
-	if l.warn != nil {
-		if err := l.warn.Output(2, fmt.Sprintf(format, v...)); err != nil {
-			if statLogEvents != nil {
-				statLogEvents.WithLabelValues("error").Inc()
-			}
+// Error logs message with error level.
+func (l Logger) Error(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, args...)
+}
+
+// Enabled returns true if records with given level are written.
+func (l Logger) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.h != nil && l.h.Enabled(ctx, level)
+}
+
+// PrintfFunc returns printf-like func which logs formatted messages with given level.
+// It is an adapter for libraries which accept Printf, e.g. zm.WithAPILogger.
+func (l Logger) PrintfFunc(level slog.Level) func(format string, v ...interface{}) {
+	return func(format string, v ...interface{}) {
+		if l.Enabled(context.Background(), level) {
+			l.log(context.Background(), level, fmt.Sprintf(format, v...))
 		}
 	}
 }
 
-func (l *Logger) SetStdLoggers(verbose bool) {
-	if verbose {
-		l.log = log.New(os.Stdout, "D", log.LstdFlags|log.Lshortfile)
+// log writes record with source of caller of exported method.
+func (l Logger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip Callers, log and exported method
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
 
-	l.warn = log.New(os.Stderr, "E", log.LstdFlags|log.Lshortfile)
+	_ = l.h.Handle(ctx, r)
 }
 
-func (l Logger) Warn() *log.Logger                 { return l.warn }
-func (l Logger) Log() *log.Logger                  { return l.log }
-func (l Logger) Loggers() (warn, log *log.Logger)  { return l.Warn(), l.Log() }
-func (l *Logger) SetLoggers(warn, log *log.Logger) { l.warn, l.log = warn, log }
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
@@ -0,0 +1,95 @@
+package embedlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLogger(t *testing.T) {
+	Convey("Test Logger", t, func() {
+		var buf bytes.Buffer
+		levels := &Levels{}
+		l := New(&buf, FormatJSON, levels)
+
+		lastRecord := func() map[string]interface{} {
+			var rec map[string]interface{}
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			So(json.Unmarshal(lines[len(lines)-1], &rec), ShouldBeNil)
+			return rec
+		}
+
+		Convey("Zero Logger discards records", func() {
+			So(func() { Logger{}.Error(context.Background(), "failed") }, ShouldNotPanic)
+			So(Logger{}.Enabled(context.Background(), slog.LevelError), ShouldBeFalse)
+		})
+
+		Convey("Record has source of caller and request attributes", func() {
+			ctx := WithAttrs(context.Background(), slog.String("requestId", "abc"))
+			ctx = WithAttrs(ctx, slog.Int("userId", 1))
+			l.Component("vt").Error(ctx, "update user activity", "err", "timeout")
+
+			rec := lastRecord()
+			So(rec["level"], ShouldEqual, "ERROR")
+			So(rec["msg"], ShouldEqual, "update user activity")
+			So(rec["component"], ShouldEqual, "vt")
+			So(rec["requestId"], ShouldEqual, "abc")
+			So(rec["userId"], ShouldEqual, 1)
+			So(rec["source"].(map[string]interface{})["file"], ShouldEndWith, "logger_test.go")
+		})
+
+		Convey("Levels of components", func() {
+			So(levels.Set(Config{Level: "warn", Levels: []string{"scheduler=debug"}}, false), ShouldBeNil)
+
+			l.Info(context.Background(), "skipped")
+			So(buf.Len(), ShouldEqual, 0)
+
+			l.Component("scheduler").Debug(context.Background(), "written")
+			So(lastRecord()["msg"], ShouldEqual, "written")
+
+			Convey("Verbose sets default level to debug", func() {
+				So(levels.Set(Config{Level: "warn"}, true), ShouldBeNil)
+				So(l.Enabled(context.Background(), slog.LevelDebug), ShouldBeTrue)
+			})
+		})
+
+		Convey("Printf adapter", func() {
+			l.PrintfFunc(slog.LevelDebug)("method=%s", "news.get")
+			So(buf.Len(), ShouldEqual, 0)
+
+			l.PrintfFunc(slog.LevelError)("method=%s", "news.get")
+			So(lastRecord()["msg"], ShouldEqual, "method=news.get")
+		})
+
+		Convey("Events are counted by level", func() {
+			stat := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "events_total"}, []string{"type"})
+			SetStatLogEvents(stat)
+			defer SetStatLogEvents(nil)
+
+			l.Debug(context.Background(), "skipped")
+			l.Info(context.Background(), "started")
+			l.Error(context.Background(), "failed")
+			l.Error(context.Background(), "failed")
+
+			So(testutil.ToFloat64(stat.WithLabelValues("debug")), ShouldEqual, 0)
+			So(testutil.ToFloat64(stat.WithLabelValues("info")), ShouldEqual, 1)
+			So(testutil.ToFloat64(stat.WithLabelValues("error")), ShouldEqual, 2)
+		})
+	})
+}
+
+func TestConfig(t *testing.T) {
+	Convey("Test Config validation", t, func() {
+		So(Config{}.Validate(), ShouldBeNil)
+		So(Config{Format: FormatJSON, Level: "debug", Levels: []string{"vt=error"}}.Validate(), ShouldBeNil)
+		So(Config{Format: "xml"}.Validate(), ShouldNotBeNil)
+		So(Config{Level: "verbose"}.Validate(), ShouldNotBeNil)
+		So(Config{Levels: []string{"vt"}}.Validate(), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,155 @@
+// Package health runs registered liveness and readiness checks and reports them as JSON.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// Kind is a kind of check.
+type Kind int
+
+const (
+	// Liveness checks fail when process should be restarted.
+	Liveness Kind = iota
+	// Readiness checks fail when process should not receive traffic. Readiness report includes liveness checks.
+	Readiness
+)
+
+// CheckFunc checks a dependency and returns details for report, e.g. latency or pool stats.
+type CheckFunc func(ctx context.Context) (details map[string]interface{}, err error)
+
+// Result is a result of one check.
+type Result struct {
+	Name     string                 `json:"name"`
+	Status   string                 `json:"status"`
+	Duration string                 `json:"duration"`
+	Error    string                 `json:"error,omitempty"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is a result of all checks of a kind.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	kind Kind
+	fn   CheckFunc
+}
+
+// Registry holds checks. It is safe to add checks while handlers are serving requests.
+type Registry struct {
+	mu      sync.RWMutex
+	checks  []check
+	timeout time.Duration
+}
+
+// NewRegistry returns Registry which runs checks with given timeout, default is 5s.
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Registry{timeout: timeout}
+}
+
+// Add registers check of given kind. Check with the same name is replaced.
+func (r *Registry) Add(kind Kind, name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.checks {
+		if r.checks[i].name == name {
+			r.checks[i] = check{name: name, kind: kind, fn: fn}
+			return
+		}
+	}
+
+	r.checks = append(r.checks, check{name: name, kind: kind, fn: fn})
+}
+
+// Check runs checks of given kind concurrently and returns report in registration order.
+func (r *Registry) Check(ctx context.Context, kind Kind) Report {
+	r.mu.RLock()
+	var checks []check
+	for _, c := range r.checks {
+		if c.kind <= kind {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	report := Report{Status: StatusOK, Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, checks[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+// Handler returns http handler which responds with report of given kind.
+// Status code is 200 if all checks passed and 503 otherwise.
+func (r *Registry) Handler(kind Kind) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context(), kind)
+
+		code := http.StatusOK
+		if report.Status != StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+// run runs check and converts its error or panic to failed result.
+func run(ctx context.Context, c check) (res Result) {
+	start := time.Now()
+	res = Result{Name: c.name, Status: StatusOK}
+
+	defer func() {
+		if p := recover(); p != nil {
+			res.Status, res.Error = StatusFail, "panic in check"
+		}
+		res.Duration = time.Since(start).String()
+	}()
+
+	details, err := c.fn(ctx)
+	res.Details = details
+	if err != nil {
+		res.Status, res.Error = StatusFail, err.Error()
+	}
+
+	return res
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRegistry(t *testing.T) {
+	Convey("Test Registry", t, func() {
+		r := NewRegistry(0)
+		ok := func(context.Context) (map[string]interface{}, error) { return nil, nil }
+		fail := func(context.Context) (map[string]interface{}, error) {
+			return map[string]interface{}{"attempts": 1}, errors.New("unavailable")
+		}
+
+		r.Add(Liveness, "workers", ok)
+		r.Add(Readiness, "db", ok)
+
+		Convey("All checks passed", func() {
+			report := r.Check(context.Background(), Readiness)
+			So(report.Status, ShouldEqual, StatusOK)
+			So(report.Checks, ShouldHaveLength, 2)
+			So(report.Checks[0].Name, ShouldEqual, "workers")
+			So(report.Checks[1].Name, ShouldEqual, "db")
+		})
+
+		Convey("Liveness report does not include readiness checks", func() {
+			r.Add(Readiness, "db", fail)
+			report := r.Check(context.Background(), Liveness)
+			So(report.Status, ShouldEqual, StatusOK)
+			So(report.Checks, ShouldHaveLength, 1)
+		})
+
+		Convey("Failed check", func() {
+			r.Add(Readiness, "db", fail)
+			report := r.Check(context.Background(), Readiness)
+			So(report.Status, ShouldEqual, StatusFail)
+			So(report.Checks, ShouldHaveLength, 2)
+			So(report.Checks[1].Status, ShouldEqual, StatusFail)
+			So(report.Checks[1].Error, ShouldEqual, "unavailable")
+			So(report.Checks[1].Details, ShouldResemble, map[string]interface{}{"attempts": 1})
+		})
+
+		Convey("Panic in check", func() {
+			r.Add(Liveness, "workers", func(context.Context) (map[string]interface{}, error) { panic("boom") })
+			report := r.Check(context.Background(), Liveness)
+			So(report.Status, ShouldEqual, StatusFail)
+			So(report.Checks[0].Error, ShouldEqual, "panic in check")
+		})
+
+		Convey("Handler", func() {
+			r.Add(Readiness, "db", fail)
+
+			rec := httptest.NewRecorder()
+			r.Handler(Liveness)(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			So(rec.Code, ShouldEqual, http.StatusOK)
+
+			rec = httptest.NewRecorder()
+			r.Handler(Readiness)(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			So(rec.Code, ShouldEqual, http.StatusServiceUnavailable)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "application/json")
+
+			var report Report
+			So(json.Unmarshal(rec.Body.Bytes(), &report), ShouldBeNil)
+			So(report.Status, ShouldEqual, StatusFail)
+		})
+	})
+}
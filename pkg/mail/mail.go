@@ -0,0 +1,104 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"apisrv/pkg/embedlog"
+)
+
+const (
+	SenderSMTP = "smtp"
+	SenderFile = "file"
+	SenderLog  = "log"
+)
+
+type Config struct {
+	Sender string // smtp, file or log (default)
+	From   string
+	Lang   string // default language for templates: ru or en
+	Dir    string // output directory for file sender
+	SMTP   struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
+	}
+}
+
+// Message is a plain text email message.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Bytes returns message in RFC 5322 format.
+func (m Message) Bytes(from string, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// Sender delivers email messages.
+type Sender interface {
+	Send(ctx context.Context, from string, msg Message) error
+}
+
+// NewSender returns Sender according to cfg.Sender. Messages are written to log if sender is not set.
+func NewSender(cfg Config, logger embedlog.Logger) Sender {
+	switch cfg.Sender {
+	case SenderSMTP:
+		return NewSMTPSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password)
+	case SenderFile:
+		return NewFileSender(cfg.Dir)
+	default:
+		return NewLogSender(logger)
+	}
+}
+
+// Mailer renders templated messages and sends them with Sender.
+type Mailer struct {
+	sender    Sender
+	templates Templates
+	from      string
+	lang      string
+}
+
+// NewMailer returns new Mailer. Default language is used for all messages.
+func NewMailer(sender Sender, cfg Config) *Mailer {
+	lang := cfg.Lang
+	if lang == "" {
+		lang = LangRu
+	}
+
+	return &Mailer{
+		sender:    sender,
+		templates: NewTemplates(),
+		from:      cfg.From,
+		lang:      lang,
+	}
+}
+
+// Send renders template name with data and sends it to recipient.
+func (m *Mailer) Send(ctx context.Context, to, name string, data interface{}) error {
+	msg, err := m.templates.Render(m.lang, name, data)
+	if err != nil {
+		return err
+	}
+
+	msg.To = to
+	return m.sender.Send(ctx, m.from, msg)
+}
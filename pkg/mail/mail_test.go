@@ -0,0 +1,94 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/embedlog"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testSender struct {
+	messages []Message
+}
+
+func (s *testSender) Send(_ context.Context, _ string, msg Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func TestTemplates_Render(t *testing.T) {
+	Convey("Test Templates", t, func() {
+		tpls := NewTemplates()
+		data := map[string]interface{}{
+			"Login":     "editor",
+			"URL":       "https://vt.local/reset?token=abc",
+			"ExpiresAt": time.Date(2024, time.August, 1, 12, 30, 0, 0, time.UTC),
+		}
+
+		Convey("Russian invite", func() {
+			msg, err := tpls.Render(LangRu, TemplateInvite, data)
+			So(err, ShouldBeNil)
+			So(msg.Subject, ShouldEqual, "Приглашение в панель управления")
+			So(msg.Body, ShouldContainSubstring, "«editor»")
+			So(msg.Body, ShouldContainSubstring, "https://vt.local/reset?token=abc")
+			So(msg.Body, ShouldContainSubstring, "01.08.2024 12:30 UTC")
+		})
+
+		Convey("English reset", func() {
+			msg, err := tpls.Render(LangEn, TemplatePasswordReset, data)
+			So(err, ShouldBeNil)
+			So(msg.Subject, ShouldEqual, "Password reset")
+			So(msg.Body, ShouldStartWith, "Hello, editor!")
+			So(msg.Body, ShouldContainSubstring, "Aug 1, 2024 12:30 UTC")
+		})
+
+		Convey("Unknown language falls back to russian", func() {
+			msg, err := tpls.Render("de", TemplatePasswordReset, data)
+			So(err, ShouldBeNil)
+			So(msg.Subject, ShouldEqual, "Восстановление пароля")
+		})
+
+		Convey("Unknown template", func() {
+			_, err := tpls.Render(LangEn, "unknown", data)
+			So(err, ShouldBeError)
+		})
+	})
+}
+
+func TestMailer_Send(t *testing.T) {
+	Convey("Test Mailer", t, func() {
+		ctx := context.Background()
+		data := map[string]interface{}{"Login": "editor", "URL": "https://vt.local", "ExpiresAt": time.Now()}
+
+		Convey("Send with configured language", func() {
+			s := &testSender{}
+			m := NewMailer(s, Config{Lang: LangEn, From: "noreply@vt.local"})
+			So(m.Send(ctx, "editor@vt.local", TemplateInvite, data), ShouldBeNil)
+			So(s.messages, ShouldHaveLength, 1)
+			So(s.messages[0].To, ShouldEqual, "editor@vt.local")
+			So(s.messages[0].Subject, ShouldEqual, "Invitation to the control panel")
+		})
+
+		Convey("Send to file", func() {
+			dir := t.TempDir()
+			m := NewMailer(NewSender(Config{Sender: SenderFile, Dir: dir}, embedlog.Logger{}), Config{From: "noreply@vt.local"})
+			So(m.Send(ctx, "editor@vt.local", TemplatePasswordReset, data), ShouldBeNil)
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+			So(err, ShouldBeNil)
+			So(files, ShouldHaveLength, 1)
+
+			b, err := os.ReadFile(files[0])
+			So(err, ShouldBeNil)
+			So(string(b), ShouldContainSubstring, "To: editor@vt.local\r\n")
+			So(string(b), ShouldContainSubstring, "Subject: =?utf-8?q?")
+			So(strings.Contains(string(b), "https://vt.local"), ShouldBeTrue)
+		})
+	})
+}
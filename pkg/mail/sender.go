@@ -0,0 +1,105 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"apisrv/pkg/embedlog"
+)
+
+// SMTPSender sends messages via SMTP server. STARTTLS is used if server supports it.
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+}
+
+func NewSMTPSender(host string, port int, username, password string) *SMTPSender {
+	return &SMTPSender{host: host, port: port, username: username, password: password}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, from string, msg Message) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp client: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+
+	if s.username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err = c.Mail(from); err != nil {
+		return err
+	}
+	if err = c.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg.Bytes(from, time.Now())); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// FileSender writes every message to separate .eml file in directory. Useful for development and tests.
+type FileSender struct {
+	dir string
+}
+
+func NewFileSender(dir string) *FileSender {
+	return &FileSender{dir: dir}
+}
+
+func (s *FileSender) Send(_ context.Context, from string, msg Message) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000000"), strings.NewReplacer("@", "_", "/", "_").Replace(msg.To))
+
+	return os.WriteFile(filepath.Join(s.dir, name), msg.Bytes(from, now), 0o644)
+}
+
+// LogSender writes messages to debug log.
+type LogSender struct {
+	embedlog.Logger
+}
+
+func NewLogSender(logger embedlog.Logger) *LogSender {
+	return &LogSender{Logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, from string, msg Message) error {
+	s.Info(ctx, "mail", "from", from, "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	LangRu = "ru"
+	LangEn = "en"
+)
+
+const (
+	TemplateInvite        = "invite"
+	TemplatePasswordReset = "reset"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Templates stores parsed message templates by language and name.
+type Templates struct {
+	tpls map[string]*template.Template
+}
+
+// NewTemplates parses all embedded templates. Each template must define "subject" and "body".
+func NewTemplates() Templates {
+	t := Templates{tpls: make(map[string]*template.Template)}
+	for _, lang := range []string{LangRu, LangEn} {
+		for _, name := range []string{TemplateInvite, TemplatePasswordReset} {
+			path := fmt.Sprintf("templates/%s/%s.tmpl", lang, name)
+			t.tpls[t.key(lang, name)] = template.Must(template.ParseFS(templatesFS, path))
+		}
+	}
+
+	return t
+}
+
+func (t Templates) key(lang, name string) string {
+	return lang + "/" + name
+}
+
+// Render executes template name for given language. Russian templates are used for unknown languages.
+func (t Templates) Render(lang, name string, data interface{}) (Message, error) {
+	tpl, ok := t.tpls[t.key(lang, name)]
+	if !ok {
+		if tpl, ok = t.tpls[t.key(LangRu, name)]; !ok {
+			return Message{}, fmt.Errorf("unknown mail template %q", name)
+		}
+	}
+
+	var subject, body bytes.Buffer
+	if err := tpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
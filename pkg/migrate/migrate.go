@@ -0,0 +1,346 @@
+// Package migrate applies versioned SQL migrations embedded into the binary.
+//
+// Migrations are stored in the migrations directory as NNNN_name.up.sql and NNNN_name.down.sql pairs.
+// Applied versions are tracked in schemaMigrations table. All changes are made in one transaction
+// under advisory lock, so it is safe to run migrations on several replicas at once.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	"github.com/go-pg/pg/v10"
+)
+
+const (
+	lockName = "schemaMigrations"
+	dir      = "migrations"
+)
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+var (
+	// ErrUnknownVersion is returned when DB has applied version which is not embedded into the binary.
+	ErrUnknownVersion = errors.New("unknown migration version")
+
+	fileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+)
+
+const createTableSQL = `CREATE TABLE IF NOT EXISTS "schemaMigrations" (
+	"version" int4 NOT NULL,
+	"name" varchar(255) NOT NULL,
+	"appliedAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "schemaMigrations_pkey" PRIMARY KEY("version")
+)`
+
+type Config struct {
+	OnStart bool // apply pending migrations before starting the app
+}
+
+// Migration is a versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// State is a migration with its applying time. AppliedAt is nil for pending migrations.
+type State struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	tableName struct{} `pg:"schemaMigrations,alias:t,discard_unknown_columns"`
+
+	Version   int       `pg:"version,pk,use_zero"`
+	Name      string    `pg:"name,use_zero"`
+	AppliedAt time.Time `pg:"appliedAt,use_zero"`
+}
+
+// Migrator applies embedded migrations to DB.
+type Migrator struct {
+	embedlog.Logger
+	dbo        db.DB
+	migrations []Migration
+}
+
+// New returns Migrator with migrations embedded into the binary.
+func New(dbo db.DB, logger embedlog.Logger) (*Migrator, error) {
+	ms, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{Logger: logger, dbo: dbo, migrations: ms}, nil
+}
+
+// Embedded returns migrations embedded into the binary sorted by version.
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(embedded, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(sub)
+}
+
+// Load reads migrations from the root of fsys and returns them sorted by version.
+// Every version must have both up and down files with the same name.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+
+		parts := fileRe.FindStringSubmatch(e.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("migration %s: invalid file name", e.Name())
+		}
+
+		version, err := strconv.Atoi(parts[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", e.Name())
+		}
+
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		} else if m.Name != parts[2] {
+			return nil, fmt.Errorf("migration %s: version %d is already used by %s", e.Name(), version, m.Name)
+		}
+
+		if parts[3] == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	ms := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		switch {
+		case m.Up == "":
+			return nil, fmt.Errorf("migration %d_%s: up file is missing or empty", m.Version, m.Name)
+		case m.Down == "":
+			return nil, fmt.Errorf("migration %d_%s: down file is missing or empty", m.Version, m.Name)
+		}
+		ms = append(ms, *m)
+	}
+
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Version < ms[j].Version })
+
+	return ms, nil
+}
+
+// Pending returns migrations which versions are not applied yet.
+func Pending(ms []Migration, applied map[int]struct{}) []Migration {
+	var r []Migration
+	for _, m := range ms {
+		if _, ok := applied[m.Version]; !ok {
+			r = append(r, m)
+		}
+	}
+
+	return r
+}
+
+// Status returns all known migrations with their applying time.
+// Applied versions which are not embedded into the binary are returned with empty Up and Down.
+func (m *Migrator) Status(ctx context.Context) ([]State, error) {
+	var applied []appliedMigration
+	err := m.dbo.RunInLock(ctx, lockName, func(tx *pg.Tx) (err error) {
+		applied, err = m.applied(ctx, tx)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]time.Time, len(applied))
+	for _, a := range applied {
+		byVersion[a.Version] = a.AppliedAt
+	}
+
+	r := make([]State, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		s := State{Migration: mg}
+		if at, ok := byVersion[mg.Version]; ok {
+			s.AppliedAt = &at
+			delete(byVersion, mg.Version)
+		}
+		r = append(r, s)
+	}
+
+	for _, a := range applied {
+		if _, ok := byVersion[a.Version]; ok {
+			at := a.AppliedAt
+			r = append(r, State{Migration: Migration{Version: a.Version, Name: a.Name}, AppliedAt: &at})
+		}
+	}
+
+	sort.SliceStable(r, func(i, j int) bool { return r[i].Version < r[j].Version })
+
+	return r, nil
+}
+
+// Up applies all pending migrations and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.dbo.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		applied, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, mg := range Pending(m.migrations, appliedVersions(applied)) {
+			if _, err = tx.ExecContext(ctx, mg.Up); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+
+			if _, err = tx.ModelContext(ctx, &appliedMigration{Version: mg.Version, Name: mg.Name}).ExcludeColumn("appliedAt").Insert(); err != nil {
+				return err
+			}
+
+			done = append(done, mg)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mg := range done {
+		m.Info(ctx, "migration applied", "version", mg.Version, "name", mg.Name)
+	}
+
+	return done, nil
+}
+
+// Down rolls back given number of last applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.dbo.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		applied, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			mg, ok := m.byVersion(applied[i].Version)
+			if !ok {
+				return fmt.Errorf("%w: %d_%s", ErrUnknownVersion, applied[i].Version, applied[i].Name)
+			}
+
+			if _, err = tx.ExecContext(ctx, mg.Down); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+
+			if _, err = tx.ModelContext(ctx, &appliedMigration{Version: mg.Version}).WherePK().Delete(); err != nil {
+				return err
+			}
+
+			done = append(done, mg)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mg := range done {
+		m.Info(ctx, "migration rolled back", "version", mg.Version, "name", mg.Name)
+	}
+
+	return done, nil
+}
+
+// Baseline marks all migrations up to version as applied without running them.
+// It is used for databases created by hand before migrations were introduced.
+func (m *Migrator) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	if _, ok := m.byVersion(version); !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	var done []Migration
+	err := m.dbo.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		applied, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, mg := range Pending(m.migrations, appliedVersions(applied)) {
+			if mg.Version > version {
+				break
+			}
+
+			if _, err = tx.ModelContext(ctx, &appliedMigration{Version: mg.Version, Name: mg.Name}).ExcludeColumn("appliedAt").Insert(); err != nil {
+				return err
+			}
+
+			done = append(done, mg)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// applied creates tracking table if needed and returns applied migrations sorted by version.
+func (m *Migrator) applied(ctx context.Context, tx *pg.Tx) ([]appliedMigration, error) {
+	if _, err := tx.ExecContext(ctx, createTableSQL); err != nil {
+		return nil, err
+	}
+
+	var list []appliedMigration
+	err := tx.ModelContext(ctx, &list).Order("version").Select()
+
+	return list, err
+}
+
+func (m *Migrator) byVersion(version int) (Migration, bool) {
+	for _, mg := range m.migrations {
+		if mg.Version == version {
+			return mg, true
+		}
+	}
+
+	return Migration{}, false
+}
+
+func appliedVersions(list []appliedMigration) map[int]struct{} {
+	r := make(map[int]struct{}, len(list))
+	for _, a := range list {
+		r[a.Version] = struct{}{}
+	}
+
+	return r
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoad(t *testing.T) {
+	Convey("Test Load", t, func() {
+		file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+
+		Convey("Migrations are sorted by version", func() {
+			ms, err := Load(fstest.MapFS{
+				"0010_news.up.sql":   file("create news"),
+				"0010_news.down.sql": file("drop news"),
+				"0002_tags.up.sql":   file("create tags"),
+				"0002_tags.down.sql": file("drop tags"),
+				"README.md":          file("readme"),
+			})
+			So(err, ShouldBeNil)
+			So(ms, ShouldResemble, []Migration{
+				{Version: 2, Name: "tags", Up: "create tags", Down: "drop tags"},
+				{Version: 10, Name: "news", Up: "create news", Down: "drop news"},
+			})
+		})
+
+		Convey("Invalid file name", func() {
+			_, err := Load(fstest.MapFS{"news.up.sql": file("create news")})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Down file is missing", func() {
+			_, err := Load(fstest.MapFS{"0001_news.up.sql": file("create news")})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Version is used twice", func() {
+			_, err := Load(fstest.MapFS{
+				"0001_news.up.sql":   file("create news"),
+				"0001_news.down.sql": file("drop news"),
+				"0001_tags.up.sql":   file("create tags"),
+				"0001_tags.down.sql": file("drop tags"),
+			})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Embedded migrations", func() {
+			ms, err := Embedded()
+			So(err, ShouldBeNil)
+			So(ms, ShouldNotBeEmpty)
+			So(ms[0].Version, ShouldEqual, 1)
+			So(ms[0].Up, ShouldContainSubstring, `CREATE TABLE "news"`)
+		})
+	})
+}
+
+func TestPending(t *testing.T) {
+	Convey("Test Pending", t, func() {
+		ms := []Migration{{Version: 1}, {Version: 2}, {Version: 3}}
+
+		So(Pending(ms, nil), ShouldResemble, ms)
+		So(Pending(ms, map[int]struct{}{1: {}, 3: {}}), ShouldResemble, []Migration{{Version: 2}})
+		So(Pending(ms, map[int]struct{}{1: {}, 2: {}, 3: {}}), ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,20 @@
+-- Drops everything created by 0001_init.up.sql.
+
+DROP TABLE IF EXISTS "categories" CASCADE;
+DROP TABLE IF EXISTS "trashItems" CASCADE;
+DROP TABLE IF EXISTS "auditLogs" CASCADE;
+DROP TABLE IF EXISTS "apiTokens" CASCADE;
+DROP TABLE IF EXISTS "newsRevisions" CASCADE;
+DROP TABLE IF EXISTS "newsTransitions" CASCADE;
+DROP TABLE IF EXISTS "newsAuthors" CASCADE;
+DROP TABLE IF EXISTS "authors" CASCADE;
+DROP TABLE IF EXISTS "newsTags" CASCADE;
+DROP TABLE IF EXISTS "news" CASCADE;
+DROP TABLE IF EXISTS "tagMerges" CASCADE;
+DROP TABLE IF EXISTS "tags" CASCADE;
+DROP TABLE IF EXISTS "vfsHashes" CASCADE;
+DROP TABLE IF EXISTS "vfsFolders" CASCADE;
+DROP TABLE IF EXISTS "vfsFiles" CASCADE;
+DROP TABLE IF EXISTS "userTokens" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
+DROP TABLE IF EXISTS "statuses" CASCADE;
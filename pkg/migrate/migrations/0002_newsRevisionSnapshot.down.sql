@@ -0,0 +1,4 @@
+-- Drops columns added by 0002_newsRevisionSnapshot.up.sql.
+
+ALTER TABLE "newsRevisions" DROP COLUMN IF EXISTS "unpublishAt";
+ALTER TABLE "newsRevisions" DROP COLUMN IF EXISTS "authorIds";
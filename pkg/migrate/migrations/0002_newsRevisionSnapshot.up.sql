@@ -0,0 +1,9 @@
+-- Adds authors and unpublish time to news revisions, so revision is a full snapshot of news fields.
+-- Existing revisions get current values of their news.
+
+ALTER TABLE "newsRevisions" ADD COLUMN "authorIds" int4[] NOT NULL DEFAULT '{}';
+ALTER TABLE "newsRevisions" ADD COLUMN "unpublishAt" timestamp with time zone;
+
+UPDATE "newsRevisions" r SET
+	"authorIds" = coalesce((SELECT array_agg(na."authorId" ORDER BY na."position") FROM "newsAuthors" na WHERE na."newsId" = r."newsId"), '{}'),
+	"unpublishAt" = (SELECT n."unpublishAt" FROM "news" n WHERE n."newsId" = r."newsId");
@@ -0,0 +1,74 @@
+package newsportal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheFlushChannel is a Postgres notification channel, running servers flush their caches on notification.
+const CacheFlushChannel = "cacheFlush"
+
+type CacheConfig struct {
+	TTL time.Duration // lifetime of cached categories, tags and authors lists, zero disables cache
+}
+
+type cacheItem struct {
+	value     any
+	expiresAt time.Time
+}
+
+// listCache keeps results of Manager calls without params for TTL.
+type listCache struct {
+	ttl   time.Duration
+	mu    sync.RWMutex
+	items map[string]cacheItem
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{ttl: ttl, items: make(map[string]cacheItem)}
+}
+
+// get returns cached value by key if it is not expired.
+func (c *listCache) get(key string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	item, ok := c.items[key]
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+func (c *listCache) set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = cacheItem{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// flush removes all cached values.
+func (c *listCache) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]cacheItem)
+}
+
+// cached returns value by key from cache or calls fn and caches its result. Errors are not cached.
+func cached[T any](ctx context.Context, c *listCache, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if c == nil || c.ttl <= 0 {
+		return fn(ctx)
+	}
+
+	if v, ok := c.get(key); ok {
+		return v.(T), nil
+	}
+
+	v, err := fn(ctx)
+	if err == nil {
+		c.set(key, v)
+	}
+	return v, err
+}
@@ -0,0 +1,59 @@
+package newsportal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCache(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	fn := func(context.Context) ([]int, error) {
+		calls++
+		return []int{calls}, nil
+	}
+
+	t.Run("disabled cache calls fn every time", func(t *testing.T) {
+		calls = 0
+		for _, c := range []*listCache{nil, newListCache(0)} {
+			_, _ = cached(ctx, c, "key", fn)
+		}
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("cached value is returned until flush", func(t *testing.T) {
+		calls = 0
+		c := newListCache(time.Minute)
+
+		v, err := cached(ctx, c, "key", fn)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, v)
+
+		v, _ = cached(ctx, c, "key", fn)
+		assert.Equal(t, []int{1}, v)
+
+		c.flush()
+		v, _ = cached(ctx, c, "key", fn)
+		assert.Equal(t, []int{2}, v)
+	})
+
+	t.Run("expired value and errors are not returned from cache", func(t *testing.T) {
+		calls = 0
+		c := newListCache(time.Millisecond)
+
+		_, _ = cached(ctx, c, "key", fn)
+		time.Sleep(2 * time.Millisecond)
+		v, _ := cached(ctx, c, "key", fn)
+		assert.Equal(t, []int{2}, v)
+
+		errFailed := errors.New("failed")
+		_, err := cached(ctx, c, "err", func(context.Context) ([]int, error) { return nil, errFailed })
+		assert.ErrorIs(t, err, errFailed)
+		_, ok := c.get("err")
+		assert.False(t, ok)
+	})
+}
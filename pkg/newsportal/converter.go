@@ -38,6 +38,17 @@ func newCategories(in []db.Category) (out []Category) {
 	return
 }
 
+func newCategoryNodes(in []db.CategoryNode) []CategoryNode {
+	out := make([]CategoryNode, 0, len(in))
+	for i := range in {
+		out = append(out, CategoryNode{
+			Category: Category{Category: &in[i].Category},
+			Children: newCategoryNodes(in[i].Children),
+		})
+	}
+	return out
+}
+
 func newTag(in *db.Tag) *Tag {
 	if in == nil {
 		return nil
@@ -54,3 +65,20 @@ func newTags(in []db.Tag) (out []Tag) {
 	}
 	return
 }
+
+func newAuthor(in *db.Author) *Author {
+	if in == nil {
+		return nil
+	}
+
+	return &Author{
+		Author: in,
+	}
+}
+
+func newAuthors(in []db.Author) (out []Author) {
+	for i := range in {
+		out = append(out, *newAuthor(&in[i]))
+	}
+	return
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 
 	"apisrv/pkg/db"
+	"apisrv/pkg/workflow"
+
+	"go.opentelemetry.io/otel"
 )
 
 type Manager struct {
-	nr db.NewsRepo
+	nr    db.NewsRepo
+	cache *listCache
 }
 
 const defaultPage = 1
@@ -15,10 +19,29 @@ const defaultPageSize = 2
 
 func ptri(r int) *int { return &r }
 
+// tracer starts spans of Manager calls.
+var tracer = otel.Tracer("apisrv/pkg/newsportal")
+
+// publishedState is the only workflow state visible on the portal.
+var publishedState = string(workflow.StatePublished)
+
 func NewManager(db db.NewsRepo) *Manager {
 	return &Manager{nr: db}
 }
 
+// WithCache returns Manager which caches lists of categories, tags and authors for cfg.TTL.
+func (m Manager) WithCache(cfg CacheConfig) *Manager {
+	m.cache = newListCache(cfg.TTL)
+	return &m
+}
+
+// FlushCache removes all cached lists.
+func (m Manager) FlushCache() {
+	if m.cache != nil {
+		m.cache.flush()
+	}
+}
+
 func checkPagination(page, pageSize *int) (int, int) {
 	if page == nil {
 		page = ptri(defaultPage)
@@ -34,31 +57,39 @@ func checkPagination(page, pageSize *int) (int, int) {
 	return *page, *pageSize
 }
 
+// FillTags sets TagIDs and Tags of news, links and tags are loaded with a single join query.
 func (m Manager) FillTags(ctx context.Context, newsList NewsList) error {
+	ctx, span := tracer.Start(ctx, "Manager.FillTags")
+	defer span.End()
 
-	tagMap := make(map[int]Tag)
-
-	// возвращаем теги из бд
-	tags, err := m.TagsByIDs(ctx, newsList.TagIDs())
+	byID := make(map[int][]int, len(newsList))
+	for i := range newsList {
+		newsList[i].TagIDs = []int{}
+		byID[newsList[i].ID] = append(byID[newsList[i].ID], i)
+	}
 
-	// создаём карту тегов
-	for _, tag := range tags {
-		tagMap[tag.ID] = tag
+	newsTags, err := m.nr.NewsTagsByNewsIDs(ctx, newsList.IDs(), db.WithRelations(db.Columns.NewsTag.Tag))
+	if err != nil {
+		return err
 	}
-	for i, summary := range newsList {
-		for _, tagId := range summary.TagIDs {
-			_, exist := tagMap[tagId]
-			if exist {
-				newsList[i].Tags = append(newsList[i].Tags, tagMap[tagId])
+
+	for _, nt := range newsTags {
+		for _, i := range byID[nt.NewsID] {
+			newsList[i].TagIDs = append(newsList[i].TagIDs, nt.TagID)
+			if nt.Tag != nil && nt.Tag.StatusID != db.StatusDeleted {
+				newsList[i].Tags = append(newsList[i].Tags, *newTag(nt.Tag))
 			}
 		}
 	}
 
-	return err
+	return nil
 }
 
 func (m Manager) NewsByID(ctx context.Context, id int) (*News, error) {
-	news, err := m.nr.NewsByID(ctx, id, db.WithRelations(db.Columns.News.Category))
+	ctx, span := tracer.Start(ctx, "Manager.NewsByID")
+	defer span.End()
+
+	news, err := m.nr.OneNews(ctx, &db.NewsSearch{ID: &id, State: &publishedState}, db.WithRelations(db.Columns.News.Category))
 	if err != nil {
 		return nil, err
 	} else if news == nil {
@@ -72,9 +103,66 @@ func (m Manager) NewsByID(ctx context.Context, id int) (*News, error) {
 	return &n[0], err
 }
 
-func (m Manager) News(ctx context.Context, categoryID, tagID, page, pageSize *int) ([]News, error) {
+// newsSearch returns search of published news, with subcategories news of all descendants of the category are matched too.
+func newsSearch(categoryID, tagID *int, withSubcategories bool) *db.NewsSearch {
+	search := &db.NewsSearch{CategoryID: categoryID, TagID: tagID, State: &publishedState}
+	if withSubcategories {
+		search.CategoryID, search.CategoryTreeID = nil, categoryID
+	}
+	return search
+}
+
+// resolveTagID returns id of the Tag which the Tag was merged into, or the same id.
+func (m Manager) resolveTagID(ctx context.Context, tagID *int) (*int, error) {
+	if tagID == nil {
+		return nil, nil
+	}
+
+	ids, err := m.nr.ResolveTagIDs(ctx, []int{*tagID})
+	if err != nil {
+		return nil, err
+	}
+	return &ids[0], nil
+}
+
+func (m Manager) News(ctx context.Context, categoryID, tagID *int, withSubcategories bool, page, pageSize *int) ([]News, error) {
+	ctx, span := tracer.Start(ctx, "Manager.News")
+	defer span.End()
+
+	tagID, err := m.resolveTagID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	newPage, newPageSize := checkPagination(page, pageSize)
+	news, err := m.nr.NewsByFilters(ctx, newsSearch(categoryID, tagID, withSubcategories), db.Pager{Page: newPage, PageSize: newPageSize}, db.WithRelations(db.Columns.News.Category))
+	if err != nil {
+		return nil, err
+	} else if len(news) == 0 {
+		return nil, nil
+	}
+
+	newsList := newNewsList(news)
+	err = m.FillTags(ctx, newsList)
+
+	return newsList, err
+}
+
+// NewsByAuthor returns published news where the Author is one of co-authors.
+// News of disabled or deleted Author are not returned.
+func (m Manager) NewsByAuthor(ctx context.Context, authorID int, page, pageSize *int) ([]News, error) {
+	ctx, span := tracer.Start(ctx, "Manager.NewsByAuthor")
+	defer span.End()
+
+	author, err := m.nr.OneAuthor(ctx, &db.AuthorSearch{ID: &authorID, StatusID: ptri(db.StatusEnabled)})
+	if err != nil {
+		return nil, err
+	} else if author == nil {
+		return nil, nil
+	}
+
 	newPage, newPageSize := checkPagination(page, pageSize)
-	news, err := m.nr.NewsByFilters(ctx, &db.NewsSearch{CategoryID: categoryID, TagIDsILike: tagID}, db.Pager{Page: newPage, PageSize: newPageSize}, db.WithRelations(db.Columns.News.Category))
+	news, err := m.nr.NewsByFilters(ctx, &db.NewsSearch{AuthorID: &authorID, State: &publishedState}, db.Pager{Page: newPage, PageSize: newPageSize}, db.WithRelations(db.Columns.News.Category))
 	if err != nil {
 		return nil, err
 	} else if len(news) == 0 {
@@ -87,32 +175,86 @@ func (m Manager) News(ctx context.Context, categoryID, tagID, page, pageSize *in
 	return newsList, err
 }
 
-func (m Manager) NewsCount(ctx context.Context, categoryID, tagID *int) (*int, error) {
-	count, err := m.nr.CountNews(ctx, &db.NewsSearch{CategoryID: categoryID, TagIDsILike: tagID})
+func (m Manager) NewsCount(ctx context.Context, categoryID, tagID *int, withSubcategories bool) (*int, error) {
+	ctx, span := tracer.Start(ctx, "Manager.NewsCount")
+	defer span.End()
+
+	tagID, err := m.resolveTagID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := m.nr.CountNews(ctx, newsSearch(categoryID, tagID, withSubcategories))
 
 	return &count, err
 }
 
-// Categories возвращает все категории
+// Categories возвращает все категории, список кэшируется
 func (m Manager) Categories(ctx context.Context) ([]Category, error) {
-	categories, err := m.nr.CategoriesByFilters(ctx, &db.CategorySearch{}, db.PagerNoLimit)
+	ctx, span := tracer.Start(ctx, "Manager.Categories")
+	defer span.End()
 
-	return newCategories(categories), err
+	return cached(ctx, m.cache, "categories", func(ctx context.Context) ([]Category, error) {
+		categories, err := m.nr.CategoriesByFilters(ctx, &db.CategorySearch{}, db.PagerNoLimit)
+
+		return newCategories(categories), err
+	})
+}
+
+// CategoryTree возвращает дерево категорий, подкатегории упорядочены по orderNumber, дерево кэшируется
+func (m Manager) CategoryTree(ctx context.Context) ([]CategoryNode, error) {
+	ctx, span := tracer.Start(ctx, "Manager.CategoryTree")
+	defer span.End()
+
+	return cached(ctx, m.cache, "categoryTree", func(ctx context.Context) ([]CategoryNode, error) {
+		categories, err := m.nr.CategoriesByFilters(ctx, &db.CategorySearch{}, db.PagerNoLimit,
+			db.WithSort(db.NewSortField(db.Columns.Category.OrderNumber, false), db.NewSortField(db.Columns.Category.Title, false)))
+		if err != nil {
+			return nil, err
+		}
+
+		return newCategoryNodes(db.NewCategoryTree(categories)), nil
+	})
 }
 
 func (m Manager) TagsByIDs(ctx context.Context, tagIDs []int) ([]Tag, error) {
+	ctx, span := tracer.Start(ctx, "Manager.TagsByIDs")
+	defer span.End()
+
 	if len(tagIDs) == 0 {
 		return nil, nil
 	}
 
+	tagIDs, err := m.nr.ResolveTagIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	tags, err := m.nr.TagsByFilters(ctx, &db.TagSearch{IDs: tagIDs}, db.PagerNoLimit)
 
 	return newTags(tags), err
 }
 
-// Tags возвращает все теги
+// Tags возвращает все теги, список кэшируется
 func (m Manager) Tags(ctx context.Context) ([]Tag, error) {
-	tags, err := m.nr.TagsByFilters(ctx, &db.TagSearch{}, db.PagerNoLimit)
+	ctx, span := tracer.Start(ctx, "Manager.Tags")
+	defer span.End()
 
-	return newTags(tags), err
+	return cached(ctx, m.cache, "tags", func(ctx context.Context) ([]Tag, error) {
+		tags, err := m.nr.TagsByFilters(ctx, &db.TagSearch{}, db.PagerNoLimit)
+
+		return newTags(tags), err
+	})
+}
+
+// Authors returns all enabled authors, the list is cached.
+func (m Manager) Authors(ctx context.Context) ([]Author, error) {
+	ctx, span := tracer.Start(ctx, "Manager.Authors")
+	defer span.End()
+
+	return cached(ctx, m.cache, "authors", func(ctx context.Context) ([]Author, error) {
+		authors, err := m.nr.AuthorsByFilters(ctx, &db.AuthorSearch{StatusID: ptri(db.StatusEnabled)}, db.PagerNoLimit)
+
+		return newAuthors(authors), err
+	})
 }
@@ -49,14 +49,17 @@ func TestMain(m *testing.M) {
 			Content:     ptrs("Контент"),
 			TagIDs:      []int{1, 2, 3},
 			Author:      "Автор",
+			State:       "published",
 			PublishedAt: time.Date(2024, time.July, 17, 18, 25, 28, 10745000, time.Local),
 			StatusID:    1,
+			Version:     1,
 			Category: &db.Category{
 				ID:          1,
 				Title:       "рр",
 				OrderNumber: nil,
 				Alias:       "к",
 				StatusID:    1,
+				Version:     1,
 			},
 		},
 		Category: &Category{&db.Category{
@@ -65,6 +68,7 @@ func TestMain(m *testing.M) {
 			OrderNumber: nil,
 			Alias:       "к",
 			StatusID:    1,
+			Version:     1,
 		},
 		},
 		Tags: []Tag{
@@ -73,6 +77,7 @@ func TestMain(m *testing.M) {
 					ID:       1,
 					Title:    "заголовок1",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 			{
@@ -80,6 +85,7 @@ func TestMain(m *testing.M) {
 					ID:       2,
 					Title:    "заголовок2",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 			{
@@ -87,6 +93,7 @@ func TestMain(m *testing.M) {
 					ID:       3,
 					Title:    "заголовок3",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 		},
@@ -100,14 +107,17 @@ func TestMain(m *testing.M) {
 			Content:     ptrs("Контент"),
 			TagIDs:      []int{1, 2},
 			Author:      "Автор",
+			State:       "published",
 			PublishedAt: time.Date(2024, time.July, 17, 18, 25, 28, 10745000, time.Local),
 			StatusID:    1,
+			Version:     1,
 			Category: &db.Category{
 				ID:          1,
 				Title:       "рр",
 				OrderNumber: nil,
 				Alias:       "к",
 				StatusID:    1,
+				Version:     1,
 			},
 		},
 		Category: &Category{&db.Category{
@@ -116,6 +126,7 @@ func TestMain(m *testing.M) {
 			OrderNumber: nil,
 			Alias:       "к",
 			StatusID:    1,
+			Version:     1,
 		},
 		},
 		Tags: []Tag{
@@ -124,6 +135,7 @@ func TestMain(m *testing.M) {
 					ID:       1,
 					Title:    "заголовок1",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 			{
@@ -131,6 +143,7 @@ func TestMain(m *testing.M) {
 					ID:       2,
 					Title:    "заголовок2",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 		},
@@ -143,14 +156,17 @@ func TestMain(m *testing.M) {
 			Content:     ptrs("Контент"),
 			TagIDs:      []int{1, 2},
 			Author:      "Автор",
+			State:       "published",
 			PublishedAt: time.Date(2024, time.July, 17, 18, 25, 28, 10745000, time.Local),
 			StatusID:    1,
+			Version:     1,
 			Category: &db.Category{
 				ID:          1,
 				Title:       "рр",
 				OrderNumber: nil,
 				Alias:       "к",
 				StatusID:    1,
+				Version:     1,
 			},
 		},
 		Category: &Category{&db.Category{
@@ -159,6 +175,7 @@ func TestMain(m *testing.M) {
 			OrderNumber: nil,
 			Alias:       "к",
 			StatusID:    1,
+			Version:     1,
 		},
 		},
 		Tags: []Tag{
@@ -167,6 +184,7 @@ func TestMain(m *testing.M) {
 					ID:       1,
 					Title:    "заголовок1",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 			{
@@ -174,6 +192,7 @@ func TestMain(m *testing.M) {
 					ID:       2,
 					Title:    "заголовок2",
 					StatusID: 1,
+					Version:  1,
 				},
 			},
 		},
@@ -225,35 +244,32 @@ func TestNewsByID(t *testing.T) {
 func TestFillTags(t *testing.T) {
 	// Инициализация тестовых данных
 	newsListTrue := []News{
-		{News: &db.News{ID: 1, TagIDs: []int{1, 2}}},
-		{News: &db.News{ID: 2, TagIDs: []int{2, 3}}},
+		{News: &db.News{ID: 15}},
+		{News: &db.News{ID: 16}},
 	}
 	newsListWrong := []News{
-		{News: &db.News{ID: 1, TagIDs: []int{}}},
-		{News: &db.News{ID: 2, TagIDs: []int{2, 13}}},
+		{News: &db.News{ID: 0}},
 	}
 	// Вызов метода
 	err := nm.FillTags(context.Background(), newsListTrue)
 	assert.NoError(t, err)
 
 	// Проверка результатов
-	trueTags := map[int][]Tag{
-		1: {{&db.Tag{ID: 1, Title: "заголовок1", StatusID: 1}}, {&db.Tag{ID: 2, Title: "заголовок2", StatusID: 1}}},
-		2: {{&db.Tag{ID: 2, Title: "заголовок2", StatusID: 1}}, {&db.Tag{ID: 3, Title: "заголовок3", StatusID: 1}}},
+	trueTagIDs := map[int][]int{
+		15: {1, 2, 3},
+		16: {1, 2},
 	}
+	for _, news := range newsListTrue {
+		assert.Equal(t, trueTagIDs[news.ID], news.TagIDs)
+	}
+	assert.Equal(t, realNews15.Tags, newsListTrue[0].Tags)
+	assert.Equal(t, realNews16.Tags, newsListTrue[1].Tags)
+
 	err = nm.FillTags(context.Background(), newsListWrong)
 	assert.NoError(t, err)
 	// Проверка результатов
-	wrongTags := map[int][]Tag{
-		1: nil,
-		2: {{&db.Tag{ID: 2, Title: "заголовок2", StatusID: 1}}},
-	}
-	for _, news := range newsListTrue {
-		assert.Equal(t, trueTags[news.ID], news.Tags)
-	}
-	for _, news := range newsListWrong {
-		assert.Equal(t, wrongTags[news.ID], news.Tags)
-	}
+	assert.Equal(t, []int{}, newsListWrong[0].TagIDs)
+	assert.Nil(t, newsListWrong[0].Tags)
 }
 
 func TestNews(t *testing.T) {
@@ -321,7 +337,7 @@ func TestNews(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := nm.News(tt.args.ctx, tt.args.categoryID, tt.args.tagID, tt.args.page, tt.args.pageSize)
+			got, err := nm.News(tt.args.ctx, tt.args.categoryID, tt.args.tagID, false, tt.args.page, tt.args.pageSize)
 			if !tt.wantErr(t, err, fmt.Sprintf("News(%v, %v, %v, %v, %v)", tt.args.ctx, tt.args.categoryID, tt.args.tagID, tt.args.page, tt.args.pageSize)) {
 				return
 			}
@@ -369,7 +385,7 @@ func TestNewsCount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := nm.NewsCount(tt.args.ctx, tt.args.categoryID, tt.args.tagID)
+			got, err := nm.NewsCount(tt.args.ctx, tt.args.categoryID, tt.args.tagID, false)
 			if !tt.wantErr(t, err, fmt.Sprintf("NewsCount(%v, %v, %v)", tt.args.ctx, tt.args.categoryID, tt.args.tagID)) {
 				return
 			}
@@ -395,7 +411,7 @@ func TestTagsByIDs(t *testing.T) {
 				ctx:    context.Background(),
 				tagIDs: []int{1, 2},
 			},
-			want:    []Tag{{&db.Tag{ID: 1, Title: "заголовок1", StatusID: 1}}, {&db.Tag{ID: 2, Title: "заголовок2", StatusID: 1}}},
+			want:    []Tag{{&db.Tag{ID: 1, Title: "заголовок1", StatusID: 1, Version: 1}}, {&db.Tag{ID: 2, Title: "заголовок2", StatusID: 1, Version: 1}}},
 			wantErr: assert.NoError,
 		},
 		{
@@ -404,7 +420,7 @@ func TestTagsByIDs(t *testing.T) {
 				ctx:    context.Background(),
 				tagIDs: []int{1, 13},
 			},
-			want:    []Tag{{&db.Tag{ID: 1, Title: "заголовок1", StatusID: 1}}},
+			want:    []Tag{{&db.Tag{ID: 1, Title: "заголовок1", StatusID: 1, Version: 1}}},
 			wantErr: assert.NoError,
 		},
 	}
@@ -14,26 +14,27 @@ type Category struct {
 	*db.Category
 }
 
+// CategoryNode is a Category with its subcategories.
+type CategoryNode struct {
+	Category
+	Children []CategoryNode
+}
+
 type Tag struct {
 	*db.Tag
 }
 
-type NewsList []News
-
-func (nn NewsList) TagIDs() []int {
-	// собираем все уникальные tagID
+type Author struct {
+	*db.Author
+}
 
-	tagIDMap := make(map[int]struct{})
-	for _, summary := range nn {
-		for _, tagId := range summary.TagIDs {
-			tagIDMap[tagId] = struct{}{}
-		}
-	}
+type NewsList []News
 
-	// заполняем карту уникальных tagId
-	var uniqueTagIDs []int
-	for tagID := range tagIDMap {
-		uniqueTagIDs = append(uniqueTagIDs, tagID)
+// IDs returns ids of all news in the list.
+func (nn NewsList) IDs() []int {
+	ids := make([]int, 0, len(nn))
+	for _, news := range nn {
+		ids = append(ids, news.ID)
 	}
-	return uniqueTagIDs
+	return ids
 }
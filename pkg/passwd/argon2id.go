@@ -0,0 +1,120 @@
+package passwd
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// Argon2idParams are argon2id parameters, zero values are replaced with OWASP recommended ones.
+type Argon2idParams struct {
+	Memory      uint32 // memory in KiB, default 19456
+	Iterations  uint32 // default 2
+	Parallelism uint8  // default 1
+	SaltLength  uint32 // default 16
+	KeyLength   uint32 // default 32
+}
+
+func (p Argon2idParams) withDefaults() Argon2idParams {
+	if p.Memory == 0 {
+		p.Memory = 19 * 1024
+	}
+	if p.Iterations == 0 {
+		p.Iterations = 2
+	}
+	if p.Parallelism == 0 {
+		p.Parallelism = 1
+	}
+	if p.SaltLength == 0 {
+		p.SaltLength = 16
+	}
+	if p.KeyLength == 0 {
+		p.KeyLength = 32
+	}
+
+	return p
+}
+
+// Argon2id is an argon2id Hasher. Encoded hash has PHC string format:
+// $argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>
+type Argon2id struct {
+	params Argon2idParams
+}
+
+func NewArgon2id(params Argon2idParams) *Argon2id {
+	return &Argon2id{params: params.withDefaults()}
+}
+
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, a.params.KeyLength)
+
+	return a.encode(a.params, salt, key), nil
+}
+
+func (a *Argon2id) Verify(password, encoded string) bool {
+	p, salt, key, err := a.decode(encoded)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+func (a *Argon2id) NeedsRehash(encoded string) bool {
+	p, _, _, err := a.decode(encoded)
+	return err != nil || p != a.params
+}
+
+func (a *Argon2id) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, argon2idPrefix)
+}
+
+func (a *Argon2id) encode(p Argon2idParams, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func (a *Argon2id) decode(encoded string) (p Argon2idParams, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return p, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return
+	} else if version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return
+	}
+
+	p.SaltLength, p.KeyLength = uint32(len(salt)), uint32(len(key))
+
+	return p, salt, key, nil
+}
@@ -0,0 +1,44 @@
+package passwd
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minBcryptCost = bcrypt.MinCost
+	maxBcryptCost = bcrypt.MaxCost
+)
+
+// Bcrypt is a bcrypt Hasher. Encoded hash has standard "$2a$cost$..." format.
+type Bcrypt struct {
+	cost int
+}
+
+// NewBcrypt returns bcrypt Hasher, bcrypt.DefaultCost is used for zero cost.
+func NewBcrypt(cost int) *Bcrypt {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &Bcrypt{cost: cost}
+}
+
+func (b *Bcrypt) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	return string(bytes), err
+}
+
+func (b *Bcrypt) Verify(password, encoded string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+}
+
+func (b *Bcrypt) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != b.cost
+}
+
+func (b *Bcrypt) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
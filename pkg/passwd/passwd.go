@@ -0,0 +1,98 @@
+package passwd
+
+import (
+	"fmt"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+type Config struct {
+	Algorithm  string // bcrypt (default) or argon2id
+	BcryptCost int    // default 10
+	Argon2id   Argon2idParams
+}
+
+// Validate checks that algorithm and its parameters are supported.
+func (c Config) Validate() error {
+	switch c.Algorithm {
+	case "", AlgorithmBcrypt:
+		if c.BcryptCost != 0 && (c.BcryptCost < minBcryptCost || c.BcryptCost > maxBcryptCost) {
+			return fmt.Errorf("passwd: bcrypt cost must be in range %d..%d", minBcryptCost, maxBcryptCost)
+		}
+	case AlgorithmArgon2id:
+		if p := c.Argon2id.withDefaults(); p.Memory < 8*uint32(p.Parallelism) {
+			return fmt.Errorf("passwd: argon2id memory must be at least 8*parallelism KiB")
+		}
+	default:
+		return fmt.Errorf("passwd: unknown algorithm %q", c.Algorithm)
+	}
+
+	return nil
+}
+
+// Hasher hashes passwords and verifies them. Encoded hash contains algorithm and its parameters.
+type Hasher interface {
+	// Hash returns encoded hash of password.
+	Hash(password string) (string, error)
+	// Verify checks password against encoded hash.
+	Verify(password, encoded string) bool
+	// NeedsRehash reports that encoded hash was made with other algorithm or parameters.
+	NeedsRehash(encoded string) bool
+}
+
+// algorithm is a Hasher which can recognize its own encoded hashes.
+type algorithm interface {
+	Hasher
+	Match(encoded string) bool
+}
+
+// Passwd hashes new passwords with configured algorithm and verifies hashes of all supported algorithms.
+type Passwd struct {
+	current    algorithm
+	algorithms []algorithm
+}
+
+var _ Hasher = (*Passwd)(nil)
+
+// New returns Passwd for given config. Bcrypt is used for unknown algorithm, see Config.Validate.
+func New(cfg Config) *Passwd {
+	bc := NewBcrypt(cfg.BcryptCost)
+	a2 := NewArgon2id(cfg.Argon2id)
+
+	p := &Passwd{current: bc, algorithms: []algorithm{bc, a2}}
+	if cfg.Algorithm == AlgorithmArgon2id {
+		p.current = a2
+	}
+
+	return p
+}
+
+func (p *Passwd) Hash(password string) (string, error) {
+	return p.current.Hash(password)
+}
+
+func (p *Passwd) Verify(password, encoded string) bool {
+	if a := p.algorithm(encoded); a != nil {
+		return a.Verify(password, encoded)
+	}
+
+	return false
+}
+
+func (p *Passwd) NeedsRehash(encoded string) bool {
+	return !p.current.Match(encoded) || p.current.NeedsRehash(encoded)
+}
+
+// algorithm returns algorithm of encoded hash or nil.
+func (p *Passwd) algorithm(encoded string) algorithm {
+	for _, a := range p.algorithms {
+		if a.Match(encoded) {
+			return a
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package passwd
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// seedHash is bcrypt hash of "12345" with cost 14 from docs/init.sql.
+const seedHash = "$2y$14$4IpqlaJ2Rvfgs.wb8f6lPODVLb/Ygl6zw1ZCUKz5CuT6WB6CV44AG"
+
+func TestPasswd(t *testing.T) {
+	Convey("Test Passwd", t, func() {
+		Convey("Bcrypt", func() {
+			p := New(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 4})
+			h, err := p.Hash("secret")
+			So(err, ShouldBeNil)
+			So(h, ShouldStartWith, "$2a$04$")
+			So(p.Verify("secret", h), ShouldBeTrue)
+			So(p.Verify("other", h), ShouldBeFalse)
+			So(p.NeedsRehash(h), ShouldBeFalse)
+
+			Convey("Existing hash with other cost", func() {
+				So(p.Verify("12345", seedHash), ShouldBeTrue)
+				So(p.NeedsRehash(seedHash), ShouldBeTrue)
+			})
+		})
+
+		Convey("Argon2id", func() {
+			p := New(Config{Algorithm: AlgorithmArgon2id, Argon2id: Argon2idParams{Memory: 1024, Iterations: 1}})
+			h, err := p.Hash("secret")
+			So(err, ShouldBeNil)
+			So(h, ShouldStartWith, "$argon2id$v=19$m=1024,t=1,p=1$")
+			So(len(h), ShouldBeLessThanOrEqualTo, 255)
+			So(p.Verify("secret", h), ShouldBeTrue)
+			So(p.Verify("other", h), ShouldBeFalse)
+			So(p.NeedsRehash(h), ShouldBeFalse)
+
+			Convey("Bcrypt hash is verified and needs rehash", func() {
+				So(p.Verify("12345", seedHash), ShouldBeTrue)
+				So(p.NeedsRehash(seedHash), ShouldBeTrue)
+			})
+
+			Convey("Changed parameters need rehash", func() {
+				p2 := New(Config{Algorithm: AlgorithmArgon2id, Argon2id: Argon2idParams{Memory: 2048, Iterations: 1}})
+				So(p2.Verify("secret", h), ShouldBeTrue)
+				So(p2.NeedsRehash(h), ShouldBeTrue)
+			})
+
+			Convey("Broken hash", func() {
+				So(p.Verify("secret", strings.TrimSuffix(h, h[len(h)-4:])+"$$$$"), ShouldBeFalse)
+				So(p.Verify("secret", "plain"), ShouldBeFalse)
+			})
+		})
+
+		Convey("Config validation", func() {
+			So(Config{}.Validate(), ShouldBeNil)
+			So(Config{Algorithm: AlgorithmArgon2id}.Validate(), ShouldBeNil)
+			So(Config{Algorithm: "md5"}.Validate(), ShouldBeError)
+			So(Config{BcryptCost: 50}.Validate(), ShouldBeError)
+			So(Config{Algorithm: AlgorithmArgon2id, Argon2id: Argon2idParams{Memory: 8, Parallelism: 4}}.Validate(), ShouldBeError)
+		})
+	})
+}
+
+func TestPolicy_Check(t *testing.T) {
+	Convey("Test Policy", t, func() {
+		Convey("Default policy", func() {
+			var p Policy
+			So(p.Check("g00d-passw0rd", "admin"), ShouldBeEmpty)
+			So(p.Check("abc", "admin"), ShouldResemble, []Violation{{Code: ViolationMinLength, Limit: 6}})
+			So(p.Check(strings.Repeat("x", 65), "admin"), ShouldResemble, []Violation{{Code: ViolationMaxLength, Limit: 64}})
+			So(p.Check(strings.Repeat("ж", 36), "admin"), ShouldBeEmpty)
+			So(p.Check(strings.Repeat("ж", 37), "admin"), ShouldResemble, []Violation{{Code: ViolationMaxBytes, Limit: 72}})
+			So(p.Check("QWERTY", "admin"), ShouldResemble, []Violation{{Code: ViolationBanned}})
+			So(p.Check("editor", "Editor"), ShouldResemble, []Violation{{Code: ViolationBanned}})
+		})
+
+		Convey("Custom policy", func() {
+			p := Policy{MinLength: 10, Banned: []string{"newsportal2024"}}
+			So(p.Check("short", ""), ShouldResemble, []Violation{{Code: ViolationMinLength, Limit: 10}})
+			So(p.Check("NewsPortal2024", ""), ShouldResemble, []Violation{{Code: ViolationBanned}})
+			So(p.Check("123456", ""), ShouldResemble, []Violation{{Code: ViolationMinLength, Limit: 10}, {Code: ViolationBanned}})
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package passwd
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ViolationMinLength = "min"
+	ViolationMaxLength = "max"
+	ViolationMaxBytes  = "maxBytes"
+	ViolationBanned    = "banned"
+)
+
+const (
+	defaultMinLength = 6
+	defaultMaxLength = 64
+
+	// maxBytes is a bcrypt limit, bcrypt rejects longer passwords. It is checked for any algorithm,
+	// because hashes are remade with bcrypt after switching algorithm back.
+	maxBytes = 72
+)
+
+// defaultBanned contains the most common passwords which are always rejected.
+var defaultBanned = []string{
+	"123456", "1234567", "12345678", "123456789", "1234567890", "111111", "123123", "654321", "000000",
+	"qwerty", "qwerty123", "qwertyuiop", "password", "password1", "admin", "admin123", "letmein", "iloveyou",
+	"йцукен", "пароль",
+}
+
+// Policy describes requirements for new passwords.
+type Policy struct {
+	MinLength int      // default 6
+	MaxLength int      // default 64
+	Banned    []string // additional banned passwords, case-insensitive
+}
+
+// Violation is a failed Policy requirement.
+type Violation struct {
+	Code  string // ViolationMinLength, ViolationMaxLength, ViolationMaxBytes or ViolationBanned
+	Limit int    // length limit for length violations, in bytes for ViolationMaxBytes
+}
+
+// Check returns violations of policy for password. Password equal to login is banned as well.
+// Length is counted in characters, password must not be longer than 72 bytes in UTF-8 as well.
+func (p Policy) Check(password, login string) []Violation {
+	minLength, maxLength := p.MinLength, p.MaxLength
+	if minLength == 0 {
+		minLength = defaultMinLength
+	}
+	if maxLength == 0 {
+		maxLength = defaultMaxLength
+	}
+
+	var vv []Violation
+	if l := utf8.RuneCountInString(password); l < minLength {
+		vv = append(vv, Violation{Code: ViolationMinLength, Limit: minLength})
+	} else if l > maxLength {
+		vv = append(vv, Violation{Code: ViolationMaxLength, Limit: maxLength})
+	} else if len(password) > maxBytes {
+		vv = append(vv, Violation{Code: ViolationMaxBytes, Limit: maxBytes})
+	}
+
+	if p.isBanned(password, login) {
+		vv = append(vv, Violation{Code: ViolationBanned})
+	}
+
+	return vv
+}
+
+func (p Policy) isBanned(password, login string) bool {
+	if login != "" && strings.EqualFold(password, login) {
+		return true
+	}
+
+	for _, list := range [][]string{defaultBanned, p.Banned} {
+		for _, banned := range list {
+			if strings.EqualFold(password, banned) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
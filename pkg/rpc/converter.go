@@ -45,13 +45,28 @@ func newCategory(in *newsportal.Category) *Category {
 	}
 
 	return &Category{
-		ID:          in.ID,
-		Title:       in.Title,
-		OrderNumber: in.OrderNumber,
-		Alias:       in.Alias,
+		ID:               in.ID,
+		Title:            in.Title,
+		ParentCategoryID: in.ParentCategoryID,
+		OrderNumber:      in.OrderNumber,
+		Alias:            in.Alias,
 	}
 }
 
+func newCategoryNodes(in []newsportal.CategoryNode) []CategoryNode {
+	out := make([]CategoryNode, 0, len(in))
+	for i := range in {
+		out = append(out, CategoryNode{
+			ID:          in[i].ID,
+			Title:       in[i].Title,
+			OrderNumber: in[i].OrderNumber,
+			Alias:       in[i].Alias,
+			Children:    newCategoryNodes(in[i].Children),
+		})
+	}
+	return out
+}
+
 func newCategories(in []newsportal.Category) (out []Category) {
 	for i := range in {
 		out = append(out, *newCategory(&in[i]))
@@ -76,3 +91,24 @@ func newTags(in []newsportal.Tag) (out []Tag) {
 	}
 	return
 }
+
+func newAuthor(in *newsportal.Author) *Author {
+	if in == nil {
+		return nil
+	}
+
+	return &Author{
+		ID:         in.ID,
+		Name:       in.Name,
+		Alias:      in.Alias,
+		Bio:        in.Bio,
+		AvatarHash: in.AvatarHash,
+	}
+}
+
+func newAuthors(in []newsportal.Author) (out []Author) {
+	for i := range in {
+		out = append(out, *newAuthor(&in[i]))
+	}
+	return
+}
@@ -23,13 +23,30 @@ type News struct {
 }
 
 type Category struct {
-	ID          int    `json:"categoryId"`
-	Title       string `json:"title"`
-	OrderNumber *int   `json:"orderNumber"`
-	Alias       string `json:"alias"`
+	ID               int    `json:"categoryId"`
+	Title            string `json:"title"`
+	ParentCategoryID *int   `json:"parentCategoryId"`
+	OrderNumber      *int   `json:"orderNumber"`
+	Alias            string `json:"alias"`
+}
+
+type CategoryNode struct {
+	ID          int            `json:"categoryId"`
+	Title       string         `json:"title"`
+	OrderNumber *int           `json:"orderNumber"`
+	Alias       string         `json:"alias"`
+	Children    []CategoryNode `json:"children"`
 }
 
 type Tag struct {
 	ID    int    `json:"tagId"`
 	Title string `json:"title"`
 }
+
+type Author struct {
+	ID         int     `json:"authorId"`
+	Name       string  `json:"name"`
+	Alias      string  `json:"alias"`
+	Bio        *string `json:"bio"`
+	AvatarHash *string `json:"avatarHash"`
+}
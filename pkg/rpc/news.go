@@ -39,6 +39,16 @@ func (rs NewsService) Categories(ctx context.Context) ([]Category, error) {
 	return newCategories(categories), err
 }
 
+// CategoryTree получение дерева категорий
+func (rs NewsService) CategoryTree(ctx context.Context) ([]CategoryNode, error) {
+	nodes, err := rs.m.CategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCategoryNodes(nodes), nil
+}
+
 // Tags получение всех тегов
 func (rs NewsService) Tags(ctx context.Context) ([]Tag, error) {
 	tags, err := rs.m.Tags(ctx)
@@ -49,9 +59,35 @@ func (rs NewsService) Tags(ctx context.Context) ([]Tag, error) {
 	return newTags(tags), err
 }
 
-// NewsWithFilters получение новости с фильтрами
-func (rs NewsService) NewsWithFilters(ctx context.Context, categoryID, tagID, page, pageSize *int) ([]NewsSummary, error) {
-	newsResponse, err := rs.m.News(ctx, categoryID, tagID, page, pageSize)
+// NewsWithFilters получение новости с фильтрами, withSubcategories включает новости подкатегорий
+func (rs NewsService) NewsWithFilters(ctx context.Context, categoryID, tagID, page, pageSize *int, withSubcategories *bool) ([]NewsSummary, error) {
+	newsResponse, err := rs.m.News(ctx, categoryID, tagID, withSubcategories != nil && *withSubcategories, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var newNewsList []NewsSummary
+	for _, summary := range newsResponse {
+		newNews := newNewsSummary(&summary)
+		newNewsList = append(newNewsList, *newNews)
+	}
+
+	return newNewsList, nil
+}
+
+// Authors получение всех авторов
+func (rs NewsService) Authors(ctx context.Context) ([]Author, error) {
+	authors, err := rs.m.Authors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuthors(authors), err
+}
+
+// NewsByAuthor получение новостей автора, включая новости в соавторстве, для отключенного автора новостей нет
+func (rs NewsService) NewsByAuthor(ctx context.Context, authorID int, page, pageSize *int) ([]NewsSummary, error) {
+	newsResponse, err := rs.m.NewsByAuthor(ctx, authorID, page, pageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -65,9 +101,9 @@ func (rs NewsService) NewsWithFilters(ctx context.Context, categoryID, tagID, pa
 	return newNewsList, nil
 }
 
-// NewsCountWithFilters получение количества новостей с фильтрами
-func (rs NewsService) NewsCountWithFilters(ctx context.Context, categoryID, tagID *int) (*int, error) {
-	count, err := rs.m.NewsCount(ctx, categoryID, tagID)
+// NewsCountWithFilters получение количества новостей с фильтрами, withSubcategories включает новости подкатегорий
+func (rs NewsService) NewsCountWithFilters(ctx context.Context, categoryID, tagID *int, withSubcategories *bool) (*int, error) {
+	count, err := rs.m.NewsCount(ctx, categoryID, tagID, withSubcategories != nil && *withSubcategories)
 
 	return count, err
 }
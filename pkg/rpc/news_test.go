@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/go-pg/pg/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/newsportal"
@@ -145,7 +147,7 @@ func TestNewsWithFilters(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ss.NewsWithFilters(context.Background(), tt.args.categoryID, tt.args.tagID, tt.args.page, tt.args.pageSize)
+			got, err := ss.NewsWithFilters(context.Background(), tt.args.categoryID, tt.args.tagID, tt.args.page, tt.args.pageSize, nil)
 			if !tt.wantErr(t, err, fmt.Sprintf("NewsWithFilters(%v, %v, %v, %v)", tt.args.categoryID, tt.args.tagID, tt.args.page, tt.args.pageSize)) {
 				return
 			}
@@ -154,3 +156,70 @@ func TestNewsWithFilters(t *testing.T) {
 		})
 	}
 }
+
+// addAuthorNews добавляет опубликованную новость в новой категории с включенным и отключенным соавторами
+func addAuthorNews(t *testing.T) (news *db.News, enabled, disabled *db.Author) {
+	ctx := context.Background()
+	s := strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	category, err := nr.AddCategory(ctx, &db.Category{Title: "Категория " + s, Alias: "category-" + s, StatusID: db.StatusEnabled})
+	require.NoError(t, err)
+	enabled, err = nr.AddAuthor(ctx, &db.Author{Name: "Автор " + s, Alias: "author-" + s, StatusID: db.StatusEnabled})
+	require.NoError(t, err)
+	disabled, err = nr.AddAuthor(ctx, &db.Author{Name: "Соавтор " + s, Alias: "coauthor-" + s, StatusID: db.StatusDisabled})
+	require.NoError(t, err)
+	news, err = nr.AddNews(ctx, &db.News{Title: "Новость " + s, CategoryID: category.ID, Foreword: "Преамбула", Author: enabled.Name, State: "published", PublishedAt: time.Now(), StatusID: db.StatusEnabled})
+	require.NoError(t, err)
+	require.NoError(t, nr.SetNewsAuthors(ctx, news.ID, []int{enabled.ID, disabled.ID}))
+
+	t.Cleanup(func() {
+		_, _ = nr.DeleteNews(ctx, news.ID)
+		_, _ = nr.PurgeNews(ctx, news.ID)
+		for _, id := range []int{enabled.ID, disabled.ID} {
+			_, _ = nr.DeleteAuthor(ctx, id)
+			_, _ = nr.PurgeAuthor(ctx, id)
+		}
+		_, _ = nr.DeleteCategory(ctx, category.ID)
+		_, _ = nr.PurgeCategory(ctx, category.ID)
+	})
+
+	return news, enabled, disabled
+}
+
+func TestAuthors(t *testing.T) {
+	_, enabled, disabled := addAuthorNews(t)
+
+	authors, err := ss.Authors(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, authors, Author{ID: enabled.ID, Name: enabled.Name, Alias: enabled.Alias})
+	for _, a := range authors {
+		assert.NotEqual(t, disabled.ID, a.ID)
+	}
+}
+
+func TestNewsByAuthor(t *testing.T) {
+	news, enabled, disabled := addAuthorNews(t)
+
+	tests := []struct {
+		name     string
+		authorID int
+		want     []int
+	}{
+		{name: "enabled author", authorID: enabled.ID, want: []int{news.ID}},
+		{name: "disabled co-author", authorID: disabled.ID, want: nil},
+		{name: "invalid author", authorID: -1, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ss.NewsByAuthor(context.Background(), tt.authorID, nil, nil)
+			assert.NoError(t, err)
+
+			var ids []int
+			for _, n := range got {
+				ids = append(ids, n.ID)
+			}
+			assert.Equalf(t, tt.want, ids, "NewsByAuthor(%v)", tt.authorID)
+		})
+	}
+}
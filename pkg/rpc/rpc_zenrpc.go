@@ -11,13 +11,16 @@ import (
 )
 
 var RPC = struct {
-	NewsService struct{ NewsByID, Categories, Tags, NewsWithFilters, NewsCountWithFilters string }
+	NewsService struct{ NewsByID, Categories, CategoryTree, Tags, NewsWithFilters, Authors, NewsByAuthor, NewsCountWithFilters string }
 }{
-	NewsService: struct{ NewsByID, Categories, Tags, NewsWithFilters, NewsCountWithFilters string }{
+	NewsService: struct{ NewsByID, Categories, CategoryTree, Tags, NewsWithFilters, Authors, NewsByAuthor, NewsCountWithFilters string }{
 		NewsByID:             "newsbyid",
 		Categories:           "categories",
+		CategoryTree:         "categorytree",
 		Tags:                 "tags",
 		NewsWithFilters:      "newswithfilters",
+		Authors:              "authors",
+		NewsByAuthor:         "newsbyauthor",
 		NewsCountWithFilters: "newscountwithfilters",
 	},
 }
@@ -88,6 +91,11 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Name: "title",
 									Type: smd.String,
 								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
 								{
 									Name:     "orderNumber",
 									Optional: true,
@@ -126,6 +134,46 @@ func (NewsService) SMD() smd.ServiceInfo {
 					},
 					Definitions: map[string]smd.Definition{
 						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+			},
+			"CategoryTree": {
+				Description: `CategoryTree получение дерева категорий`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Type:     smd.Array,
+					TypeName: "[]CategoryNode",
+					Items: map[string]string{
+						"$ref": "#/definitions/CategoryNode",
+					},
+					Definitions: map[string]smd.Definition{
+						"CategoryNode": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -145,6 +193,13 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Name: "alias",
 									Type: smd.String,
 								},
+								{
+									Name: "children",
+									Type: smd.Array,
+									Items: map[string]string{
+										"$ref": "#/definitions/CategoryNode",
+									},
+								},
 							},
 						},
 					},
@@ -177,7 +232,7 @@ func (NewsService) SMD() smd.ServiceInfo {
 				},
 			},
 			"NewsWithFilters": {
-				Description: `NewsWithFilters получение новости с фильтрами`,
+				Description: `NewsWithFilters получение новости с фильтрами, withSubcategories включает новости подкатегорий`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:     "categoryID",
@@ -199,6 +254,156 @@ func (NewsService) SMD() smd.ServiceInfo {
 						Optional: true,
 						Type:     smd.Integer,
 					},
+					{
+						Name:     "withSubcategories",
+						Optional: true,
+						Type:     smd.Boolean,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Type:     smd.Array,
+					TypeName: "[]NewsSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "newsId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "categoryId",
+									Ref:  "#/definitions/Category",
+									Type: smd.Object,
+								},
+								{
+									Name: "foreword",
+									Type: smd.String,
+								},
+								{
+									Name: "tags",
+									Type: smd.Array,
+									Items: map[string]string{
+										"$ref": "#/definitions/Tag",
+									},
+								},
+								{
+									Name: "author",
+									Type: smd.String,
+								},
+								{
+									Name: "publishedAt",
+									Type: smd.String,
+								},
+							},
+						},
+						"Category": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+							},
+						},
+						"Tag": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "tagId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+			},
+			"Authors": {
+				Description: `Authors получение всех авторов`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Type:     smd.Array,
+					TypeName: "[]Author",
+					Items: map[string]string{
+						"$ref": "#/definitions/Author",
+					},
+					Definitions: map[string]smd.Definition{
+						"Author": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "authorId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "name",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "bio",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "avatarHash",
+									Optional: true,
+									Type:     smd.String,
+								},
+							},
+						},
+					},
+				},
+			},
+			"NewsByAuthor": {
+				Description: `NewsByAuthor получение новостей автора, включая новости в соавторстве, для отключенного автора новостей нет`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name: "authorID",
+						Type: smd.Integer,
+					},
+					{
+						Name:     "page",
+						Optional: true,
+						Type:     smd.Integer,
+					},
+					{
+						Name:     "pageSize",
+						Optional: true,
+						Type:     smd.Integer,
+					},
 				},
 				Returns: smd.JSONSchema{
 					Type:     smd.Array,
@@ -255,6 +460,11 @@ func (NewsService) SMD() smd.ServiceInfo {
 									Name: "title",
 									Type: smd.String,
 								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
 								{
 									Name:     "orderNumber",
 									Optional: true,
@@ -283,7 +493,7 @@ func (NewsService) SMD() smd.ServiceInfo {
 				},
 			},
 			"NewsCountWithFilters": {
-				Description: `NewsCountWithFilters получение количества новостей с фильтрами`,
+				Description: `NewsCountWithFilters получение количества новостей с фильтрами, withSubcategories включает новости подкатегорий`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:     "categoryID",
@@ -295,6 +505,11 @@ func (NewsService) SMD() smd.ServiceInfo {
 						Optional: true,
 						Type:     smd.Integer,
 					},
+					{
+						Name:     "withSubcategories",
+						Optional: true,
+						Type:     smd.Boolean,
+					},
 				},
 				Returns: smd.JSONSchema{
 					Optional: true,
@@ -333,19 +548,47 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 	case RPC.NewsService.Categories:
 		resp.Set(s.Categories(ctx))
 
+	case RPC.NewsService.CategoryTree:
+		resp.Set(s.CategoryTree(ctx))
+
 	case RPC.NewsService.Tags:
 		resp.Set(s.Tags(ctx))
 
 	case RPC.NewsService.NewsWithFilters:
 		var args = struct {
-			CategoryID *int `json:"categoryID"`
-			TagID      *int `json:"tagID"`
-			Page       *int `json:"page"`
-			PageSize   *int `json:"pageSize"`
+			CategoryID        *int  `json:"categoryID"`
+			TagID             *int  `json:"tagID"`
+			Page              *int  `json:"page"`
+			PageSize          *int  `json:"pageSize"`
+			WithSubcategories *bool `json:"withSubcategories"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"categoryID", "tagID", "page", "pageSize", "withSubcategories"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.NewsWithFilters(ctx, args.CategoryID, args.TagID, args.Page, args.PageSize, args.WithSubcategories))
+
+	case RPC.NewsService.Authors:
+		resp.Set(s.Authors(ctx))
+
+	case RPC.NewsService.NewsByAuthor:
+		var args = struct {
+			AuthorID int  `json:"authorID"`
+			Page     *int `json:"page"`
+			PageSize *int `json:"pageSize"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"categoryID", "tagID", "page", "pageSize"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"authorID", "page", "pageSize"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -356,16 +599,17 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.NewsWithFilters(ctx, args.CategoryID, args.TagID, args.Page, args.PageSize))
+		resp.Set(s.NewsByAuthor(ctx, args.AuthorID, args.Page, args.PageSize))
 
 	case RPC.NewsService.NewsCountWithFilters:
 		var args = struct {
-			CategoryID *int `json:"categoryID"`
-			TagID      *int `json:"tagID"`
+			CategoryID        *int  `json:"categoryID"`
+			TagID             *int  `json:"tagID"`
+			WithSubcategories *bool `json:"withSubcategories"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"categoryID", "tagID"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"categoryID", "tagID", "withSubcategories"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -376,7 +620,7 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.NewsCountWithFilters(ctx, args.CategoryID, args.TagID))
+		resp.Set(s.NewsCountWithFilters(ctx, args.CategoryID, args.TagID, args.WithSubcategories))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -1,12 +1,15 @@
 package rpc
 
 import (
+	"log/slog"
 	"net/http"
 
 	"apisrv/pkg/newsportal"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/timeout"
+	"apisrv/pkg/tracing"
 
 	zm "github.com/vmkteam/zenrpc-middleware"
 	"github.com/vmkteam/zenrpc/v2"
@@ -30,28 +33,29 @@ var allowDebugFn = func() zm.AllowDebugFunc {
 //go:generate zenrpc
 
 // New returns new zenrpc Server.
-func New(dbo db.DB, logger embedlog.Logger, isDevel bool, m *newsportal.Manager) zenrpc.Server {
+func New(dbo db.DB, logger embedlog.Logger, isDevel bool, m *newsportal.Manager, timeouts timeout.Config) zenrpc.Server {
 	rpc := zenrpc.NewServer(zenrpc.Options{
 		ExposeSMD: true,
 		AllowCORS: true,
 	})
 
 	rpc.Use(
+		embedlog.RPCMiddleware(),
 		zm.WithDevel(isDevel),
 		zm.WithHeaders(),
 		zm.WithSentry(zm.DefaultServerName),
-		zm.WithNoCancelContext(),
+		timeout.Middleware(timeouts, zm.DefaultServerName),
 		zm.WithMetrics(zm.DefaultServerName),
+		tracing.RPCMiddleware(),
 		zm.WithTiming(isDevel, allowDebugFn()),
 		zm.WithSQLLogger(dbo.DB, isDevel, allowDebugFn(), allowDebugFn()),
 	)
 
-	if errlog, stdlog := logger.Loggers(); errlog != nil && stdlog != nil {
-		rpc.Use(
-			zm.WithAPILogger(stdlog.Printf, zm.DefaultServerName),
-			zm.WithErrorLogger(errlog.Printf, zm.DefaultServerName),
-		)
-	}
+	// requests are logged with debug level, so they are written only in verbose mode
+	rpc.Use(
+		zm.WithAPILogger(logger.PrintfFunc(slog.LevelDebug), zm.DefaultServerName),
+		zm.WithErrorLogger(logger.PrintfFunc(slog.LevelError), zm.DefaultServerName),
+	)
 	// services
 	rpc.RegisterAll(map[string]zenrpc.Invoker{
 		NsRPC: NewNewsService(m),
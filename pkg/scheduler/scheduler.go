@@ -0,0 +1,274 @@
+// Package scheduler publishes and unpublishes news at the time set by editors.
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/workflow"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	lockName         = "newsScheduler"
+	defaultInterval  = time.Minute
+	defaultBatchSize = 100
+)
+
+type Config struct {
+	Interval  time.Duration // check interval, default is one minute
+	BatchSize int           // max news processed by each action per run, default is 100
+}
+
+func (c Config) interval() time.Duration {
+	if c.Interval <= 0 {
+		return defaultInterval
+	}
+	return c.Interval
+}
+
+func (c Config) batchSize() int {
+	if c.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
+
+// Action is a scheduled change of News.
+type Action string
+
+const (
+	ActionPublish   Action = "publish"
+	ActionUnpublish Action = "unpublish"
+)
+
+// Event is a change of News made by scheduler.
+type Event struct {
+	NewsID      int
+	Action      Action
+	From, To    workflow.State
+	ScheduledAt time.Time // time set by editor
+	ProcessedAt time.Time
+}
+
+// Planned is an upcoming scheduled action.
+type Planned struct {
+	News   db.News
+	Action Action
+	At     time.Time
+}
+
+// Scheduler moves scheduled News to published state after publishedAt and published News to archived state after unpublishAt.
+// It is safe to run scheduler on several replicas: runs are serialized by advisory lock and rows are locked with SKIP LOCKED.
+type Scheduler struct {
+	embedlog.Logger
+	dbo       db.DB
+	newsRepo  db.NewsRepo
+	cfg       Config
+	listeners []func(ctx context.Context, e Event)
+
+	actions *prometheus.CounterVec
+	errors  prometheus.Counter
+	delay   *prometheus.SummaryVec
+	lastRun prometheus.Gauge
+}
+
+func New(appName string, dbo db.DB, logger embedlog.Logger, cfg Config) *Scheduler {
+	return &Scheduler{
+		Logger:   logger,
+		dbo:      dbo,
+		newsRepo: db.NewNewsRepo(dbo),
+		cfg:      cfg,
+		actions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: appName,
+			Subsystem: "scheduler",
+			Name:      "actions_total",
+			Help:      "Processed scheduled actions by type.",
+		}, []string{"action"}),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: appName,
+			Subsystem: "scheduler",
+			Name:      "errors_total",
+			Help:      "Failed scheduler runs.",
+		}),
+		delay: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: appName,
+			Subsystem: "scheduler",
+			Name:      "delay_seconds",
+			Help:      "Delay between scheduled and actual time of action.",
+		}, []string{"action"}),
+		lastRun: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: appName,
+			Subsystem: "scheduler",
+			Name:      "last_run_timestamp_seconds",
+			Help:      "Time of the last successful scheduler run.",
+		}),
+	}
+}
+
+var _ prometheus.Collector = (*Scheduler)(nil)
+
+// Describe describes all scheduler metrics.
+func (s *Scheduler) Describe(ch chan<- *prometheus.Desc) {
+	s.actions.Describe(ch)
+	s.errors.Describe(ch)
+	s.delay.Describe(ch)
+	s.lastRun.Describe(ch)
+}
+
+// Collect collects all scheduler metrics.
+func (s *Scheduler) Collect(ch chan<- prometheus.Metric) {
+	s.actions.Collect(ch)
+	s.errors.Collect(ch)
+	s.delay.Collect(ch)
+	s.lastRun.Collect(ch)
+}
+
+// Interval returns scheduler check interval.
+func (s *Scheduler) Interval() time.Duration {
+	return s.cfg.interval()
+}
+
+// OnChange adds listener which is called for every News changed by scheduler after transaction commit.
+// It is not safe to call OnChange concurrently with Process.
+func (s *Scheduler) OnChange(fn func(ctx context.Context, e Event)) {
+	s.listeners = append(s.listeners, fn)
+}
+
+// Process publishes and unpublishes all News that are due and returns number of changed News.
+func (s *Scheduler) Process(ctx context.Context) (int, error) {
+	var events []Event
+	err := s.dbo.RunInLock(ctx, lockName, func(tx *pg.Tx) error {
+		events = events[:0]
+		repo := s.newsRepo.WithTransaction(tx)
+		now := time.Now()
+
+		// publish scheduled news
+		scheduled := string(workflow.StateScheduled)
+		list, err := repo.NewsForUpdate(ctx, &db.NewsSearch{State: &scheduled, PublishedAtTo: &now}, s.cfg.batchSize())
+		if err != nil {
+			return err
+		}
+		for i := range list {
+			e, err := s.apply(ctx, repo, &list[i], ActionPublish, list[i].PublishedAt, now)
+			if err != nil {
+				return err
+			}
+			events = append(events, e)
+		}
+
+		// unpublish expired news, including just published ones
+		published := string(workflow.StatePublished)
+		list, err = repo.NewsForUpdate(ctx, &db.NewsSearch{State: &published, UnpublishAtTo: &now}, s.cfg.batchSize())
+		if err != nil {
+			return err
+		}
+		for i := range list {
+			e, err := s.apply(ctx, repo, &list[i], ActionUnpublish, *list[i].UnpublishAt, now)
+			if err != nil {
+				return err
+			}
+			events = append(events, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.errors.Inc()
+		return 0, err
+	}
+
+	s.lastRun.SetToCurrentTime()
+	for _, e := range events {
+		s.actions.WithLabelValues(string(e.Action)).Inc()
+		s.delay.WithLabelValues(string(e.Action)).Observe(e.ProcessedAt.Sub(e.ScheduledAt).Seconds())
+		s.Info(ctx, "scheduler action", "action", e.Action, "newsId", e.NewsID, "from", e.From, "to", e.To)
+		for _, fn := range s.listeners {
+			fn(ctx, e)
+		}
+	}
+
+	return len(events), nil
+}
+
+// apply changes News state and status and stores transition.
+func (s *Scheduler) apply(ctx context.Context, repo db.NewsRepo, news *db.News, action Action, scheduledAt, now time.Time) (Event, error) {
+	e := Event{
+		NewsID:      news.ID,
+		Action:      action,
+		From:        workflow.State(news.State),
+		ScheduledAt: scheduledAt,
+		ProcessedAt: now,
+	}
+
+	switch action {
+	case ActionPublish:
+		e.To, news.StatusID = workflow.StatePublished, db.StatusEnabled
+	case ActionUnpublish:
+		e.To, news.StatusID = workflow.StateArchived, db.StatusDisabled
+	}
+	news.State = string(e.To)
+	news.Version++
+
+	if _, err := repo.UpdateNews(ctx, news, db.WithColumns(db.Columns.News.State, db.Columns.News.StatusID, db.Columns.News.Version)); err != nil {
+		return e, err
+	}
+
+	reason := "scheduled " + string(action)
+	_, err := repo.AddNewsTransition(ctx, &db.NewsTransition{
+		NewsID:    news.ID,
+		FromState: string(e.From),
+		ToState:   string(e.To),
+		Reason:    &reason,
+	})
+
+	return e, err
+}
+
+// Upcoming returns nearest scheduled actions ordered by time.
+func Upcoming(ctx context.Context, repo db.NewsRepo, limit int) ([]Planned, error) {
+	// scheduled news to publish
+	scheduled := string(workflow.StateScheduled)
+	toPublish, err := repo.NewsByFilters(ctx, &db.NewsSearch{State: &scheduled}, db.Pager{Page: 1, PageSize: limit},
+		db.WithSort(db.NewSortField(db.Columns.News.PublishedAt, false)))
+	if err != nil {
+		return nil, err
+	}
+
+	// scheduled and published news with unpublish time
+	search := &db.NewsSearch{States: []string{scheduled, string(workflow.StatePublished)}}
+	search.With("? IS NOT NULL", pg.Ident(db.Columns.News.UnpublishAt))
+	toUnpublish, err := repo.NewsByFilters(ctx, search, db.Pager{Page: 1, PageSize: limit},
+		db.WithSort(db.NewSortField(db.Columns.News.UnpublishAt, false)))
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]Planned, 0, len(toPublish)+len(toUnpublish))
+	for _, n := range toPublish {
+		r = append(r, Planned{News: n, Action: ActionPublish, At: n.PublishedAt})
+	}
+	for _, n := range toUnpublish {
+		r = append(r, Planned{News: n, Action: ActionUnpublish, At: *n.UnpublishAt})
+	}
+
+	return sortPlanned(r, limit), nil
+}
+
+// sortPlanned orders actions by time and returns first limit actions.
+func sortPlanned(list []Planned, limit int) []Planned {
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].At.Before(list[j].At)
+	})
+
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/workflow"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	assert.Equal(t, defaultInterval, Config{}.interval())
+	assert.Equal(t, defaultBatchSize, Config{}.batchSize())
+	assert.Equal(t, time.Second, Config{Interval: time.Second}.interval())
+	assert.Equal(t, 5, Config{BatchSize: 5}.batchSize())
+}
+
+func TestSortPlanned(t *testing.T) {
+	now := time.Now()
+	list := []Planned{
+		{News: db.News{ID: 1}, Action: ActionPublish, At: now.Add(time.Hour)},
+		{News: db.News{ID: 2}, Action: ActionPublish, At: now.Add(3 * time.Hour)},
+		{News: db.News{ID: 1}, Action: ActionUnpublish, At: now.Add(2 * time.Hour)},
+		{News: db.News{ID: 3}, Action: ActionUnpublish, At: now.Add(time.Minute)},
+	}
+
+	got := sortPlanned(list, 3)
+	assert.Len(t, got, 3)
+	assert.Equal(t, 3, got[0].News.ID)
+	assert.Equal(t, ActionPublish, got[1].Action)
+	assert.Equal(t, ActionUnpublish, got[2].Action)
+	assert.Equal(t, 1, got[2].News.ID)
+}
+
+func testDB(t *testing.T) db.DB {
+	dbConn := os.Getenv("DB_CONN")
+	if dbConn == "" {
+		dbConn = "postgresql://localhost:5432/apisrv?sslmode=disable"
+	}
+
+	opts, err := pg.ParseURL(dbConn)
+	require.NoError(t, err)
+
+	dbc := pg.Connect(opts)
+	t.Cleanup(func() { _ = dbc.Close() })
+
+	return db.New(dbc)
+}
+
+func addTestNews(t *testing.T, repo db.NewsRepo, categoryID int, state workflow.State, publishedAt time.Time, unpublishAt *time.Time) *db.News {
+	news, err := repo.AddNews(context.Background(), &db.News{
+		Title:       fmt.Sprintf("Scheduled %d", time.Now().UnixNano()),
+		CategoryID:  categoryID,
+		Author:      "Author",
+		PublishedAt: publishedAt,
+		UnpublishAt: unpublishAt,
+		StatusID:    db.StatusDisabled,
+		State:       string(state),
+	})
+	require.NoError(t, err)
+	return news
+}
+
+func TestDB_Process(t *testing.T) {
+	ctx, dbo := context.Background(), testDB(t)
+	repo := db.NewNewsRepo(dbo)
+	s := New("test", dbo, embedlog.Logger{}, Config{})
+
+	var events []Event
+	s.OnChange(func(_ context.Context, e Event) { events = append(events, e) })
+	eventsOf := func(id int) []Event {
+		var r []Event
+		for _, e := range events {
+			if e.NewsID == id {
+				r = append(r, e)
+			}
+		}
+		return r
+	}
+
+	suffix := time.Now().UnixNano()
+	category, err := repo.AddCategory(ctx, &db.Category{Title: fmt.Sprintf("Scheduler %d", suffix), Alias: fmt.Sprintf("scheduler-%d", suffix), StatusID: db.StatusEnabled})
+	require.NoError(t, err)
+
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	toPublish := addTestNews(t, repo, category.ID, workflow.StateScheduled, past, nil)
+	toUnpublish := addTestNews(t, repo, category.ID, workflow.StatePublished, past, &past)
+	upcoming := addTestNews(t, repo, category.ID, workflow.StateScheduled, future, nil)
+
+	t.Run("publish and unpublish due news", func(t *testing.T) {
+		_, err := s.Process(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []Action{ActionPublish}, actions(eventsOf(toPublish.ID)))
+		assert.Equal(t, []Action{ActionUnpublish}, actions(eventsOf(toUnpublish.ID)))
+		assert.Empty(t, eventsOf(upcoming.ID))
+
+		e := eventsOf(toPublish.ID)[0]
+		assert.Equal(t, workflow.StateScheduled, e.From)
+		assert.Equal(t, workflow.StatePublished, e.To)
+
+		published, err := repo.NewsByID(ctx, toPublish.ID)
+		require.NoError(t, err)
+		assert.Equal(t, string(workflow.StatePublished), published.State)
+		assert.Equal(t, db.StatusEnabled, published.StatusID)
+		assert.Equal(t, toPublish.Version+1, published.Version)
+
+		archived, err := repo.NewsByID(ctx, toUnpublish.ID)
+		require.NoError(t, err)
+		assert.Equal(t, string(workflow.StateArchived), archived.State)
+		assert.Equal(t, db.StatusDisabled, archived.StatusID)
+
+		count, err := repo.CountNewsTransitions(ctx, &db.NewsTransitionSearch{NewsID: &toPublish.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		// processed news are not changed again
+		events = nil
+		_, err = s.Process(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, eventsOf(toPublish.ID))
+		assert.Empty(t, eventsOf(toUnpublish.ID))
+	})
+
+	t.Run("news locked by other transaction is skipped", func(t *testing.T) {
+		locked := addTestNews(t, repo, category.ID, workflow.StateScheduled, past, nil)
+
+		tx, err := dbo.BeginContext(ctx)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, `SELECT 1 FROM ? WHERE ? = ? FOR UPDATE`, pg.Ident(db.Tables.News.Name), pg.Ident(db.Columns.News.ID), locked.ID)
+		require.NoError(t, err)
+
+		events = nil
+		_, err = s.Process(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, eventsOf(locked.ID))
+		require.NoError(t, tx.Rollback())
+
+		_, err = s.Process(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []Action{ActionPublish}, actions(eventsOf(locked.ID)))
+	})
+
+	t.Run("runs are serialized by lock", func(t *testing.T) {
+		acquired, release, done := make(chan struct{}), make(chan struct{}), make(chan error)
+		go func() {
+			done <- dbo.RunInLock(ctx, lockName, func(*pg.Tx) error {
+				close(acquired)
+				<-release
+				return nil
+			})
+		}()
+		<-acquired
+
+		due := addTestNews(t, repo, category.ID, workflow.StateScheduled, past, nil)
+		timeoutCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		events = nil
+		_, err := s.Process(timeoutCtx)
+		assert.Error(t, err)
+		assert.Empty(t, events)
+
+		close(release)
+		require.NoError(t, <-done)
+
+		_, err = s.Process(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []Action{ActionPublish}, actions(eventsOf(due.ID)))
+	})
+}
+
+func actions(events []Event) []Action {
+	r := make([]Action, 0, len(events))
+	for _, e := range events {
+		r = append(r, e.Action)
+	}
+	return r
+}
@@ -0,0 +1,77 @@
+// Package textdiff calculates word level difference between two texts.
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+// Chunk is a part of text with the same operation.
+type Chunk struct {
+	Op   Op
+	Text string
+}
+
+// Words returns chunks that transform text a to text b. Texts are compared by words and whitespaces.
+func Words(a, b string) []Chunk {
+	at, bt := tokenize(a), tokenize(b)
+
+	var chunks []Chunk
+	m := difflib.NewMatcherWithJunk(at, bt, false, nil)
+	for _, oc := range m.GetOpCodes() {
+		switch oc.Tag {
+		case 'e':
+			chunks = appendChunk(chunks, OpEqual, at[oc.I1:oc.I2])
+		case 'd':
+			chunks = appendChunk(chunks, OpDelete, at[oc.I1:oc.I2])
+		case 'i':
+			chunks = appendChunk(chunks, OpInsert, bt[oc.J1:oc.J2])
+		case 'r':
+			chunks = appendChunk(chunks, OpDelete, at[oc.I1:oc.I2])
+			chunks = appendChunk(chunks, OpInsert, bt[oc.J1:oc.J2])
+		}
+	}
+
+	return chunks
+}
+
+// appendChunk appends tokens as chunk or merges them with the last chunk with the same operation.
+func appendChunk(chunks []Chunk, op Op, tokens []string) []Chunk {
+	text := strings.Join(tokens, "")
+	if text == "" {
+		return chunks
+	} else if l := len(chunks); l > 0 && chunks[l-1].Op == op {
+		chunks[l-1].Text += text
+		return chunks
+	}
+
+	return append(chunks, Chunk{Op: op, Text: text})
+}
+
+// tokenize splits text into words and whitespace runs keeping all characters.
+func tokenize(s string) []string {
+	var (
+		tokens []string
+		start  int
+	)
+
+	runes := []rune(s)
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || unicode.IsSpace(runes[i]) != unicode.IsSpace(runes[i-1]) {
+			tokens = append(tokens, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,46 @@
+package textdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []Chunk
+	}{
+		{
+			name: "equal",
+			a:    "hello world",
+			b:    "hello world",
+			want: []Chunk{{Op: OpEqual, Text: "hello world"}},
+		},
+		{
+			name: "replace word",
+			a:    "hello old world",
+			b:    "hello new world",
+			want: []Chunk{{Op: OpEqual, Text: "hello "}, {Op: OpDelete, Text: "old"}, {Op: OpInsert, Text: "new"}, {Op: OpEqual, Text: " world"}},
+		},
+		{
+			name: "insert into empty",
+			a:    "",
+			b:    "новость",
+			want: []Chunk{{Op: OpInsert, Text: "новость"}},
+		},
+		{
+			name: "delete tail",
+			a:    "one two three",
+			b:    "one two",
+			want: []Chunk{{Op: OpEqual, Text: "one two"}, {Op: OpDelete, Text: " three"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Words(tt.a, tt.b))
+		})
+	}
+}
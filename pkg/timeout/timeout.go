@@ -0,0 +1,145 @@
+package timeout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// ErrTimeout is returned when rpc call exceeded its deadline.
+var ErrTimeout = zenrpc.NewStringError(http.StatusGatewayTimeout, "Request timeout")
+
+type Config struct {
+	Default  time.Duration // deadline of rpc calls, default 30s, negative value disables deadline
+	Methods  []string      // deadlines of namespaces or methods which override Default, e.g. news=1m or news.get=5s
+	Detached []string      // namespaces or methods which are not cancelled by deadline or client disconnect, e.g. news.bulkDelete
+}
+
+// Validate checks deadlines of methods.
+func (c Config) Validate() error {
+	_, err := c.parse()
+	return err
+}
+
+// rules holds deadlines by lowercased namespace or ns.method.
+type rules struct {
+	def      time.Duration
+	methods  map[string]time.Duration
+	detached map[string]struct{}
+}
+
+func (c Config) parse() (rules, error) {
+	r := rules{
+		def:      c.Default,
+		methods:  make(map[string]time.Duration, len(c.Methods)),
+		detached: make(map[string]struct{}, len(c.Detached)),
+	}
+	if r.def == 0 {
+		r.def = defaultTimeout
+	}
+
+	var errs []error
+	for _, s := range c.Methods {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			errs = append(errs, fmt.Errorf("invalid method timeout %q, expected method=duration", s))
+			continue
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("invalid timeout of %s %q, expected positive duration", name, value))
+			continue
+		}
+
+		r.methods[strings.ToLower(name)] = d
+	}
+
+	for _, name := range c.Detached {
+		r.detached[strings.ToLower(name)] = struct{}{}
+	}
+
+	return r, errors.Join(errs...)
+}
+
+// lookup returns deadline of method, zero deadline means that call has no deadline.
+// Settings of ns.method take precedence over namespace settings.
+func (r rules) lookup(ns, method string) (d time.Duration, detached bool) {
+	names := []string{strings.ToLower(ns + "." + method), strings.ToLower(ns)}
+	for _, name := range names {
+		if _, ok := r.detached[name]; ok {
+			return 0, true
+		}
+		if d, ok := r.methods[name]; ok {
+			return d, false
+		}
+	}
+
+	if r.def < 0 {
+		return 0, false
+	}
+
+	return r.def, false
+}
+
+// timeoutsCounter returns <serverName>_rpc_timeouts_total counter. It is registered once,
+// middlewares of servers with the same name share it.
+func timeoutsCounter(serverName string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: serverName,
+		Subsystem: "rpc",
+		Name:      "timeouts_total",
+		Help:      "RPC calls which exceeded deadline by method.",
+	}, []string{"method"})
+
+	var are prometheus.AlreadyRegisteredError
+	if err := prometheus.Register(c); errors.As(err, &are) {
+		return are.ExistingCollector.(*prometheus.CounterVec)
+	} else if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Middleware sets deadline of rpc call and cancels it on client disconnect.
+// Failed calls which exceeded deadline return ErrTimeout and are counted by <serverName>_rpc_timeouts_total metric,
+// successful results are returned as is. Detached methods run without deadline and are not cancelled on client disconnect.
+// Invalid settings are ignored.
+func Middleware(cfg Config, serverName string) zenrpc.MiddlewareFunc {
+	r, _ := cfg.parse()
+	timeouts := timeoutsCounter(serverName)
+
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			ns := zenrpc.NamespaceFromContext(ctx)
+			d, detached := r.lookup(ns, method)
+			if detached {
+				return h(context.WithoutCancel(ctx), method, params)
+			}
+
+			if d > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, d)
+				defer cancel()
+			}
+
+			resp := h(ctx, method, params)
+			if resp.Error != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				timeouts.WithLabelValues(ns + "." + method).Inc()
+				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrTimeout.Code, ErrTimeout.Message, nil)
+			}
+
+			return resp
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package timeout
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
+	"github.com/vmkteam/zenrpc/v2/smd"
+)
+
+func TestConfig(t *testing.T) {
+	Convey("Test timeout config", t, func() {
+		Convey("Method settings override namespace and default", func() {
+			r, err := Config{
+				Methods:  []string{"news=1m", "news.get=5s"},
+				Detached: []string{"news.bulkDelete"},
+			}.parse()
+			So(err, ShouldBeNil)
+
+			d, detached := r.lookup("news", "get")
+			So(d, ShouldEqual, 5*time.Second)
+			So(detached, ShouldBeFalse)
+
+			d, _ = r.lookup("news", "update")
+			So(d, ShouldEqual, time.Minute)
+
+			d, _ = r.lookup("tag", "get")
+			So(d, ShouldEqual, defaultTimeout)
+
+			_, detached = r.lookup("news", "bulkdelete")
+			So(detached, ShouldBeTrue)
+		})
+
+		Convey("Negative default disables deadline", func() {
+			r, err := Config{Default: -1}.parse()
+			So(err, ShouldBeNil)
+
+			d, _ := r.lookup("news", "get")
+			So(d, ShouldEqual, 0)
+		})
+
+		Convey("Invalid method timeouts are reported", func() {
+			err := Config{Methods: []string{"news", "news.get=0s", "tag=abc"}}.Validate()
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `"news"`)
+			So(err.Error(), ShouldContainSubstring, "news.get")
+			So(err.Error(), ShouldContainSubstring, "tag")
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	rpc := zenrpc.NewServer(zenrpc.Options{})
+	rpc.Use(Middleware(Config{Methods: []string{"news.slow=10ms", "news.late=10ms"}, Detached: []string{"news.detached"}}, "timeouttest"))
+	rpc.Register("news", waitingService{})
+
+	call := func(ctx context.Context, method string) *zenrpc.Response {
+		resp, err := rpc.Do(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"news.`+method+`"}`))
+		So(err, ShouldBeNil)
+
+		var r zenrpc.Response
+		So(json.Unmarshal(resp, &r), ShouldBeNil)
+		return &r
+	}
+
+	Convey("Test timeout middleware", t, func() {
+		Convey("Call with exceeded deadline returns timeout error", func() {
+			r := call(context.Background(), "slow")
+			So(r.Error, ShouldNotBeNil)
+			So(r.Error.Code, ShouldEqual, ErrTimeout.Code)
+
+			n, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "timeouttest_rpc_timeouts_total")
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+		})
+
+		Convey("Successful result of call which exceeded deadline is returned", func() {
+			before, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "timeouttest_rpc_timeouts_total")
+			So(err, ShouldBeNil)
+
+			r := call(context.Background(), "late")
+			So(r.Error, ShouldBeNil)
+			So(string(*r.Result), ShouldEqual, "true")
+
+			n, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "timeouttest_rpc_timeouts_total")
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, before)
+		})
+
+		Convey("Middleware could be created again with the same server name", func() {
+			So(func() { Middleware(Config{}, "timeouttest") }, ShouldNotPanic)
+		})
+
+		Convey("Call gets default deadline", func() {
+			r := call(context.Background(), "deadline")
+			So(r.Error, ShouldBeNil)
+			So(string(*r.Result), ShouldEqual, "true")
+		})
+
+		Convey("Detached call is not cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			r := call(ctx, "detached")
+			So(r.Error, ShouldBeNil)
+			So(string(*r.Result), ShouldEqual, "false")
+		})
+	})
+}
+
+// waitingService waits for cancellation of slow and late calls, late calls succeed anyway.
+// Other calls report whether their context has deadline.
+type waitingService struct{}
+
+func (waitingService) Invoke(ctx context.Context, method string, _ json.RawMessage) zenrpc.Response {
+	var resp zenrpc.Response
+	switch method {
+	case "slow":
+		<-ctx.Done()
+		return zenrpc.NewResponseError(nil, zenrpc.InternalError, ctx.Err().Error(), nil)
+	case "late":
+		<-ctx.Done()
+		resp.Set(true)
+	case "detached":
+		_, hasDeadline := ctx.Deadline()
+		resp.Set(hasDeadline || ctx.Err() != nil)
+	default:
+		_, hasDeadline := ctx.Deadline()
+		resp.Set(hasDeadline)
+	}
+
+	return resp
+}
+
+func (waitingService) SMD() smd.ServiceInfo { return smd.ServiceInfo{} }
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmkteam/zenrpc/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "apisrv/pkg/tracing"
+
+// EchoMiddleware starts server span for each request, trace context of caller is extracted from request headers.
+// Requests to skipped paths, e.g. health checks, are not traced.
+func EchoMiddleware(skipPaths ...string) echo.MiddlewareFunc {
+	tracer := otel.Tracer(instrumentationName)
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if _, ok := skip[req.URL.Path]; ok {
+				return next(c)
+			}
+
+			name, route := req.Method, c.Path()
+			if route != "" {
+				name += " " + route
+			}
+
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+					semconv.ClientAddress(c.RealIP()),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			// handle error here to get response status
+			err := next(c)
+			if err != nil {
+				span.RecordError(err)
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			return err
+		}
+	}
+}
+
+// RPCMiddleware starts span for each JSON-RPC method call, span is named as namespace.method.
+func RPCMiddleware() zenrpc.MiddlewareFunc {
+	tracer := otel.Tracer(instrumentationName)
+
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			ns, name := zenrpc.NamespaceFromContext(ctx), method
+			if ns != "" {
+				name = ns + "." + method
+			}
+
+			ctx, span := tracer.Start(ctx, name, trace.WithAttributes(
+				semconv.RPCSystemKey.String("jsonrpc"),
+				semconv.RPCService(ns),
+				semconv.RPCMethod(method),
+			))
+			defer span.End()
+
+			r := h(ctx, method, params)
+			if r.Error != nil {
+				span.SetAttributes(semconv.RPCJsonrpcErrorCode(r.Error.Code))
+				span.SetStatus(codes.Error, r.Error.Message)
+			}
+
+			return r
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Package tracing sets up OpenTelemetry tracer provider and provides middlewares for echo and zenrpc.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	Exporter    string  // none (default), stdout, file or otlp
+	File        string  // output file for file exporter, spans are appended as JSON
+	Endpoint    string  // host:port of OTLP/HTTP collector, default is localhost:4318 or OTEL_EXPORTER_OTLP_ENDPOINT
+	Insecure    bool    // use http instead of https for OTLP exporter
+	SampleRatio float64 // ratio of sampled new traces in range 0-1, default is 1; sampling decision of caller is respected
+}
+
+// Enabled returns true if spans are exported.
+func (c Config) Enabled() bool {
+	return c.Exporter != "" && c.Exporter != ExporterNone
+}
+
+func (c Config) sampleRatio() float64 {
+	if c.SampleRatio <= 0 {
+		return 1
+	}
+	return c.SampleRatio
+}
+
+// New sets global W3C trace context propagator and, if exporter is enabled, global tracer provider.
+// Returned func flushes pending spans and stops exporter, it should be called on shutdown.
+func New(ctx context.Context, cfg Config, serviceName, version string) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.sampleRatio()))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), closer.Close())
+	}, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// newExporter returns span exporter by config and closer of its output.
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exp, nopCloser{}, err
+	case ExporterFile:
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			return nil, nil, errors.Join(err, f.Close())
+		}
+		return exp, f, nil
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exp, err := otlptracehttp.New(ctx, opts...)
+		return exp, nopCloser{}, err
+	default:
+		return nil, nil, fmt.Errorf("unknown exporter %q", cfg.Exporter)
+	}
+}
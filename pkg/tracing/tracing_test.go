@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmkteam/zenrpc/v2"
+	"github.com/vmkteam/zenrpc/v2/smd"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware(t *testing.T) {
+	Convey("Test middlewares", t, func() {
+		_, err := New(context.Background(), Config{}, "test", "devel")
+		So(err, ShouldBeNil)
+
+		sr := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+		e := echo.New()
+		e.Use(EchoMiddleware("/healthz"))
+
+		Convey("Echo span continues trace of caller", func() {
+			var handlerSpan trace.SpanContext
+			e.GET("/news/:id", func(c echo.Context) error {
+				handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+				return c.NoContent(http.StatusInternalServerError)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/news/1", nil)
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := sr.Ended()
+			So(spans, ShouldHaveLength, 1)
+			So(spans[0].Name(), ShouldEqual, "GET /news/:id")
+			So(spans[0].SpanKind(), ShouldEqual, trace.SpanKindServer)
+			So(spans[0].Parent().SpanID().String(), ShouldEqual, "00f067aa0ba902b7")
+			So(spans[0].SpanContext().TraceID().String(), ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+			So(spans[0].Status().Code, ShouldEqual, codes.Error)
+			So(handlerSpan.SpanID(), ShouldEqual, spans[0].SpanContext().SpanID())
+		})
+
+		Convey("Skipped path is not traced", func() {
+			e.GET("/healthz", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			So(sr.Ended(), ShouldBeEmpty)
+		})
+
+		Convey("RPC span is named by namespace and method", func() {
+			rpc := zenrpc.NewServer(zenrpc.Options{})
+			rpc.Use(RPCMiddleware())
+			rpc.Register("news", failingService{})
+
+			_, err := rpc.Do(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"news.get"}`))
+			So(err, ShouldBeNil)
+
+			spans := sr.Ended()
+			So(spans, ShouldHaveLength, 1)
+			So(spans[0].Name(), ShouldEqual, "news.get")
+			So(spans[0].Status().Code, ShouldEqual, codes.Error)
+		})
+	})
+}
+
+// failingService responds with invalid params error to all calls.
+type failingService struct{}
+
+func (failingService) Invoke(_ context.Context, _ string, _ json.RawMessage) zenrpc.Response {
+	return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", nil)
+}
+
+func (failingService) SMD() smd.ServiceInfo { return smd.ServiceInfo{} }
+
+func TestNew(t *testing.T) {
+	Convey("Test New", t, func() {
+		defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+		Convey("File exporter writes spans on shutdown", func() {
+			path := filepath.Join(t.TempDir(), "spans.json")
+			shutdown, err := New(context.Background(), Config{Exporter: ExporterFile, File: path}, "test", "devel")
+			So(err, ShouldBeNil)
+
+			_, span := otel.Tracer("test").Start(context.Background(), "work")
+			span.End()
+			So(shutdown(context.Background()), ShouldBeNil)
+
+			b, err := os.ReadFile(path)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldContainSubstring, `"Name":"work"`)
+		})
+
+		Convey("Unknown exporter", func() {
+			_, err := New(context.Background(), Config{Exporter: "zipkin"}, "test", "devel")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,289 @@
+package vt
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	zm "github.com/vmkteam/zenrpc-middleware"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const (
+	methodAdd    = "add"
+	methodUpdate = "update"
+	methodDelete = "delete"
+)
+
+type AuditConfig struct {
+	Retention time.Duration // audit records lifetime, zero keeps records forever
+}
+
+// auditSnapshotFunc returns entity by id for storing its state in audit log.
+type auditSnapshotFunc func(ctx context.Context, id int) (interface{}, error)
+
+// newAuditSnapshot wraps GetByID method of service into auditSnapshotFunc.
+func newAuditSnapshot[T any](getByID func(ctx context.Context, id int) (*T, error)) auditSnapshotFunc {
+	return func(ctx context.Context, id int) (interface{}, error) {
+		return getByID(ctx, id)
+	}
+}
+
+// auditLogger writes audit records for VT mutations.
+type auditLogger struct {
+	embedlog.Logger
+	commonRepo db.CommonRepo
+	snapshots  map[string]auditSnapshotFunc // entity loaders by namespace
+}
+
+// middleware records all successful mutating calls.
+// For add, update and delete methods entity state before and after the call is stored.
+func (al auditLogger) middleware() zenrpc.MiddlewareFunc {
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			ns := zenrpc.NamespaceFromContext(ctx)
+			if !isAuditedMethod(ns, method) {
+				return h(ctx, method, params)
+			}
+
+			// entity id and snapshots are stored only for known entities
+			snapshot, isEntity := al.snapshots[ns]
+			hasSnapshot := isEntity && (method == methodAdd || method == methodUpdate || method == methodDelete)
+
+			// load entity before changes
+			var entityID *int
+			var before map[string]interface{}
+			if isEntity && method != methodAdd {
+				entityID = findEntityID(params)
+			}
+			if hasSnapshot && entityID != nil {
+				before = al.snapshot(ctx, snapshot, *entityID)
+			}
+
+			resp := h(ctx, method, params)
+			if resp.Error != nil {
+				return resp
+			}
+
+			// changes are committed, so audit log is written even if call was cancelled
+			ctx = context.WithoutCancel(ctx)
+
+			// load entity after changes
+			var after map[string]interface{}
+			if isEntity && method == methodAdd && resp.Result != nil {
+				entityID = findEntityID(*resp.Result)
+			}
+			if hasSnapshot && entityID != nil && method != methodDelete {
+				after = al.snapshot(ctx, snapshot, *entityID)
+			}
+
+			rec := &db.AuditLog{
+				Namespace: ns,
+				Method:    method,
+				EntityID:  entityID,
+				Before:    before,
+				After:     after,
+				RequestID: stringOrNil(zm.XRequestIDFromContext(ctx)),
+				IP:        stringOrNil(zm.IPFromContext(ctx)),
+			}
+
+			if isEntity {
+				rec.EntityType = &ns
+			}
+
+			if user := UserFromContext(ctx); user != nil {
+				rec.UserID = &user.ID
+			} else if token := APITokenFromContext(ctx); token != nil {
+				rec.APITokenID = &token.ID
+			}
+
+			if _, err := al.commonRepo.AddAuditLog(ctx, rec); err != nil {
+				al.Error(ctx, "add audit log", "err", err)
+			}
+
+			return resp
+		}
+	}
+}
+
+// snapshot returns entity state as json object or nil if entity is not found.
+func (al auditLogger) snapshot(ctx context.Context, fn auditSnapshotFunc, id int) map[string]interface{} {
+	entity, err := fn(ctx, id)
+	if err != nil {
+		if err != ErrNotFound {
+			al.Error(ctx, "audit snapshot", "id", id, "err", err)
+		}
+		return nil
+	}
+
+	b, err := json.Marshal(entity)
+	if err != nil {
+		al.Error(ctx, "audit snapshot", "id", id, "err", err)
+		return nil
+	}
+
+	var r map[string]interface{}
+	if err = json.Unmarshal(b, &r); err != nil {
+		return nil
+	}
+
+	return r
+}
+
+// isAuditedMethod checks that namespace method changes data.
+func isAuditedMethod(ns, method string) bool {
+	if _, ok := apiTokenReadMethods[method]; ok {
+		return false
+	}
+
+	switch ns {
+	case NSAudit:
+		return false
+	case NSAuth:
+		return method == RPC.AuthService.ChangePassword
+	case NSAPIToken:
+		return method != RPC.APITokenService.Scopes
+	case NSTrash:
+		return method != RPC.TrashService.EntityTypes
+	case NSVFS:
+		for _, prefix := range []string{"get", "count", "search", "url", "help"} {
+			if strings.HasPrefix(method, prefix) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// findEntityID returns "id" field from json params or result.
+// Params could be passed as object or as array, entity could be wrapped into object, e.g. {"news": {"id": 1}}.
+func findEntityID(raw json.RawMessage) *int {
+	var id int
+	if err := json.Unmarshal(raw, &id); err == nil && id > 0 {
+		return &id
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		if len(list) > 0 {
+			return findEntityID(list[0])
+		}
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+
+	if v, ok := obj["id"]; ok {
+		return findEntityID(v)
+	}
+
+	for _, v := range obj {
+		if strings.HasPrefix(strings.TrimSpace(string(v)), "{") {
+			if id := findEntityID(v); id != nil {
+				return id
+			}
+		}
+	}
+
+	return nil
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// CleanAuditLog removes audit records older than retention period.
+func CleanAuditLog(ctx context.Context, dbo db.DB, cfg AuditConfig) (int, error) {
+	if cfg.Retention <= 0 {
+		return 0, nil
+	}
+
+	return db.NewCommonRepo(dbo).DeleteAuditLogsBefore(ctx, time.Now().Add(-cfg.Retention))
+}
+
+type AuditService struct {
+	zenrpc.Service
+	embedlog.Logger
+	commonRepo db.CommonRepo
+}
+
+func NewAuditService(dbo db.DB, logger embedlog.Logger) *AuditService {
+	return &AuditService{
+		Logger:     logger,
+		commonRepo: db.NewCommonRepo(dbo),
+	}
+}
+
+func (s AuditService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.commonRepo.DefaultAuditLogSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.AuditLog.ID, db.Columns.AuditLog.CreatedAt, db.Columns.AuditLog.Namespace, db.Columns.AuditLog.Method, db.Columns.AuditLog.EntityID:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count AuditLogs according to conditions in search params
+//
+//zenrpc:search AuditLogSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s AuditService) Count(ctx context.Context, search *AuditLogSearch) (int, error) {
+	count, err := s.commonRepo.CountAuditLogs(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get а list of AuditLogs according to conditions in search params
+//
+//zenrpc:search AuditLogSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []AuditLogSummary
+//zenrpc:500 Internal Error
+func (s AuditService) Get(ctx context.Context, search *AuditLogSearch, viewOps *ViewOps) ([]AuditLogSummary, error) {
+	list, err := s.commonRepo.AuditLogsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.commonRepo.FullAuditLog())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	logs := make([]AuditLogSummary, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if log := NewAuditLogSummary(&list[i]); log != nil {
+			logs = append(logs, *log)
+		}
+	}
+	return logs, nil
+}
+
+// GetByID returns a AuditLog with entity snapshots by its ID.
+//
+//zenrpc:id int
+//zenrpc:return AuditLog
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s AuditService) GetByID(ctx context.Context, id int) (*AuditLog, error) {
+	db, err := s.commonRepo.AuditLogByID(ctx, id, s.commonRepo.FullAuditLog())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return NewAuditLog(db), nil
+}
@@ -0,0 +1,44 @@
+package vt
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFindEntityID(t *testing.T) {
+	Convey("Test entity id from params", t, func() {
+		cases := map[string]int{
+			`{"id": 5}`:                       5,
+			`[7]`:                             7,
+			`{"news": {"id": 3, "tags": []}}`: 3,
+			`[{"id": 4, "title": "tag"}]`:     4,
+			`{"apiToken": {"id": 9}, "token": "vt_1"}`: 9,
+		}
+		for params, id := range cases {
+			got := findEntityID(json.RawMessage(params))
+			So(got, ShouldNotBeNil)
+			So(*got, ShouldEqual, id)
+		}
+
+		So(findEntityID(json.RawMessage(`{"password": "secret"}`)), ShouldBeNil)
+		So(findEntityID(json.RawMessage(`[]`)), ShouldBeNil)
+		So(findEntityID(json.RawMessage(`{"news": {"id": 0}}`)), ShouldBeNil)
+	})
+}
+
+func TestIsAuditedMethod(t *testing.T) {
+	Convey("Test audited methods", t, func() {
+		So(isAuditedMethod(NSNews, RPC.NewsService.Update), ShouldBeTrue)
+		So(isAuditedMethod(NSNews, RPC.NewsService.Get), ShouldBeFalse)
+		So(isAuditedMethod(NSUser, RPC.UserService.Invite), ShouldBeTrue)
+		So(isAuditedMethod(NSAuth, RPC.AuthService.Login), ShouldBeFalse)
+		So(isAuditedMethod(NSAuth, RPC.AuthService.ChangePassword), ShouldBeTrue)
+		So(isAuditedMethod(NSAudit, RPC.AuditService.Count), ShouldBeFalse)
+		So(isAuditedMethod(NSVFS, "getfolder"), ShouldBeFalse)
+		So(isAuditedMethod(NSVFS, "deletefiles"), ShouldBeTrue)
+		So(isAuditedMethod(NSTrash, RPC.TrashService.Restore), ShouldBeTrue)
+		So(isAuditedMethod(NSTrash, RPC.TrashService.EntityTypes), ShouldBeFalse)
+	})
+}
@@ -3,11 +3,14 @@ package vt
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/workflow"
 
 	"github.com/vmkteam/zenrpc/v2"
 )
@@ -15,9 +18,12 @@ import (
 type userCtx string
 
 const (
-	userKey userCtx = "vt.user"
+	userKey     userCtx = "vt.user"
+	apiTokenKey userCtx = "vt.apiToken"
 )
 
+const activityUpdateInterval = time.Second * 90
+
 func authMiddleware(commonRepo *db.CommonRepo, logger embedlog.Logger) zenrpc.MiddlewareFunc {
 	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
 		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
@@ -28,27 +34,44 @@ func authMiddleware(commonRepo *db.CommonRepo, logger embedlog.Logger) zenrpc.Mi
 
 			ns := zenrpc.NamespaceFromContext(ctx)
 
-			// skip auth.Login method
-			if ns == NSAuth && method == RPC.AuthService.Login {
+			// skip public auth methods
+			if ns == NSAuth && isPublicAuthMethod(method) {
 				return h(ctx, method, params)
 			}
 
+			// authenticate service by api token
+			if token := requestAPIToken(req); token != "" {
+				at, err := commonRepo.EnabledAPITokenByHash(ctx, hashToken(token))
+				if err != nil || at == nil {
+					return newResponseError(ctx, ErrUnauthorized)
+				} else if !apiTokenAllows(at.Scopes, ns, method) {
+					return newResponseError(ctx, ErrForbidden)
+				}
+
+				ctx = embedlog.WithAttrs(ctx, slog.Int("apiTokenId", at.ID))
+				updateAPITokenUsage(ctx, commonRepo, at, logger)
+
+				return h(context.WithValue(ctx, apiTokenKey, at), method, params)
+			}
+
 			authHeader := req.Header.Get(AuthKey)
 			// return error if header is not set
 			if authHeader == "" {
-				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrUnauthorized.Code, ErrUnauthorized.Message, ErrUnauthorized.Data)
+				return newResponseError(ctx, ErrUnauthorized)
 			}
 
 			// return error  if user not found
 			dbu, err := commonRepo.EnabledUserByAuthKey(ctx, authHeader)
 			if err != nil || dbu == nil {
-				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrUnauthorized.Code, ErrUnauthorized.Message, ErrUnauthorized.Data)
+				return newResponseError(ctx, ErrUnauthorized)
 			}
 
+			ctx = embedlog.WithAttrs(ctx, slog.Int("userId", dbu.ID))
+
 			// updating last activity
-			if dbu.LastActivityAt == nil || time.Since(*dbu.LastActivityAt) > time.Second*90 {
+			if dbu.LastActivityAt == nil || time.Since(*dbu.LastActivityAt) > activityUpdateInterval {
 				if _, err := commonRepo.UpdateUserActivity(ctx, dbu); err != nil {
-					logger.Errorf("update user activity error=%s", err)
+					logger.Error(ctx, "update user activity", "err", err)
 				}
 			}
 
@@ -57,6 +80,43 @@ func authMiddleware(commonRepo *db.CommonRepo, logger embedlog.Logger) zenrpc.Mi
 	}
 }
 
+func newResponseError(ctx context.Context, err *zenrpc.Error) zenrpc.Response {
+	return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), err.Code, err.Message, err.Data)
+}
+
+// isPublicAuthMethod checks that auth method could be called without authentication.
+func isPublicAuthMethod(method string) bool {
+	switch method {
+	case RPC.AuthService.Login, RPC.AuthService.RequestPasswordReset, RPC.AuthService.ResetPassword:
+		return true
+	}
+	return false
+}
+
+// requestAPIToken returns api token from APITokenKey header or from "Authorization: Bearer" header.
+func requestAPIToken(r *http.Request) string {
+	if token := r.Header.Get(APITokenKey); token != "" {
+		return token
+	}
+
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+// updateAPITokenUsage updates last usage time of api token not often than activityUpdateInterval.
+func updateAPITokenUsage(ctx context.Context, commonRepo *db.CommonRepo, at *db.APIToken, logger embedlog.Logger) {
+	if at.LastUsedAt != nil && time.Since(*at.LastUsedAt) <= activityUpdateInterval {
+		return
+	}
+
+	if _, err := commonRepo.UpdateAPITokenUsage(ctx, at); err != nil {
+		logger.Error(ctx, "update api token usage", "err", err)
+	}
+}
+
 func UserFromContext(ctx context.Context) *db.User {
 	if user, ok := ctx.Value(userKey).(*db.User); ok {
 		return user
@@ -64,11 +124,52 @@ func UserFromContext(ctx context.Context) *db.User {
 	return nil
 }
 
-// HTTPAuthMiddleware checks user from authKey header
+// currentUserID returns id of authenticated user or nil for api tokens.
+func currentUserID(ctx context.Context) *int {
+	if user := UserFromContext(ctx); user != nil {
+		return &user.ID
+	}
+	return nil
+}
+
+// currentRole returns workflow role of authenticated user or enabled api token owner.
+func currentRole(ctx context.Context) workflow.Role {
+	if user := UserFromContext(ctx); user != nil {
+		return workflow.Role(user.Role)
+	} else if token := APITokenFromContext(ctx); token != nil && token.User != nil && token.User.StatusID == db.StatusEnabled {
+		return workflow.Role(token.User.Role)
+	}
+	return ""
+}
+
+// APITokenFromContext returns api token used for authentication of current request or nil.
+func APITokenFromContext(ctx context.Context) *db.APIToken {
+	if token, ok := ctx.Value(apiTokenKey).(*db.APIToken); ok {
+		return token
+	}
+	return nil
+}
+
+// HTTPAuthMiddleware checks user from authKey header or api token with vfs scope.
 func HTTPAuthMiddleware(commonRepo db.CommonRepo, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errCode := http.StatusUnauthorized
 
+		// check api token
+		if token := requestAPIToken(r); token != "" {
+			at, err := commonRepo.EnabledAPITokenByHash(r.Context(), hashToken(token))
+			if err != nil || at == nil {
+				http.Error(w, "api token not found", errCode)
+				return
+			} else if !apiTokenAllows(at.Scopes, NSVFS, "") {
+				http.Error(w, "api token scope is not allowed", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiTokenKey, at)))
+			return
+		}
+
 		// return error if header is not set
 		authHeader := r.Header.Get(AuthKey)
 		if authHeader == "" {
@@ -83,6 +184,6 @@ func HTTPAuthMiddleware(commonRepo db.CommonRepo, next http.Handler) http.Handle
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey, dbu)))
 	})
 }
@@ -2,22 +2,273 @@ package vt
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/scheduler"
+	"apisrv/pkg/workflow"
 
+	"github.com/go-pg/pg/v10"
 	"github.com/vmkteam/zenrpc/v2"
 )
 
+type AuthorService struct {
+	zenrpc.Service
+	embedlog.Logger
+	db       db.DB
+	newsRepo db.NewsRepo
+}
+
+func NewAuthorService(dbo db.DB, logger embedlog.Logger) *AuthorService {
+	return &AuthorService{
+		Logger:   logger,
+		db:       dbo,
+		newsRepo: db.NewNewsRepo(dbo),
+	}
+}
+
+func (s AuthorService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.newsRepo.DefaultAuthorSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.Author.ID, db.Columns.Author.Name, db.Columns.Author.Alias, db.Columns.Author.StatusID:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count returns count Authors according to conditions in search params.
+//
+//zenrpc:search AuthorSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s AuthorService) Count(ctx context.Context, search *AuthorSearch) (int, error) {
+	count, err := s.newsRepo.CountAuthors(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get returns а list of Authors according to conditions in search params.
+//
+//zenrpc:search AuthorSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []AuthorSummary
+//zenrpc:500 Internal Error
+func (s AuthorService) Get(ctx context.Context, search *AuthorSearch, viewOps *ViewOps) ([]AuthorSummary, error) {
+	list, err := s.newsRepo.AuthorsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.newsRepo.FullAuthor())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	authors := make([]AuthorSummary, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if author := NewAuthorSummary(&list[i]); author != nil {
+			authors = append(authors, *author)
+		}
+	}
+	return authors, nil
+}
+
+// GetByID returns a Author by its ID.
+//
+//zenrpc:id int
+//zenrpc:return Author
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s AuthorService) GetByID(ctx context.Context, id int) (*Author, error) {
+	db, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthor(db), nil
+}
+
+func (s AuthorService) byID(ctx context.Context, id int) (*db.Author, error) {
+	db, err := s.newsRepo.AuthorByID(ctx, id, s.newsRepo.FullAuthor())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return db, nil
+}
+
+// Add adds a Author from the query.
+//
+//zenrpc:author Author
+//zenrpc:return Author
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s AuthorService) Add(ctx context.Context, author Author) (*Author, error) {
+	if ve := s.isValid(ctx, author, false); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	db, err := s.newsRepo.AddAuthor(ctx, author.ToDB())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return NewAuthor(db), nil
+}
+
+// Update updates the Author data identified by id from the query.
+//
+//zenrpc:authors Author
+//zenrpc:return Author
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
+func (s AuthorService) Update(ctx context.Context, author Author) (bool, error) {
+	cur, err := s.byID(ctx, author.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if ve := s.isValid(ctx, author, true); ve.HasErrors() {
+		return false, ve.Error()
+	} else if cur.Version != author.Version {
+		return false, ConflictError(cur.Version)
+	}
+
+	ok, err := s.newsRepo.UpdateAuthorVersion(ctx, author.ToDB())
+	if err != nil {
+		return false, InternalError(err)
+	} else if !ok {
+		return false, s.conflict(ctx, author.ID)
+	}
+	return ok, nil
+}
+
+// conflict returns conflict error with current Author version.
+func (s AuthorService) conflict(ctx context.Context, id int) error {
+	cur, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ConflictError(cur.Version)
+}
+
+// Dependencies returns count of News of the Author.
+//
+//zenrpc:id int
+//zenrpc:return Dependencies
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s AuthorService) Dependencies(ctx context.Context, id int) (*Dependencies, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	count, err := s.newsRepo.CountNewsByAuthor(ctx, id)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return &Dependencies{News: count}, nil
+}
+
+// Delete deletes the Author by its ID. Links to News are kept, so Author could be restored from trash.
+//
+//zenrpc:id int
+//zenrpc:return isDeleted
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s AuthorService) Delete(ctx context.Context, id int) (bool, error) {
+	author, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	var ok bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if ok, err = s.newsRepo.WithTransaction(tx).DeleteAuthor(ctx, id); err != nil || !ok {
+			return err
+		}
+		return addTrashItem(ctx, tx, NSAuthor, id, author.Name, author.StatusID)
+	})
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, nil
+}
+
+// Validate verifies that Author data is valid.
+//
+//zenrpc:author Author
+//zenrpc:return []FieldError
+//zenrpc:500 Internal Error
+func (s AuthorService) Validate(ctx context.Context, author Author) ([]FieldError, error) {
+	isUpdate := author.ID != 0
+	if isUpdate {
+		_, err := s.byID(ctx, author.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	v := s.isValid(ctx, author, isUpdate)
+	if v.HasInternalError() {
+		return nil, v.Error()
+	}
+
+	return v.Fields(), nil
+}
+
+func (s AuthorService) isValid(ctx context.Context, author Author, isUpdate bool) Validator {
+	var v Validator
+	if v.CheckBasic(ctx, author); v.HasInternalError() {
+		return v
+	}
+
+	// check alias unique
+	search := &db.AuthorSearch{
+		Alias: &author.Alias,
+		NotID: &author.ID,
+	}
+	item, err := s.newsRepo.OneAuthor(ctx, search)
+	if err != nil {
+		v.SetInternalError(err)
+	} else if item != nil {
+		v.Append("alias", FieldErrorUnique)
+	}
+
+	// custom validation starts here
+	if isUpdate && author.Version == 0 {
+		v.Append("version", FieldErrorRequired)
+	}
+
+	return v
+}
+
+// categoryTreeLock serializes changes of Category parents, so concurrent moves could not make a cycle.
+const categoryTreeLock = "categoryTree"
+
+// errInvalidParent is returned from transaction when parent Category check under lock fails.
+var errInvalidParent = errors.New("invalid parent category")
+
+// categoryTreeSort is the order of siblings in Categories tree.
+var categoryTreeSort = db.WithSort(db.NewSortField(db.Columns.Category.OrderNumber, false), db.NewSortField(db.Columns.Category.Title, false))
+
 type CategoryService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db       db.DB
 	newsRepo db.NewsRepo
 }
 
 func NewCategoryService(dbo db.DB, logger embedlog.Logger) *CategoryService {
 	return &CategoryService{
 		Logger:   logger,
+		db:       dbo,
 		newsRepo: db.NewNewsRepo(dbo),
 	}
 }
@@ -118,39 +369,221 @@ func (s CategoryService) Add(ctx context.Context, category Category) (*Category,
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s CategoryService) Update(ctx context.Context, category Category) (bool, error) {
-	if _, err := s.byID(ctx, category.ID); err != nil {
+	cur, err := s.byID(ctx, category.ID)
+	if err != nil {
 		return false, err
 	}
 
 	if ve := s.isValid(ctx, category, true); ve.HasErrors() {
 		return false, ve.Error()
+	} else if cur.Version != category.Version {
+		return false, ConflictError(cur.Version)
 	}
 
-	ok, err := s.newsRepo.UpdateCategory(ctx, category.ToDB())
-	if err != nil {
+	var ok bool
+	err = s.db.RunInLock(ctx, categoryTreeLock, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		// parent is checked again under lock, concurrent moves could make a cycle
+		if code, err := s.checkParent(ctx, repo, category.ID, category.ParentCategoryID); err != nil {
+			return err
+		} else if code != "" {
+			return errInvalidParent
+		}
+
+		ok, err = repo.UpdateCategoryVersion(ctx, category.ToDB())
+		return err
+	})
+	if errors.Is(err, errInvalidParent) {
+		return false, ValidationError([]FieldError{{Field: "parentCategoryId", Error: FieldErrorCycle}})
+	} else if err != nil {
 		return false, InternalError(err)
+	} else if !ok {
+		return false, s.conflict(ctx, category.ID)
 	}
 	return ok, nil
 }
 
+// conflict returns conflict error with current Category version.
+func (s CategoryService) conflict(ctx context.Context, id int) error {
+	cur, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ConflictError(cur.Version)
+}
+
+// Dependencies returns count of News in the Category.
+//
+//zenrpc:id int
+//zenrpc:return Dependencies
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s CategoryService) Dependencies(ctx context.Context, id int) (*Dependencies, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	count, err := s.newsRepo.CountNewsByCategory(ctx, id)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	children, err := s.newsRepo.CountCategories(ctx, &db.CategorySearch{ParentCategoryID: &id})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return &Dependencies{News: count, Categories: children}, nil
+}
+
 // Delete deletes the Category by its ID.
+// Category with News is deleted only with reassign strategy, News are moved to reassignTo Category in the same transaction.
+// Category with subcategories could not be deleted, subcategories must be moved or deleted first.
 //
 //zenrpc:id int
+//zenrpc:strategy delete strategy: restrict (default), reassign
+//zenrpc:reassignTo Category id for News of deleted Category, required for reassign strategy
 //zenrpc:return isDeleted
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
-func (s CategoryService) Delete(ctx context.Context, id int) (bool, error) {
-	if _, err := s.byID(ctx, id); err != nil {
+func (s CategoryService) Delete(ctx context.Context, id int, strategy *string, reassignTo *int) (bool, error) {
+	category, err := s.byID(ctx, id)
+	if err != nil {
 		return false, err
 	}
 
-	ok, err := s.newsRepo.DeleteCategory(ctx, id)
-	if err != nil {
+	if ve := s.isValidDelete(ctx, id, strategy, reassignTo); ve.HasErrors() {
+		return false, ve.Error()
+	} else if strategy == nil || *strategy == DeleteStrategyRestrict {
+		reassignTo = nil
+	}
+
+	var ok bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		if children, err := repo.CountCategories(ctx, &db.CategorySearch{ParentCategoryID: &id}); err != nil {
+			return err
+		} else if children > 0 {
+			return db.ErrEntityInUse
+		}
+
+		count, err := repo.CountNewsByCategory(ctx, id)
+		if err != nil {
+			return err
+		} else if count > 0 && reassignTo == nil {
+			return db.ErrEntityInUse
+		} else if count > 0 {
+			if _, err = repo.ReassignNewsCategory(ctx, id, *reassignTo); err != nil {
+				return err
+			}
+		}
+
+		if ok, err = repo.DeleteCategory(ctx, id); err != nil || !ok {
+			return err
+		}
+		return addTrashItem(ctx, tx, NSCategory, id, category.Title, category.StatusID)
+	})
+	if errors.Is(err, db.ErrEntityInUse) {
+		return false, ValidationError([]FieldError{{Field: "id", Error: FieldErrorInUse}})
+	} else if err != nil {
 		return false, InternalError(err)
 	}
-	return ok, err
+	return ok, nil
+}
+
+// isValidDelete checks delete strategy and target Category for reassign strategy.
+func (s CategoryService) isValidDelete(ctx context.Context, id int, strategy *string, reassignTo *int) Validator {
+	var v Validator
+	switch {
+	case strategy == nil, *strategy == DeleteStrategyRestrict:
+	case *strategy != DeleteStrategyReassign:
+		v.Append("strategy", FieldErrorIncorrect)
+	case reassignTo == nil:
+		v.Append("reassignTo", FieldErrorRequired)
+	case *reassignTo == id:
+		v.Append("reassignTo", FieldErrorIncorrect)
+	default:
+		target, err := s.newsRepo.CategoryByID(ctx, *reassignTo)
+		if err != nil {
+			v.SetInternalError(err)
+		} else if target == nil {
+			v.Append("reassignTo", FieldErrorIncorrect)
+		}
+	}
+
+	return v
+}
+
+// SetStatus sets status of Categories in one transaction. Categories with News or subcategories could not be deleted.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s CategoryService) SetStatus(ctx context.Context, statusUpdate StatusUpdate) ([]BulkResult, error) {
+	var v Validator
+	if v.CheckBasic(ctx, statusUpdate); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	results := make([]BulkResult, 0, len(statusUpdate.ObjectIDs))
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		results = results[:0]
+		repo := s.newsRepo.WithTransaction(tx)
+		list, err := repo.CategoriesByFilters(ctx, &db.CategorySearch{IDs: statusUpdate.ObjectIDs}, db.PagerNoLimit)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int]*db.Category, len(list))
+		for i := range list {
+			byID[list[i].ID] = &list[i]
+		}
+
+		for _, id := range statusUpdate.ObjectIDs {
+			category, ok := byID[id]
+			if !ok {
+				results = append(results, BulkResult{ID: id, Result: BulkResultNotFound})
+				continue
+			}
+
+			// category used by news could be deleted only by Delete with explicit strategy
+			if statusUpdate.StatusID == db.StatusDeleted {
+				count, err := repo.CountNewsByCategory(ctx, id)
+				if err != nil {
+					return err
+				}
+				children, err := repo.CountCategories(ctx, &db.CategorySearch{ParentCategoryID: &id})
+				if err != nil {
+					return err
+				} else if count > 0 || children > 0 {
+					results = append(results, BulkResult{ID: id, Result: BulkResultForbidden})
+					continue
+				}
+			}
+
+			previousStatusID := category.StatusID
+			category.StatusID = statusUpdate.StatusID
+			category.Version++
+			if _, err = repo.UpdateCategory(ctx, category, db.WithColumns(db.Columns.Category.StatusID, db.Columns.Category.Version)); err != nil {
+				return err
+			}
+			if category.StatusID == db.StatusDeleted {
+				if err = addTrashItem(ctx, tx, NSCategory, id, category.Title, previousStatusID); err != nil {
+					return err
+				}
+			}
+			results = append(results, BulkResult{ID: id, Result: BulkResultUpdated})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return results, nil
 }
 
 // Validate verifies that Category data is valid.
@@ -194,174 +627,814 @@ func (s CategoryService) isValid(ctx context.Context, category Category, isUpdat
 		v.Append("alias", FieldErrorUnique)
 	}
 
+	if code, err := s.checkParent(ctx, s.newsRepo, category.ID, category.ParentCategoryID); err != nil {
+		v.SetInternalError(err)
+	} else if code != "" {
+		v.Append("parentCategoryId", code)
+	}
+
 	// custom validation starts here
+	if isUpdate && category.Version == 0 {
+		v.Append("version", FieldErrorRequired)
+	}
 	return v
 }
 
+// checkParent returns field error code if parent Category does not exist or it is the Category itself or its descendant.
+func (s CategoryService) checkParent(ctx context.Context, repo db.NewsRepo, id int, parentID *int) (string, error) {
+	if parentID == nil {
+		return "", nil
+	}
+
+	parent, err := repo.CategoryByID(ctx, *parentID)
+	if err != nil {
+		return "", err
+	} else if parent == nil {
+		return FieldErrorIncorrect, nil
+	} else if id == 0 {
+		return "", nil
+	}
+
+	ids, err := repo.CategoryTreeIDs(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	for _, treeID := range ids {
+		if treeID == *parentID {
+			return FieldErrorCycle, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Tree returns all Categories as a tree, siblings are ordered by orderNumber and title.
+// Categories with deleted parent are returned as roots.
+//
+//zenrpc:return []CategoryNode
+//zenrpc:500 Internal Error
+func (s CategoryService) Tree(ctx context.Context) ([]CategoryNode, error) {
+	list, err := s.newsRepo.CategoriesByFilters(ctx, &db.CategorySearch{}, db.PagerNoLimit, categoryTreeSort)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return NewCategoryNodes(db.NewCategoryTree(list)), nil
+}
+
+// Move moves the Category to the position among children of the new parent, siblings orderNumber is renumbered from 1.
+// All changes are made in one transaction.
+//
+//zenrpc:move CategoryMove
+//zenrpc:return isMoved
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s CategoryService) Move(ctx context.Context, move CategoryMove) (bool, error) {
+	if _, err := s.byID(ctx, move.ID); err != nil {
+		return false, err
+	}
+
+	var v Validator
+	if v.CheckBasic(ctx, move); v.HasErrors() {
+		return false, v.Error()
+	}
+
+	err := s.db.RunInLock(ctx, categoryTreeLock, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		if code, err := s.checkParent(ctx, repo, move.ID, move.ParentCategoryID); err != nil {
+			return err
+		} else if code != "" {
+			v.Append("parentCategoryId", code)
+			return errInvalidParent
+		}
+
+		list, err := repo.CategoriesByFilters(ctx, &db.CategorySearch{}, db.PagerNoLimit, categoryTreeSort)
+		if err != nil {
+			return err
+		}
+
+		var oldParentID *int
+		var siblings, oldSiblings []int
+		for _, c := range list {
+			switch {
+			case c.ID == move.ID:
+				oldParentID = c.ParentCategoryID
+			case equalIntPtrs(c.ParentCategoryID, move.ParentCategoryID):
+				siblings = append(siblings, c.ID)
+			}
+		}
+		for _, c := range list {
+			if c.ID != move.ID && equalIntPtrs(c.ParentCategoryID, oldParentID) {
+				oldSiblings = append(oldSiblings, c.ID)
+			}
+		}
+
+		if !equalIntPtrs(oldParentID, move.ParentCategoryID) {
+			if err = repo.SetCategoriesOrder(ctx, oldParentID, oldSiblings); err != nil {
+				return err
+			}
+		}
+
+		return repo.SetCategoriesOrder(ctx, move.ParentCategoryID, insertInt(siblings, move.ID, move.Position))
+	})
+	if errors.Is(err, errInvalidParent) {
+		return false, v.Error()
+	} else if err != nil {
+		return false, InternalError(err)
+	}
+	return true, nil
+}
+
+const (
+	defaultScheduledLimit = 50
+	maxScheduledLimit     = 500
+)
+
 type NewsService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db       db.DB
 	newsRepo db.NewsRepo
 }
 
 func NewNewsService(dbo db.DB, logger embedlog.Logger) *NewsService {
 	return &NewsService{
 		Logger:   logger,
+		db:       dbo,
 		newsRepo: db.NewNewsRepo(dbo),
 	}
 }
 
-func (s NewsService) dbSort(ops *ViewOps) db.OpFunc {
-	v := s.newsRepo.DefaultNewsSort()
-	if ops == nil {
-		return v
+func (s NewsService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.newsRepo.DefaultNewsSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.News.ID, db.Columns.News.Title, db.Columns.News.CategoryID, db.Columns.News.Author, db.Columns.News.PublishedAt, db.Columns.News.StatusID, db.Columns.News.State:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count returns count News according to conditions in search params.
+//
+//zenrpc:search NewsSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s NewsService) Count(ctx context.Context, search *NewsSearch) (int, error) {
+	count, err := s.newsRepo.CountNews(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get returns а list of News according to conditions in search params.
+//
+//zenrpc:search NewsSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []NewsSummary
+//zenrpc:500 Internal Error
+func (s NewsService) Get(ctx context.Context, search *NewsSearch, viewOps *ViewOps) ([]NewsSummary, error) {
+	list, err := s.newsRepo.NewsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.newsRepo.FullNews())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if err = s.newsRepo.FillNewsTagIDs(ctx, newsPointers(list)...); err != nil {
+		return nil, InternalError(err)
+	} else if err = s.newsRepo.FillNewsAuthorIDs(ctx, newsPointers(list)...); err != nil {
+		return nil, InternalError(err)
+	}
+	newsList := make([]NewsSummary, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if news := NewNewsSummary(&list[i]); news != nil {
+			newsList = append(newsList, *news)
+		}
+	}
+	return newsList, nil
+}
+
+// GetByID returns a News by its ID.
+//
+//zenrpc:id int
+//zenrpc:return News
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s NewsService) GetByID(ctx context.Context, id int) (*News, error) {
+	db, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewNews(db), nil
+}
+
+func (s NewsService) byID(ctx context.Context, id int) (*db.News, error) {
+	db, err := s.newsRepo.NewsByID(ctx, id, s.newsRepo.FullNews())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	} else if err = s.newsRepo.FillNewsTagIDs(ctx, db); err != nil {
+		return nil, InternalError(err)
+	} else if err = s.newsRepo.FillNewsAuthorIDs(ctx, db); err != nil {
+		return nil, InternalError(err)
+	}
+	return db, nil
+}
+
+// Add adds a News from the query.
+//
+//zenrpc:news News
+//zenrpc:return News
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) Add(ctx context.Context, news News) (*News, error) {
+	if ve := s.isValid(ctx, news, false); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	// new News always starts from draft and is hidden until published
+	news.State = string(workflow.StateDraft)
+	dbn := news.ToDB()
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		if _, err := repo.AddNews(ctx, dbn); err != nil {
+			return err
+		} else if err = repo.SetNewsTags(ctx, dbn.ID, dbn.TagIDs); err != nil {
+			return err
+		} else if err = repo.SetNewsAuthors(ctx, dbn.ID, dbn.AuthorIDs); err != nil {
+			return err
+		}
+
+		_, err := repo.AddNewsRevisionFrom(ctx, dbn, currentUserID(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return NewNews(dbn), nil
+}
+
+// Update updates the News data identified by id from the query.
+//
+//zenrpc:newsList News
+//zenrpc:return News
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:403 Forbidden
+//zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
+func (s NewsService) Update(ctx context.Context, news News) (bool, error) {
+	cur, err := s.byID(ctx, news.ID)
+	if err != nil {
+		return false, err
+	} else if !canChangeNews(ctx, cur) {
+		return false, ErrForbidden
+	}
+
+	if ve := s.isValid(ctx, news, true); ve.HasErrors() {
+		return false, ve.Error()
+	} else if cur.Version != news.Version {
+		return false, ConflictError(cur.Version)
+	}
+
+	// state is changed only via Transition
+	news.State = cur.State
+	var ok bool
+	dbn := news.ToDB()
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) (err error) {
+		repo := s.newsRepo.WithTransaction(tx)
+		if ok, err = repo.UpdateNewsVersion(ctx, dbn); err != nil || !ok {
+			return err
+		} else if err = repo.SetNewsTags(ctx, dbn.ID, dbn.TagIDs); err != nil {
+			return err
+		} else if err = repo.SetNewsAuthors(ctx, dbn.ID, dbn.AuthorIDs); err != nil {
+			return err
+		}
+
+		_, err = repo.AddNewsRevisionFrom(ctx, dbn, currentUserID(ctx))
+		return err
+	})
+	if err != nil {
+		return false, InternalError(err)
+	} else if !ok {
+		return false, s.conflict(ctx, news.ID)
+	}
+	return ok, nil
+}
+
+// conflict returns conflict error with current News version.
+func (s NewsService) conflict(ctx context.Context, id int) error {
+	cur, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ConflictError(cur.Version)
+}
+
+// Delete deletes the News by its ID.
+//
+//zenrpc:id int
+//zenrpc:return isDeleted
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:403 Forbidden
+//zenrpc:404 Not Found
+func (s NewsService) Delete(ctx context.Context, id int) (bool, error) {
+	news, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
+	} else if !canChangeNews(ctx, news) {
+		return false, ErrForbidden
+	}
+
+	var ok bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if ok, err = s.newsRepo.WithTransaction(tx).DeleteNews(ctx, id); err != nil || !ok {
+			return err
+		}
+		return addTrashItem(ctx, tx, NSNews, id, news.Title, news.StatusID)
+	})
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, nil
+}
+
+// SetStatus sets status of News in one transaction.
+// Only published News could be enabled, published News could not be disabled: unpublish them first.
+// Authors could change draft News only.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) SetStatus(ctx context.Context, statusUpdate StatusUpdate) ([]BulkResult, error) {
+	var v Validator
+	if v.CheckBasic(ctx, statusUpdate); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	return s.bulkUpdate(ctx, statusUpdate.ObjectIDs, func(news *db.News) bool {
+		isPublished := news.State == string(workflow.StatePublished)
+		if statusUpdate.StatusID == db.StatusEnabled && !isPublished || statusUpdate.StatusID == db.StatusDisabled && isPublished {
+			return false
+		}
+		news.StatusID = statusUpdate.StatusID
+		return true
+	})
+}
+
+// BulkDelete deletes News by their IDs in one transaction.
+//
+//zenrpc:ids []int
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) BulkDelete(ctx context.Context, ids []int) ([]BulkResult, error) {
+	if len(ids) == 0 {
+		return nil, ValidationError([]FieldError{{Field: "ids", Error: FieldErrorRequired}})
+	}
+
+	return s.bulkUpdate(ctx, ids, func(news *db.News) bool {
+		news.StatusID = db.StatusDeleted
+		return true
+	})
+}
+
+// BulkMove moves News to the Category in one transaction.
+//
+//zenrpc:categoryUpdate NewsCategoryUpdate
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) BulkMove(ctx context.Context, categoryUpdate NewsCategoryUpdate) ([]BulkResult, error) {
+	var v Validator
+	if v.CheckBasic(ctx, categoryUpdate); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	category, err := s.newsRepo.CategoryByID(ctx, categoryUpdate.CategoryID)
+	if err != nil {
+		return nil, InternalError(err)
+	} else if category == nil {
+		return nil, ValidationError([]FieldError{{Field: "categoryId", Error: FieldErrorIncorrect}})
+	}
+
+	return s.bulkUpdate(ctx, categoryUpdate.ObjectIDs, func(news *db.News) bool {
+		news.CategoryID = categoryUpdate.CategoryID
+		return true
+	})
+}
+
+// BulkAddTags adds Tags to News in one transaction.
+//
+//zenrpc:tagsUpdate NewsTagsUpdate
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) BulkAddTags(ctx context.Context, tagsUpdate NewsTagsUpdate) ([]BulkResult, error) {
+	if err := s.checkTagsUpdate(ctx, tagsUpdate); err != nil {
+		return nil, err
+	}
+
+	return s.bulkUpdate(ctx, tagsUpdate.ObjectIDs, func(news *db.News) bool {
+		for _, id := range tagsUpdate.TagIDs {
+			if !containsInt(news.TagIDs, id) {
+				news.TagIDs = append(news.TagIDs, id)
+			}
+		}
+		return true
+	})
+}
+
+// BulkRemoveTags removes Tags from News in one transaction.
+//
+//zenrpc:tagsUpdate NewsTagsUpdate
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s NewsService) BulkRemoveTags(ctx context.Context, tagsUpdate NewsTagsUpdate) ([]BulkResult, error) {
+	if err := s.checkTagsUpdate(ctx, tagsUpdate); err != nil {
+		return nil, err
+	}
+
+	return s.bulkUpdate(ctx, tagsUpdate.ObjectIDs, func(news *db.News) bool {
+		tagIDs := make([]int, 0, len(news.TagIDs))
+		for _, id := range news.TagIDs {
+			if !containsInt(tagsUpdate.TagIDs, id) {
+				tagIDs = append(tagIDs, id)
+			}
+		}
+		news.TagIDs = tagIDs
+		return true
+	})
+}
+
+func (s NewsService) checkTagsUpdate(ctx context.Context, tagsUpdate NewsTagsUpdate) error {
+	var v Validator
+	if v.CheckBasic(ctx, tagsUpdate); v.HasErrors() {
+		return v.Error()
+	}
+
+	tags, err := s.newsRepo.TagsByFilters(ctx, &db.TagSearch{IDs: tagsUpdate.TagIDs}, db.PagerNoLimit)
+	if err != nil {
+		return InternalError(err)
+	} else if len(tags) != len(tagsUpdate.TagIDs) {
+		return ValidationError([]FieldError{{Field: "tagIds", Error: FieldErrorIncorrect}})
+	}
+
+	return nil
+}
+
+// canChangeNews checks that current user could change the News: authors could change draft News only.
+func canChangeNews(ctx context.Context, news *db.News) bool {
+	return currentRole(ctx) != workflow.RoleAuthor || news.State == string(workflow.StateDraft)
+}
+
+// bulkUpdate applies fn to each News in one transaction and saves changed News with a new revision, changed Tags are saved too.
+// News are locked until the end of transaction. If News could not be changed by current user or fn returns false, News is not changed.
+func (s NewsService) bulkUpdate(ctx context.Context, ids []int, fn func(news *db.News) bool) ([]BulkResult, error) {
+	results := make([]BulkResult, 0, len(ids))
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		results = results[:0]
+		repo := s.newsRepo.WithTransaction(tx)
+		list, err := repo.NewsByIDsForUpdate(ctx, ids)
+		if err != nil {
+			return err
+		} else if err = repo.FillNewsTagIDs(ctx, newsPointers(list)...); err != nil {
+			return err
+		} else if err = repo.FillNewsAuthorIDs(ctx, newsPointers(list)...); err != nil {
+			return err
+		}
+
+		byID := make(map[int]*db.News, len(list))
+		for i := range list {
+			byID[list[i].ID] = &list[i]
+		}
+
+		for _, id := range ids {
+			news, ok := byID[id]
+			if !ok {
+				results = append(results, BulkResult{ID: id, Result: BulkResultNotFound})
+				continue
+			}
+
+			previousStatusID, tagIDs := news.StatusID, news.TagIDs
+			if !canChangeNews(ctx, news) || !fn(news) {
+				results = append(results, BulkResult{ID: id, Result: BulkResultForbidden})
+				continue
+			}
+
+			if ok, err = repo.UpdateNewsVersion(ctx, news); err != nil {
+				return err
+			} else if !ok {
+				results = append(results, BulkResult{ID: id, Result: BulkResultConflict})
+				continue
+			} else if !equalInts(tagIDs, news.TagIDs) {
+				if err = repo.SetNewsTags(ctx, id, news.TagIDs); err != nil {
+					return err
+				}
+			}
+			if news.StatusID == db.StatusDeleted {
+				if err = addTrashItem(ctx, tx, NSNews, id, news.Title, previousStatusID); err != nil {
+					return err
+				}
+			}
+			if _, err = repo.AddNewsRevisionFrom(ctx, news, currentUserID(ctx)); err != nil {
+				return err
+			}
+			results = append(results, BulkResult{ID: id, Result: BulkResultUpdated})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return results, nil
+}
+
+// Validate verifies that News data is valid.
+//
+//zenrpc:news News
+//zenrpc:return []FieldError
+//zenrpc:500 Internal Error
+func (s NewsService) Validate(ctx context.Context, news News) ([]FieldError, error) {
+	isUpdate := news.ID != 0
+	if isUpdate {
+		_, err := s.byID(ctx, news.ID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	switch ops.SortColumn {
-	case db.Columns.News.ID, db.Columns.News.Title, db.Columns.News.CategoryID, db.Columns.News.Author, db.Columns.News.PublishedAt, db.Columns.News.StatusID:
-		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	ve := s.isValid(ctx, news, isUpdate)
+	if ve.HasInternalError() {
+		return nil, ve.Error()
 	}
 
-	return v
+	return ve.Fields(), nil
 }
 
-// Count returns count News according to conditions in search params.
+// Revisions returns a list of News revisions, the latest revision is first.
 //
-//zenrpc:search NewsSearch
-//zenrpc:return int
+//zenrpc:id int
+//zenrpc:return []NewsRevisionSummary
 //zenrpc:500 Internal Error
-func (s NewsService) Count(ctx context.Context, search *NewsSearch) (int, error) {
-	count, err := s.newsRepo.CountNews(ctx, search.ToDB())
-	if err != nil {
-		return 0, InternalError(err)
+//zenrpc:404 Not Found
+func (s NewsService) Revisions(ctx context.Context, id int) ([]NewsRevisionSummary, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return nil, err
 	}
-	return count, nil
-}
 
-// Get returns а list of News according to conditions in search params.
-//
-//zenrpc:search NewsSearch
-//zenrpc:viewOps ViewOps
-//zenrpc:return []NewsSummary
-//zenrpc:500 Internal Error
-func (s NewsService) Get(ctx context.Context, search *NewsSearch, viewOps *ViewOps) ([]NewsSummary, error) {
-	list, err := s.newsRepo.NewsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.newsRepo.FullNews())
+	list, err := s.newsRepo.NewsRevisionsByFilters(ctx, &db.NewsRevisionSearch{NewsID: &id}, db.PagerNoLimit, s.newsRepo.DefaultNewsRevisionSort(), s.newsRepo.FullNewsRevision())
 	if err != nil {
 		return nil, InternalError(err)
 	}
-	newsList := make([]NewsSummary, 0, len(list))
+	revisions := make([]NewsRevisionSummary, 0, len(list))
 	for i := 0; i < len(list); i++ {
-		if news := NewNewsSummary(&list[i]); news != nil {
-			newsList = append(newsList, *news)
+		if rev := NewNewsRevisionSummary(&list[i]); rev != nil {
+			revisions = append(revisions, *rev)
 		}
 	}
-	return newsList, nil
+	return revisions, nil
 }
 
-// GetByID returns a News by its ID.
+// RevisionDiff returns changed fields and text diff between two News revisions.
 //
 //zenrpc:id int
-//zenrpc:return News
+//zenrpc:fromRev revision number to compare from
+//zenrpc:toRev revision number to compare to
+//zenrpc:return NewsRevisionDiff
 //zenrpc:500 Internal Error
 //zenrpc:404 Not Found
-func (s NewsService) GetByID(ctx context.Context, id int) (*News, error) {
-	db, err := s.byID(ctx, id)
+func (s NewsService) RevisionDiff(ctx context.Context, id, fromRev, toRev int) (*NewsRevisionDiff, error) {
+	from, err := s.revision(ctx, id, fromRev)
 	if err != nil {
 		return nil, err
 	}
-	return NewNews(db), nil
-}
 
-func (s NewsService) byID(ctx context.Context, id int) (*db.News, error) {
-	db, err := s.newsRepo.NewsByID(ctx, id, s.newsRepo.FullNews())
+	to, err := s.revision(ctx, id, toRev)
 	if err != nil {
-		return nil, InternalError(err)
-	} else if db == nil {
-		return nil, ErrNotFound
+		return nil, err
 	}
-	return db, nil
+
+	return NewNewsRevisionDiff(from, to), nil
 }
 
-// Add adds a News from the query.
+// RestoreRevision restores News fields from the revision. News status is not changed.
+// Restored News is validated and saved as a new revision.
 //
-//zenrpc:news News
-//zenrpc:return News
+//zenrpc:id int
+//zenrpc:revision revision number
+//zenrpc:return bool
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
-func (s NewsService) Add(ctx context.Context, news News) (*News, error) {
-	if ve := s.isValid(ctx, news, false); ve.HasErrors() {
-		return nil, ve.Error()
+//zenrpc:403 Forbidden
+//zenrpc:404 Not Found
+func (s NewsService) RestoreRevision(ctx context.Context, id, revision int) (bool, error) {
+	cur, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
 	}
 
-	db, err := s.newsRepo.AddNews(ctx, news.ToDB())
+	rev, err := s.revision(ctx, id, revision)
 	if err != nil {
-		return nil, InternalError(err)
+		return false, err
 	}
-	return NewNews(db), nil
+
+	news := News{
+		ID:          cur.ID,
+		Title:       rev.Title,
+		CategoryID:  rev.CategoryID,
+		Foreword:    rev.Foreword,
+		Content:     rev.Content,
+		TagIDs:      rev.TagIDs,
+		AuthorIDs:   rev.AuthorIDs,
+		Author:      rev.Author,
+		PublishedAt: rev.PublishedAt,
+		UnpublishAt: rev.UnpublishAt,
+		StatusID:    cur.StatusID,
+		Version:     cur.Version,
+	}
+
+	return s.Update(ctx, news)
 }
 
-// Update updates the News data identified by id from the query.
+// Transition moves News to the new workflow state.
+// Allowed transitions depend on current user role, some of them require a reason.
+// News becomes enabled when it is published and disabled when it leaves published state.
+// If News was changed concurrently, version conflict is returned.
 //
-//zenrpc:newsList News
-//zenrpc:return News
+//zenrpc:id int
+//zenrpc:state new state: draft, review, approved, scheduled, published, archived
+//zenrpc:reason comment for transition
+//zenrpc:return bool
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
+//zenrpc:403 Forbidden
 //zenrpc:404 Not Found
-func (s NewsService) Update(ctx context.Context, news News) (bool, error) {
-	if _, err := s.byID(ctx, news.ID); err != nil {
+//zenrpc:409 Version Conflict
+func (s NewsService) Transition(ctx context.Context, id int, state string, reason *string) (bool, error) {
+	news, err := s.byID(ctx, id)
+	if err != nil {
 		return false, err
 	}
 
-	if ve := s.isValid(ctx, news, true); ve.HasErrors() {
-		return false, ve.Error()
+	var comment string
+	if reason != nil {
+		comment = strings.TrimSpace(*reason)
+	}
+
+	from, to := workflow.State(news.State), workflow.State(state)
+	var v Validator
+	switch err = workflow.News().Check(from, to, currentRole(ctx), comment); err {
+	case nil:
+	case workflow.ErrForbidden:
+		return false, ErrForbidden
+	case workflow.ErrReasonRequired:
+		v.Append("reason", FieldErrorRequired)
+	default:
+		v.Append("state", FieldErrorIncorrect)
+	}
+
+	// scheduled news is published by scheduler, so publish time must be in the future
+	if to == workflow.StateScheduled && !news.PublishedAt.After(time.Now()) {
+		v.Append("publishedAt", FieldErrorIncorrect)
+	}
+	if v.HasErrors() {
+		return false, v.Error()
+	}
+
+	news.State = state
+	if to == workflow.StatePublished {
+		news.StatusID = db.StatusEnabled
+	} else if from == workflow.StatePublished && news.StatusID == db.StatusEnabled {
+		news.StatusID = db.StatusDisabled
+	}
+
+	tr := &db.NewsTransition{
+		NewsID:    news.ID,
+		UserID:    currentUserID(ctx),
+		FromState: string(from),
+		ToState:   string(to),
+		Reason:    stringOrNil(comment),
+	}
+	if token := APITokenFromContext(ctx); token != nil {
+		tr.APITokenID = &token.ID
 	}
 
-	ok, err := s.newsRepo.UpdateNews(ctx, news.ToDB())
+	var ok bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) (err error) {
+		repo := s.newsRepo.WithTransaction(tx)
+		if ok, err = repo.UpdateNewsState(ctx, news, string(from)); err != nil || !ok {
+			return err
+		}
+
+		_, err = repo.AddNewsTransition(ctx, tr)
+		return err
+	})
 	if err != nil {
 		return false, InternalError(err)
+	} else if !ok {
+		return false, s.conflict(ctx, id)
 	}
 	return ok, nil
 }
 
-// Delete deletes the News by its ID.
+// Transitions returns a history of News state changes, the latest change is first.
 //
 //zenrpc:id int
-//zenrpc:return isDeleted
+//zenrpc:return []NewsTransition
 //zenrpc:500 Internal Error
-//zenrpc:400 Validation Error
 //zenrpc:404 Not Found
-func (s NewsService) Delete(ctx context.Context, id int) (bool, error) {
+func (s NewsService) Transitions(ctx context.Context, id int) ([]NewsTransition, error) {
 	if _, err := s.byID(ctx, id); err != nil {
-		return false, err
+		return nil, err
 	}
 
-	ok, err := s.newsRepo.DeleteNews(ctx, id)
+	list, err := s.newsRepo.NewsTransitionsByFilters(ctx, &db.NewsTransitionSearch{NewsID: &id}, db.PagerNoLimit, s.newsRepo.DefaultNewsTransitionSort(), s.newsRepo.FullNewsTransition())
 	if err != nil {
-		return false, InternalError(err)
+		return nil, InternalError(err)
+	}
+	transitions := make([]NewsTransition, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if tr := NewNewsTransition(&list[i]); tr != nil {
+			transitions = append(transitions, *tr)
+		}
 	}
-	return ok, err
+	return transitions, nil
 }
 
-// Validate verifies that News data is valid.
+// AvailableTransitions returns states that current user could move News to.
 //
-//zenrpc:news News
-//zenrpc:return []FieldError
+//zenrpc:id int
+//zenrpc:return []AvailableTransition
 //zenrpc:500 Internal Error
-func (s NewsService) Validate(ctx context.Context, news News) ([]FieldError, error) {
-	isUpdate := news.ID != 0
-	if isUpdate {
-		_, err := s.byID(ctx, news.ID)
-		if err != nil {
-			return nil, err
-		}
+//zenrpc:404 Not Found
+func (s NewsService) AvailableTransitions(ctx context.Context, id int) ([]AvailableTransition, error) {
+	news, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	ve := s.isValid(ctx, news, isUpdate)
-	if ve.HasInternalError() {
-		return nil, ve.Error()
+	list := workflow.News().Available(workflow.State(news.State), currentRole(ctx))
+	transitions := make([]AvailableTransition, 0, len(list))
+	for _, t := range list {
+		transitions = append(transitions, AvailableTransition{State: string(t.To), ReasonRequired: t.ReasonRequired})
 	}
+	return transitions, nil
+}
 
-	return ve.Fields(), nil
+// Scheduled returns upcoming automatic publications and unpublications of News ordered by time.
+//
+//zenrpc:limit max number of actions, default is 50
+//zenrpc:return []ScheduledAction
+//zenrpc:500 Internal Error
+func (s NewsService) Scheduled(ctx context.Context, limit *int) ([]ScheduledAction, error) {
+	l := defaultScheduledLimit
+	if limit != nil && *limit > 0 && *limit <= maxScheduledLimit {
+		l = *limit
+	}
+
+	list, err := scheduler.Upcoming(ctx, s.newsRepo, l)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	actions := make([]ScheduledAction, 0, len(list))
+	for i := range list {
+		actions = append(actions, ScheduledAction{
+			Action: string(list[i].Action),
+			At:     list[i].At,
+			News:   NewNewsSummary(&list[i].News),
+		})
+	}
+	return actions, nil
+}
+
+// States returns all News workflow states in lifecycle order.
+//
+//zenrpc:return []string
+func (s NewsService) States() []string {
+	states := workflow.States()
+	r := make([]string, 0, len(states))
+	for _, st := range states {
+		r = append(r, string(st))
+	}
+	return r
+}
+
+func (s NewsService) revision(ctx context.Context, newsID, revision int) (*db.NewsRevision, error) {
+	rev, err := s.newsRepo.NewsRevisionByNumber(ctx, newsID, revision, s.newsRepo.FullNewsRevision())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if rev == nil {
+		return nil, ErrNotFound
+	}
+	return rev, nil
 }
 
 func (s NewsService) isValid(ctx context.Context, news News, isUpdate bool) Validator {
@@ -389,19 +1462,37 @@ func (s NewsService) isValid(ctx context.Context, news News, isUpdate bool) Vali
 			v.Append("tagIds", FieldErrorIncorrect)
 		}
 	}
+
+	if len(news.AuthorIDs) != 0 {
+		items, err := s.newsRepo.AuthorsByFilters(ctx, &db.AuthorSearch{IDs: news.AuthorIDs}, db.PagerNoLimit)
+		if err != nil {
+			v.SetInternalError(err)
+		} else if len(items) != len(news.AuthorIDs) {
+			v.Append("authorIds", FieldErrorIncorrect)
+		}
+	}
+
 	// custom validation starts here
+	if isUpdate && news.Version == 0 {
+		v.Append("version", FieldErrorRequired)
+	}
+	if news.UnpublishAt != nil && !news.UnpublishAt.After(news.PublishedAt) {
+		v.Append("unpublishAt", FieldErrorIncorrect)
+	}
 	return v
 }
 
 type TagService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db       db.DB
 	newsRepo db.NewsRepo
 }
 
 func NewTagService(dbo db.DB, logger embedlog.Logger) *TagService {
 	return &TagService{
 		Logger:   logger,
+		db:       dbo,
 		newsRepo: db.NewNewsRepo(dbo),
 	}
 }
@@ -502,39 +1593,269 @@ func (s TagService) Add(ctx context.Context, tag Tag) (*Tag, error) {
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s TagService) Update(ctx context.Context, tag Tag) (bool, error) {
-	if _, err := s.byID(ctx, tag.ID); err != nil {
+	cur, err := s.byID(ctx, tag.ID)
+	if err != nil {
 		return false, err
 	}
 
 	if ve := s.isValid(ctx, tag, true); ve.HasErrors() {
 		return false, ve.Error()
+	} else if cur.Version != tag.Version {
+		return false, ConflictError(cur.Version)
 	}
 
-	ok, err := s.newsRepo.UpdateTag(ctx, tag.ToDB())
+	ok, err := s.newsRepo.UpdateTagVersion(ctx, tag.ToDB())
 	if err != nil {
 		return false, InternalError(err)
+	} else if !ok {
+		return false, s.conflict(ctx, tag.ID)
 	}
 	return ok, nil
 }
 
+// conflict returns conflict error with current Tag version.
+func (s TagService) conflict(ctx context.Context, id int) error {
+	cur, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ConflictError(cur.Version)
+}
+
+// Dependencies returns count of News with the Tag.
+//
+//zenrpc:id int
+//zenrpc:return Dependencies
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s TagService) Dependencies(ctx context.Context, id int) (*Dependencies, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	count, err := s.newsRepo.CountNewsByTag(ctx, id)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return &Dependencies{News: count}, nil
+}
+
 // Delete deletes the Tag by its ID.
+// Tag used by News is deleted only with strip strategy, the Tag is removed from all News in the same transaction.
 //
 //zenrpc:id int
+//zenrpc:strategy delete strategy: restrict (default), strip
 //zenrpc:return isDeleted
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
-func (s TagService) Delete(ctx context.Context, id int) (bool, error) {
-	if _, err := s.byID(ctx, id); err != nil {
+func (s TagService) Delete(ctx context.Context, id int, strategy *string) (bool, error) {
+	tag, err := s.byID(ctx, id)
+	if err != nil {
 		return false, err
 	}
 
-	ok, err := s.newsRepo.DeleteTag(ctx, id)
-	if err != nil {
+	isStrip := strategy != nil && *strategy == DeleteStrategyStrip
+	if strategy != nil && !isStrip && *strategy != DeleteStrategyRestrict {
+		return false, ValidationError([]FieldError{{Field: "strategy", Error: FieldErrorIncorrect}})
+	}
+
+	var ok bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		count, err := repo.CountNewsByTag(ctx, id)
+		if err != nil {
+			return err
+		} else if count > 0 && !isStrip {
+			return db.ErrEntityInUse
+		} else if count > 0 {
+			if _, err = repo.StripNewsTag(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		if ok, err = repo.DeleteTag(ctx, id); err != nil || !ok {
+			return err
+		}
+		return addTrashItem(ctx, tx, NSTag, id, tag.Title, tag.StatusID)
+	})
+	if errors.Is(err, db.ErrEntityInUse) {
+		return false, ValidationError([]FieldError{{Field: "id", Error: FieldErrorInUse}})
+	} else if err != nil {
 		return false, InternalError(err)
 	}
-	return ok, err
+	return ok, nil
+}
+
+// SetStatus sets status of Tags in one transaction. Tags used by News could not be deleted.
+//
+//zenrpc:statusUpdate StatusUpdate
+//zenrpc:return []BulkResult
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s TagService) SetStatus(ctx context.Context, statusUpdate StatusUpdate) ([]BulkResult, error) {
+	var v Validator
+	if v.CheckBasic(ctx, statusUpdate); v.HasErrors() {
+		return nil, v.Error()
+	}
+
+	results := make([]BulkResult, 0, len(statusUpdate.ObjectIDs))
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		results = results[:0]
+		repo := s.newsRepo.WithTransaction(tx)
+		list, err := repo.TagsByFilters(ctx, &db.TagSearch{IDs: statusUpdate.ObjectIDs}, db.PagerNoLimit)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int]*db.Tag, len(list))
+		for i := range list {
+			byID[list[i].ID] = &list[i]
+		}
+
+		for _, id := range statusUpdate.ObjectIDs {
+			tag, ok := byID[id]
+			if !ok {
+				results = append(results, BulkResult{ID: id, Result: BulkResultNotFound})
+				continue
+			}
+
+			// tag used by news could be deleted only by Delete with explicit strategy
+			if statusUpdate.StatusID == db.StatusDeleted {
+				count, err := repo.CountNewsByTag(ctx, id)
+				if err != nil {
+					return err
+				} else if count > 0 {
+					results = append(results, BulkResult{ID: id, Result: BulkResultForbidden})
+					continue
+				}
+			}
+
+			previousStatusID := tag.StatusID
+			tag.StatusID = statusUpdate.StatusID
+			tag.Version++
+			if _, err = repo.UpdateTag(ctx, tag, db.WithColumns(db.Columns.Tag.StatusID, db.Columns.Tag.Version)); err != nil {
+				return err
+			}
+			if tag.StatusID == db.StatusDeleted {
+				if err = addTrashItem(ctx, tx, NSTag, id, tag.Title, previousStatusID); err != nil {
+					return err
+				}
+			}
+			results = append(results, BulkResult{ID: id, Result: BulkResultUpdated})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	return results, nil
+}
+
+// errInvalidMerge is returned from transaction when Tags merge check under lock fails.
+var errInvalidMerge = errors.New("invalid tags merge")
+
+// Merge merges source Tags into the target Tag in one transaction: News get the target Tag instead of source ones,
+// source Tags are removed and recorded as merged, so their ids are resolved to the target Tag in public API.
+// Revision is saved for every changed News.
+//
+//zenrpc:merge TagMerge
+//zenrpc:return count of changed News
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+func (s TagService) Merge(ctx context.Context, merge TagMerge) (int, error) {
+	var v Validator
+	if v.CheckBasic(ctx, merge); v.HasErrors() {
+		return 0, v.Error()
+	}
+
+	var count int
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		repo := s.newsRepo.WithTransaction(tx)
+		// tags are checked under lock, concurrent merge could remove sources or target
+		sources, ve := s.isValidMerge(ctx, repo, merge)
+		if ve.HasErrors() {
+			v = ve
+			return errInvalidMerge
+		}
+
+		newsList, err := repo.MergeNewsTags(ctx, merge.SourceIDs, merge.TargetID)
+		if err != nil {
+			return err
+		} else if err = repo.FillNewsTagIDs(ctx, newsPointers(newsList)...); err != nil {
+			return err
+		} else if err = repo.FillNewsAuthorIDs(ctx, newsPointers(newsList)...); err != nil {
+			return err
+		}
+
+		for i := range newsList {
+			if _, err = repo.AddNewsRevisionFrom(ctx, &newsList[i], currentUserID(ctx)); err != nil {
+				return err
+			}
+		}
+		count = len(newsList)
+
+		if err = repo.RedirectTagMerges(ctx, merge.SourceIDs, merge.TargetID); err != nil {
+			return err
+		}
+
+		for _, source := range sources {
+			tm := &db.TagMerge{SourceTagID: source.ID, SourceTitle: source.Title, TargetTagID: merge.TargetID, UserID: currentUserID(ctx)}
+			if _, err = repo.AddTagMerge(ctx, tm); err != nil {
+				return err
+			}
+		}
+
+		return repo.RemoveTags(ctx, merge.SourceIDs)
+	})
+	if errors.Is(err, errInvalidMerge) {
+		return 0, v.Error()
+	} else if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// isValidMerge locks target and source Tags and checks that all of them exist and target is not one of sources.
+// It returns locked source Tags.
+func (s TagService) isValidMerge(ctx context.Context, repo db.NewsRepo, merge TagMerge) ([]db.Tag, Validator) {
+	var v Validator
+	sourceIDs := make(map[int]struct{}, len(merge.SourceIDs))
+	for _, id := range merge.SourceIDs {
+		sourceIDs[id] = struct{}{}
+	}
+	if _, ok := sourceIDs[merge.TargetID]; ok {
+		v.Append("sourceIds", FieldErrorIncorrect)
+		return nil, v
+	}
+
+	tags, err := repo.TagsForUpdate(ctx, append([]int{merge.TargetID}, merge.SourceIDs...))
+	if err != nil {
+		v.SetInternalError(err)
+		return nil, v
+	}
+
+	var hasTarget bool
+	sources := make([]db.Tag, 0, len(sourceIDs))
+	for _, tag := range tags {
+		if tag.ID == merge.TargetID {
+			hasTarget = true
+		} else {
+			sources = append(sources, tag)
+		}
+	}
+
+	if !hasTarget {
+		v.Append("targetId", FieldErrorIncorrect)
+	}
+	if len(sources) != len(sourceIDs) {
+		v.Append("sourceIds", FieldErrorIncorrect)
+	}
+
+	return sources, v
 }
 
 // Validate verifies that Tag data is valid.
@@ -566,6 +1887,25 @@ func (s TagService) isValid(ctx context.Context, tag Tag, isUpdate bool) Validat
 		return v
 	}
 
+	// check title unique ignoring case
+	item, err := s.newsRepo.OneTag(ctx, &db.TagSearch{TitleFold: &tag.Title, NotID: &tag.ID})
+	if err != nil {
+		v.SetInternalError(err)
+	} else if item != nil {
+		v.Append("title", FieldErrorUnique)
+	}
+
+	// check alias unique
+	item, err = s.newsRepo.OneTag(ctx, &db.TagSearch{Alias: &tag.Alias, NotID: &tag.ID})
+	if err != nil {
+		v.SetInternalError(err)
+	} else if item != nil {
+		v.Append("alias", FieldErrorUnique)
+	}
+
 	// custom validation starts here
+	if isUpdate && tag.Version == 0 {
+		v.Append("version", FieldErrorRequired)
+	}
 	return v
 }
@@ -1,7 +1,11 @@
 package vt
 
 import (
+	"reflect"
+	"time"
+
 	"apisrv/pkg/db"
+	"apisrv/pkg/textdiff"
 )
 
 func NewCategory(in *db.Category) *Category {
@@ -10,13 +14,16 @@ func NewCategory(in *db.Category) *Category {
 	}
 
 	category := &Category{
-		ID:          in.ID,
-		Title:       in.Title,
-		OrderNumber: in.OrderNumber,
-		Alias:       in.Alias,
-		StatusID:    in.StatusID,
+		ID:               in.ID,
+		Title:            in.Title,
+		ParentCategoryID: in.ParentCategoryID,
+		OrderNumber:      in.OrderNumber,
+		Alias:            in.Alias,
+		StatusID:         in.StatusID,
+		Version:          in.Version,
 
-		Status: NewStatus(in.StatusID),
+		ParentCategory: NewCategorySummary(in.ParentCategory),
+		Status:         NewStatus(in.StatusID),
 	}
 
 	return category
@@ -28,15 +35,75 @@ func NewCategorySummary(in *db.Category) *CategorySummary {
 	}
 
 	return &CategorySummary{
-		ID:          in.ID,
-		Title:       in.Title,
-		OrderNumber: in.OrderNumber,
-		Alias:       in.Alias,
+		ID:               in.ID,
+		Title:            in.Title,
+		ParentCategoryID: in.ParentCategoryID,
+		OrderNumber:      in.OrderNumber,
+		Alias:            in.Alias,
 
 		Status: NewStatus(in.StatusID),
 	}
 }
 
+func NewCategoryNodes(in []db.CategoryNode) []CategoryNode {
+	nodes := make([]CategoryNode, 0, len(in))
+	for _, node := range in {
+		nodes = append(nodes, CategoryNode{
+			ID:          node.ID,
+			Title:       node.Title,
+			OrderNumber: node.OrderNumber,
+			Alias:       node.Alias,
+
+			Status:   NewStatus(node.StatusID),
+			Children: NewCategoryNodes(node.Children),
+		})
+	}
+
+	return nodes
+}
+
+func NewAuthor(in *db.Author) *Author {
+	if in == nil {
+		return nil
+	}
+
+	author := &Author{
+		ID:         in.ID,
+		Name:       in.Name,
+		Alias:      in.Alias,
+		Bio:        in.Bio,
+		AvatarHash: in.AvatarHash,
+		StatusID:   in.StatusID,
+		Version:    in.Version,
+
+		Status: NewStatus(in.StatusID),
+	}
+	if in.AvatarHash != nil {
+		author.Avatar = newVfsHashImage(*in.AvatarHash)
+	}
+
+	return author
+}
+
+func NewAuthorSummary(in *db.Author) *AuthorSummary {
+	if in == nil {
+		return nil
+	}
+
+	author := &AuthorSummary{
+		ID:    in.ID,
+		Name:  in.Name,
+		Alias: in.Alias,
+
+		Status: NewStatus(in.StatusID),
+	}
+	if in.AvatarHash != nil {
+		author.Avatar = newVfsHashImage(*in.AvatarHash)
+	}
+
+	return author
+}
+
 func NewNews(in *db.News) *News {
 	if in == nil {
 		return nil
@@ -49,9 +116,13 @@ func NewNews(in *db.News) *News {
 		Foreword:    in.Foreword,
 		Content:     in.Content,
 		TagIDs:      in.TagIDs,
+		AuthorIDs:   in.AuthorIDs,
 		Author:      in.Author,
 		PublishedAt: in.PublishedAt,
+		UnpublishAt: in.UnpublishAt,
 		StatusID:    in.StatusID,
+		Version:     in.Version,
+		State:       in.State,
 
 		Category: NewCategorySummary(in.Category),
 		Status:   NewStatus(in.StatusID),
@@ -69,8 +140,11 @@ func NewNewsSummary(in *db.News) *NewsSummary {
 		ID:          in.ID,
 		Title:       in.Title,
 		CategoryID:  in.CategoryID,
+		AuthorIDs:   in.AuthorIDs,
 		Author:      in.Author,
 		PublishedAt: in.PublishedAt,
+		UnpublishAt: in.UnpublishAt,
+		State:       in.State,
 
 		Category: NewCategorySummary(in.Category),
 		Status:   NewStatus(in.StatusID),
@@ -85,7 +159,9 @@ func NewTag(in *db.Tag) *Tag {
 	tag := &Tag{
 		ID:       in.ID,
 		Title:    in.Title,
+		Alias:    in.Alias,
 		StatusID: in.StatusID,
+		Version:  in.Version,
 
 		Status: NewStatus(in.StatusID),
 	}
@@ -101,7 +177,125 @@ func NewTagSummary(in *db.Tag) *TagSummary {
 	return &TagSummary{
 		ID:    in.ID,
 		Title: in.Title,
+		Alias: in.Alias,
 
 		Status: NewStatus(in.StatusID),
 	}
 }
+
+func NewNewsRevision(in *db.NewsRevision) *NewsRevision {
+	if in == nil {
+		return nil
+	}
+
+	return &NewsRevision{
+		ID:          in.ID,
+		NewsID:      in.NewsID,
+		Revision:    in.Revision,
+		CreatedAt:   in.CreatedAt,
+		Title:       in.Title,
+		CategoryID:  in.CategoryID,
+		Foreword:    in.Foreword,
+		Content:     in.Content,
+		TagIDs:      in.TagIDs,
+		AuthorIDs:   in.AuthorIDs,
+		Author:      in.Author,
+		PublishedAt: in.PublishedAt,
+		UnpublishAt: in.UnpublishAt,
+		StatusID:    in.StatusID,
+
+		User: NewUserSummary(in.User),
+	}
+}
+
+func NewNewsRevisionSummary(in *db.NewsRevision) *NewsRevisionSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &NewsRevisionSummary{
+		ID:        in.ID,
+		Revision:  in.Revision,
+		CreatedAt: in.CreatedAt,
+		Title:     in.Title,
+
+		User: NewUserSummary(in.User),
+	}
+}
+
+func NewNewsTransition(in *db.NewsTransition) *NewsTransition {
+	if in == nil {
+		return nil
+	}
+
+	return &NewsTransition{
+		ID:         in.ID,
+		NewsID:     in.NewsID,
+		FromState:  in.FromState,
+		ToState:    in.ToState,
+		Reason:     in.Reason,
+		CreatedAt:  in.CreatedAt,
+		UserID:     in.UserID,
+		APITokenID: in.APITokenID,
+
+		User: NewUserSummary(in.User),
+	}
+}
+
+// NewNewsRevisionDiff returns changed fields between from and to revisions.
+func NewNewsRevisionDiff(from, to *db.NewsRevision) *NewsRevisionDiff {
+	diff := &NewsRevisionDiff{
+		NewsID: to.NewsID,
+		From:   NewNewsRevision(from),
+		To:     NewNewsRevision(to),
+		Fields: []NewsFieldDiff{},
+	}
+
+	text := func(field, was, now string) {
+		if was != now {
+			diff.Fields = append(diff.Fields, NewsFieldDiff{Field: field, Old: was, New: now, Text: newDiffChunks(textdiff.Words(was, now))})
+		}
+	}
+	value := func(field string, was, now interface{}) {
+		if !reflect.DeepEqual(was, now) {
+			diff.Fields = append(diff.Fields, NewsFieldDiff{Field: field, Old: was, New: now})
+		}
+	}
+
+	text("title", from.Title, to.Title)
+	value("categoryId", from.CategoryID, to.CategoryID)
+	text("foreword", from.Foreword, to.Foreword)
+	text("content", stringValue(from.Content), stringValue(to.Content))
+	value("tagIds", from.TagIDs, to.TagIDs)
+	value("authorIds", from.AuthorIDs, to.AuthorIDs)
+	text("author", from.Author, to.Author)
+	value("publishedAt", from.PublishedAt.UTC(), to.PublishedAt.UTC())
+	value("unpublishAt", timeUTC(from.UnpublishAt), timeUTC(to.UnpublishAt))
+	value("statusId", from.StatusID, to.StatusID)
+
+	return diff
+}
+
+func newDiffChunks(in []textdiff.Chunk) []DiffChunk {
+	chunks := make([]DiffChunk, 0, len(in))
+	for _, c := range in {
+		chunks = append(chunks, DiffChunk{Op: string(c.Op), Text: c.Text})
+	}
+	return chunks
+}
+
+// timeUTC returns time in UTC or nil, so equal times are compared regardless of location.
+func timeUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,81 @@
+package vt
+
+import (
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewNewsRevisionDiff(t *testing.T) {
+	Convey("Test News revision diff", t, func() {
+		publishedAt := time.Now()
+		content := "first text"
+		from := &db.NewsRevision{NewsID: 1, Revision: 1, Title: "Title", CategoryID: 1, Foreword: "Foreword", TagIDs: []int{1}, Author: "Author", PublishedAt: publishedAt, StatusID: db.StatusEnabled}
+		to := &db.NewsRevision{NewsID: 1, Revision: 2, Title: "New Title", CategoryID: 1, Foreword: "Foreword", Content: &content, TagIDs: []int{1, 2}, Author: "Author", PublishedAt: publishedAt, StatusID: db.StatusEnabled}
+
+		diff := NewNewsRevisionDiff(from, to)
+		So(diff.From.Revision, ShouldEqual, 1)
+		So(diff.To.Revision, ShouldEqual, 2)
+		So(diff.Fields, ShouldHaveLength, 3)
+
+		So(diff.Fields[0].Field, ShouldEqual, "title")
+		So(diff.Fields[0].Text, ShouldResemble, []DiffChunk{{Op: "insert", Text: "New "}, {Op: "equal", Text: "Title"}})
+		So(diff.Fields[1].Field, ShouldEqual, "content")
+		So(diff.Fields[1].Text, ShouldResemble, []DiffChunk{{Op: "insert", Text: content}})
+		So(diff.Fields[2].Field, ShouldEqual, "tagIds")
+		So(diff.Fields[2].Text, ShouldBeNil)
+
+		Convey("Authors and unpublish time are compared", func() {
+			unpublishAt := publishedAt.Add(time.Hour)
+			from.AuthorIDs, to.AuthorIDs = []int{1}, []int{2, 1}
+			to.UnpublishAt = &unpublishAt
+			from.UnpublishAt = nil
+
+			diff := NewNewsRevisionDiff(from, to)
+			So(diff.Fields, ShouldHaveLength, 5)
+			So(diff.Fields[3].Field, ShouldEqual, "authorIds")
+			So(diff.Fields[4].Field, ShouldEqual, "unpublishAt")
+
+			sameTime := unpublishAt.In(time.FixedZone("test", 3600))
+			from.UnpublishAt = &sameTime
+			So(NewNewsRevisionDiff(from, to).Fields, ShouldHaveLength, 4)
+		})
+	})
+}
+
+func TestNewCategoryNodes(t *testing.T) {
+	Convey("Test Category tree", t, func() {
+		parent, child, deleted := 1, 2, 5
+		list := []db.Category{
+			{ID: 1, Title: "Root", StatusID: db.StatusEnabled},
+			{ID: 3, Title: "Child 2", ParentCategoryID: &parent, StatusID: db.StatusEnabled},
+			{ID: 2, Title: "Child 1", ParentCategoryID: &parent, StatusID: db.StatusEnabled},
+			{ID: 4, Title: "Grandchild", ParentCategoryID: &child, StatusID: db.StatusDisabled},
+			{ID: 6, Title: "Orphan", ParentCategoryID: &deleted, StatusID: db.StatusEnabled},
+		}
+
+		nodes := NewCategoryNodes(db.NewCategoryTree(list))
+		So(nodes, ShouldHaveLength, 2)
+		So(nodes[0].ID, ShouldEqual, 1)
+		So(nodes[1].ID, ShouldEqual, 6)
+
+		Convey("Siblings keep list order", func() {
+			So(nodes[0].Children, ShouldHaveLength, 2)
+			So(nodes[0].Children[0].ID, ShouldEqual, 3)
+			So(nodes[0].Children[1].ID, ShouldEqual, 2)
+			So(nodes[0].Children[1].Children[0].ID, ShouldEqual, 4)
+			So(nodes[0].Children[1].Children[0].Status.ID, ShouldEqual, db.StatusDisabled)
+			So(nodes[1].Children, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Test insert of moved Category", t, func() {
+		So(insertInt([]int{1, 2, 3}, 9, 0), ShouldResemble, []int{9, 1, 2, 3})
+		So(insertInt([]int{1, 2, 3}, 9, 2), ShouldResemble, []int{1, 2, 9, 3})
+		So(insertInt([]int{1, 2, 3}, 9, 10), ShouldResemble, []int{1, 2, 3, 9})
+		So(insertInt(nil, 9, 1), ShouldResemble, []int{9})
+	})
+}
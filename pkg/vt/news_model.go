@@ -8,13 +8,16 @@ import (
 )
 
 type Category struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title" validate:"required,max=255"`
-	OrderNumber *int   `json:"orderNumber"`
-	Alias       string `json:"alias" validate:"required,alias,max=255"`
-	StatusID    int    `json:"statusId" validate:"required,status"`
-
-	Status *Status `json:"status"`
+	ID               int    `json:"id"`
+	Title            string `json:"title" validate:"required,max=255"`
+	ParentCategoryID *int   `json:"parentCategoryId"`
+	OrderNumber      *int   `json:"orderNumber"`
+	Alias            string `json:"alias" validate:"required,alias,max=255"`
+	StatusID         int    `json:"statusId" validate:"required,status"`
+	Version          int    `json:"version"`
+
+	ParentCategory *CategorySummary `json:"parentCategory"`
+	Status         *Status          `json:"status"`
 }
 
 func (c *Category) ToDB() *db.Category {
@@ -23,24 +26,27 @@ func (c *Category) ToDB() *db.Category {
 	}
 
 	category := &db.Category{
-		ID:          c.ID,
-		Title:       c.Title,
-		OrderNumber: c.OrderNumber,
-		Alias:       c.Alias,
-		StatusID:    c.StatusID,
+		ID:               c.ID,
+		Title:            c.Title,
+		ParentCategoryID: c.ParentCategoryID,
+		OrderNumber:      c.OrderNumber,
+		Alias:            c.Alias,
+		StatusID:         c.StatusID,
+		Version:          c.Version,
 	}
 
 	return category
 }
 
 type CategorySearch struct {
-	ID          *int    `json:"id"`
-	Title       *string `json:"title"`
-	OrderNumber *int    `json:"orderNumber"`
-	Alias       *string `json:"alias"`
-	StatusID    *int    `json:"statusId"`
-	IDs         []int   `json:"ids"`
-	NotID       *int    `json:"notId"`
+	ID               *int    `json:"id"`
+	Title            *string `json:"title"`
+	ParentCategoryID *int    `json:"parentCategoryId"`
+	OrderNumber      *int    `json:"orderNumber"`
+	Alias            *string `json:"alias"`
+	StatusID         *int    `json:"statusId"`
+	IDs              []int   `json:"ids"`
+	NotID            *int    `json:"notId"`
 }
 
 func (cs *CategorySearch) ToDB() *db.CategorySearch {
@@ -49,35 +55,123 @@ func (cs *CategorySearch) ToDB() *db.CategorySearch {
 	}
 
 	return &db.CategorySearch{
-		ID:          cs.ID,
-		TitleILike:  cs.Title,
-		OrderNumber: cs.OrderNumber,
-		Alias:       cs.Alias,
-		StatusID:    cs.StatusID,
-		IDs:         cs.IDs,
-		NotID:       cs.NotID,
+		ID:               cs.ID,
+		TitleILike:       cs.Title,
+		ParentCategoryID: cs.ParentCategoryID,
+		OrderNumber:      cs.OrderNumber,
+		Alias:            cs.Alias,
+		StatusID:         cs.StatusID,
+		IDs:              cs.IDs,
+		NotID:            cs.NotID,
 	}
 }
 
 type CategorySummary struct {
+	ID               int    `json:"id"`
+	Title            string `json:"title"`
+	ParentCategoryID *int   `json:"parentCategoryId"`
+	OrderNumber      *int   `json:"orderNumber"`
+	Alias            string `json:"alias"`
+
+	Status *Status `json:"status"`
+}
+
+// CategoryNode is a Category in the tree, children are ordered by orderNumber.
+type CategoryNode struct {
 	ID          int    `json:"id"`
 	Title       string `json:"title"`
 	OrderNumber *int   `json:"orderNumber"`
 	Alias       string `json:"alias"`
 
-	Status *Status `json:"status"`
+	Status   *Status        `json:"status"`
+	Children []CategoryNode `json:"children"`
+}
+
+// CategoryMove is a drag-and-drop move of the Category.
+type CategoryMove struct {
+	ID               int  `json:"id" validate:"required"`
+	ParentCategoryID *int `json:"parentCategoryId"`          // new parent, nil for root
+	Position         int  `json:"position" validate:"min=0"` // zero-based position among new siblings
+}
+
+type Author struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name" validate:"required,max=128"`
+	Alias      string  `json:"alias" validate:"required,alias,max=128"`
+	Bio        *string `json:"bio"`
+	AvatarHash *string `json:"avatarHash" validate:"omitempty,max=40"`
+	StatusID   int     `json:"statusId" validate:"required,status"`
+	Version    int     `json:"version"`
+
+	Avatar *VfsHashImage `json:"avatar"`
+	Status *Status       `json:"status"`
+}
+
+func (a *Author) ToDB() *db.Author {
+	if a == nil {
+		return nil
+	}
+
+	author := &db.Author{
+		ID:         a.ID,
+		Name:       a.Name,
+		Alias:      a.Alias,
+		Bio:        a.Bio,
+		AvatarHash: a.AvatarHash,
+		StatusID:   a.StatusID,
+		Version:    a.Version,
+	}
+
+	return author
+}
+
+type AuthorSearch struct {
+	ID       *int    `json:"id"`
+	Name     *string `json:"name"`
+	Alias    *string `json:"alias"`
+	StatusID *int    `json:"statusId"`
+	IDs      []int   `json:"ids"`
+	NotID    *int    `json:"notId"`
+}
+
+func (as *AuthorSearch) ToDB() *db.AuthorSearch {
+	if as == nil {
+		return nil
+	}
+
+	return &db.AuthorSearch{
+		ID:        as.ID,
+		NameILike: as.Name,
+		Alias:     as.Alias,
+		StatusID:  as.StatusID,
+		IDs:       as.IDs,
+		NotID:     as.NotID,
+	}
+}
+
+type AuthorSummary struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Alias string `json:"alias"`
+
+	Avatar *VfsHashImage `json:"avatar"`
+	Status *Status       `json:"status"`
 }
 
 type News struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title" validate:"required,max=255"`
-	CategoryID  int       `json:"categoryId" validate:"required"`
-	Foreword    string    `json:"foreword" validate:"required,max=1024"`
-	Content     *string   `json:"content"`
-	TagIDs      []int     `json:"tagIds" validate:"required"`
-	Author      string    `json:"author" validate:"required,max=64"`
-	PublishedAt time.Time `json:"publishedAt" validate:"required"`
-	StatusID    int       `json:"statusId" validate:"required,status"`
+	ID          int        `json:"id"`
+	Title       string     `json:"title" validate:"required,max=255"`
+	CategoryID  int        `json:"categoryId" validate:"required"`
+	Foreword    string     `json:"foreword" validate:"required,max=1024"`
+	Content     *string    `json:"content"`
+	TagIDs      []int      `json:"tagIds" validate:"required"`
+	AuthorIDs   []int      `json:"authorIds"` // co-authors, the first one is the main author
+	Author      string     `json:"author" validate:"required,max=64"`
+	PublishedAt time.Time  `json:"publishedAt" validate:"required"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+	StatusID    int        `json:"statusId" validate:"required,status"`
+	State       string     `json:"state"` // read-only, changed via transition method
+	Version     int        `json:"version"`
 
 	Category *CategorySummary `json:"category"`
 	Status   *Status          `json:"status"`
@@ -95,9 +189,13 @@ func (n *News) ToDB() *db.News {
 		Foreword:    n.Foreword,
 		Content:     n.Content,
 		TagIDs:      n.TagIDs,
+		AuthorIDs:   n.AuthorIDs,
 		Author:      n.Author,
 		PublishedAt: n.PublishedAt,
+		UnpublishAt: n.UnpublishAt,
 		StatusID:    n.StatusID,
+		State:       n.State,
+		Version:     n.Version,
 	}
 
 	return news
@@ -111,7 +209,12 @@ type NewsSearch struct {
 	Author      *string    `json:"author"`
 	PublishedAt *time.Time `json:"publishedAt"`
 	StatusID    *int       `json:"statusId"`
+	State       *string    `json:"state"`
+	TagID       *int       `json:"tagId"`
+	AuthorID    *int       `json:"authorId"`
 	IDs         []int      `json:"ids"`
+
+	WithSubcategories bool `json:"withSubcategories"` // categoryId matches News of all its descendants too
 }
 
 func (ns *NewsSearch) ToDB() *db.NewsSearch {
@@ -119,7 +222,7 @@ func (ns *NewsSearch) ToDB() *db.NewsSearch {
 		return nil
 	}
 
-	return &db.NewsSearch{
+	search := &db.NewsSearch{
 		ID:            ns.ID,
 		TitleILike:    ns.Title,
 		CategoryID:    ns.CategoryID,
@@ -127,25 +230,115 @@ func (ns *NewsSearch) ToDB() *db.NewsSearch {
 		AuthorILike:   ns.Author,
 		PublishedAt:   ns.PublishedAt,
 		StatusID:      ns.StatusID,
+		State:         ns.State,
+		TagID:         ns.TagID,
+		AuthorID:      ns.AuthorID,
 		IDs:           ns.IDs,
 	}
+	if ns.WithSubcategories {
+		search.CategoryID, search.CategoryTreeID = nil, ns.CategoryID
+	}
+
+	return search
 }
 
 type NewsSummary struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	CategoryID  int       `json:"categoryId"`
-	Author      string    `json:"author"`
-	PublishedAt time.Time `json:"publishedAt"`
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	CategoryID  int        `json:"categoryId"`
+	AuthorIDs   []int      `json:"authorIds"`
+	Author      string     `json:"author"`
+	PublishedAt time.Time  `json:"publishedAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+	State       string     `json:"state"`
 
 	Category *CategorySummary `json:"category"`
 	Status   *Status          `json:"status"`
 }
 
+type NewsRevision struct {
+	ID          int        `json:"id"`
+	NewsID      int        `json:"newsId"`
+	Revision    int        `json:"revision"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Title       string     `json:"title"`
+	CategoryID  int        `json:"categoryId"`
+	Foreword    string     `json:"foreword"`
+	Content     *string    `json:"content"`
+	TagIDs      []int      `json:"tagIds"`
+	AuthorIDs   []int      `json:"authorIds"`
+	Author      string     `json:"author"`
+	PublishedAt time.Time  `json:"publishedAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+	StatusID    int        `json:"statusId"`
+
+	User *UserSummary `json:"user"`
+}
+
+type NewsRevisionSummary struct {
+	ID        int       `json:"id"`
+	Revision  int       `json:"revision"`
+	CreatedAt time.Time `json:"createdAt"`
+	Title     string    `json:"title"`
+
+	User *UserSummary `json:"user"`
+}
+
+// NewsRevisionDiff contains changed fields between two News revisions.
+type NewsRevisionDiff struct {
+	NewsID int             `json:"newsId"`
+	From   *NewsRevision   `json:"from"`
+	To     *NewsRevision   `json:"to"`
+	Fields []NewsFieldDiff `json:"fields"`
+}
+
+// NewsFieldDiff is a change of a single News field. Text is filled for text fields only.
+type NewsFieldDiff struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+	Text  []DiffChunk `json:"text"`
+}
+
+// NewsTransition is a record of News state change.
+type NewsTransition struct {
+	ID         int       `json:"id"`
+	NewsID     int       `json:"newsId"`
+	FromState  string    `json:"fromState"`
+	ToState    string    `json:"toState"`
+	Reason     *string   `json:"reason"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UserID     *int      `json:"userId"`
+	APITokenID *int      `json:"apiTokenId"`
+
+	User *UserSummary `json:"user"`
+}
+
+// AvailableTransition is a News state that current user could move News to.
+type AvailableTransition struct {
+	State          string `json:"state"`
+	ReasonRequired bool   `json:"reasonRequired"`
+}
+
+// ScheduledAction is an upcoming automatic change of News, action is one of: publish, unpublish.
+type ScheduledAction struct {
+	Action string       `json:"action"`
+	At     time.Time    `json:"at"`
+	News   *NewsSummary `json:"news"`
+}
+
+// DiffChunk is a part of text diff, op is one of: equal, insert, delete.
+type DiffChunk struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
 type Tag struct {
 	ID       int    `json:"id"`
 	Title    string `json:"title" validate:"required,max=128"`
+	Alias    string `json:"alias" validate:"required,alias,max=128"`
 	StatusID int    `json:"statusId" validate:"required,status"`
+	Version  int    `json:"version"`
 
 	Status *Status `json:"status"`
 }
@@ -158,7 +351,9 @@ func (t *Tag) ToDB() *db.Tag {
 	tag := &db.Tag{
 		ID:       t.ID,
 		Title:    t.Title,
+		Alias:    t.Alias,
 		StatusID: t.StatusID,
+		Version:  t.Version,
 	}
 
 	return tag
@@ -167,6 +362,7 @@ func (t *Tag) ToDB() *db.Tag {
 type TagSearch struct {
 	ID       *int    `json:"id"`
 	Title    *string `json:"title"`
+	Alias    *string `json:"alias"`
 	StatusID *int    `json:"statusId"`
 	IDs      []int   `json:"ids"`
 }
@@ -179,14 +375,22 @@ func (ts *TagSearch) ToDB() *db.TagSearch {
 	return &db.TagSearch{
 		ID:         ts.ID,
 		TitleILike: ts.Title,
+		Alias:      ts.Alias,
 		StatusID:   ts.StatusID,
 		IDs:        ts.IDs,
 	}
 }
 
+// TagMerge merges source Tags into the target Tag.
+type TagMerge struct {
+	SourceIDs []int `json:"sourceIds" validate:"required,min=1"`
+	TargetID  int   `json:"targetId" validate:"required"`
+}
+
 type TagSummary struct {
 	ID    int    `json:"id"`
 	Title string `json:"title"`
+	Alias string `json:"alias"`
 
 	Status *Status `json:"status"`
 }
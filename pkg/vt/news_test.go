@@ -0,0 +1,724 @@
+package vt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+	"apisrv/pkg/workflow"
+
+	"github.com/go-pg/pg/v10"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testSuffix returns unique suffix for titles and aliases of test entities.
+func testSuffix() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// testUserContext returns context with admin user from DB which has the given role.
+func testUserContext(ctx context.Context, role workflow.Role) context.Context {
+	login := "admin"
+	user, err := db.NewCommonRepo(testDb).OneUser(ctx, &db.UserSearch{Login: &login})
+	So(err, ShouldBeNil)
+	So(user, ShouldNotBeNil)
+
+	user.Role = string(role)
+	return context.WithValue(ctx, userKey, user)
+}
+
+func addTestCategory(ctx context.Context, parentID *int) *db.Category {
+	s := testSuffix()
+	c, err := db.NewNewsRepo(testDb).AddCategory(ctx, &db.Category{Title: "Category " + s, Alias: "category-" + s, ParentCategoryID: parentID, StatusID: db.StatusEnabled})
+	So(err, ShouldBeNil)
+	return c
+}
+
+func addTestTag(ctx context.Context) *db.Tag {
+	s := testSuffix()
+	t, err := db.NewNewsRepo(testDb).AddTag(ctx, &db.Tag{Title: "Tag " + s, Alias: "tag-" + s, StatusID: db.StatusEnabled})
+	So(err, ShouldBeNil)
+	return t
+}
+
+func addTestAuthor(ctx context.Context, statusID int) *db.Author {
+	s := testSuffix()
+	a, err := db.NewNewsRepo(testDb).AddAuthor(ctx, &db.Author{Name: "Author " + s, Alias: "author-" + s, StatusID: statusID})
+	So(err, ShouldBeNil)
+	return a
+}
+
+// addTestNews adds draft News with the Tags and Authors by NewsService.
+func addTestNews(ctx context.Context, srv *NewsService, categoryID int, tagIDs, authorIDs []int) *News {
+	news, err := srv.Add(ctx, News{
+		Title:       "News " + testSuffix(),
+		CategoryID:  categoryID,
+		Foreword:    "Foreword",
+		TagIDs:      tagIDs,
+		AuthorIDs:   authorIDs,
+		Author:      "Author",
+		PublishedAt: time.Now(),
+		StatusID:    db.StatusEnabled,
+	})
+	So(err, ShouldBeNil)
+	So(news, ShouldNotBeNil)
+
+	return news
+}
+
+func TestDB_NewsRevisions(t *testing.T) {
+	Convey("Test News revisions", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv := NewNewsService(testDb, embedlog.Logger{})
+
+		category, tag := addTestCategory(ctx, nil), addTestTag(ctx)
+		first, second := addTestAuthor(ctx, db.StatusEnabled), addTestAuthor(ctx, db.StatusEnabled)
+		news := addTestNews(ctx, srv, category.ID, []int{tag.ID}, []int{first.ID})
+
+		Convey("Revision is a full snapshot of News fields", func() {
+			cur, err := srv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+
+			unpublishAt := cur.PublishedAt.Add(time.Hour)
+			cur.AuthorIDs = []int{second.ID, first.ID}
+			cur.UnpublishAt = &unpublishAt
+			ok, err := srv.Update(ctx, *cur)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			diff, err := srv.RevisionDiff(ctx, news.ID, 1, 2)
+			So(err, ShouldBeNil)
+			So(diff.To.AuthorIDs, ShouldResemble, []int{second.ID, first.ID})
+			So(diff.To.UnpublishAt, ShouldNotBeNil)
+
+			fields := make([]string, 0, len(diff.Fields))
+			for _, f := range diff.Fields {
+				fields = append(fields, f.Field)
+			}
+			So(fields, ShouldContain, "authorIds")
+			So(fields, ShouldContain, "unpublishAt")
+
+			Convey("Restore of revision restores authors and unpublish time", func() {
+				ok, err := srv.RestoreRevision(ctx, news.ID, 1)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				restored, err := srv.GetByID(ctx, news.ID)
+				So(err, ShouldBeNil)
+				So(restored.AuthorIDs, ShouldResemble, []int{first.ID})
+				So(restored.UnpublishAt, ShouldBeNil)
+
+				revisions, err := srv.Revisions(ctx, news.ID)
+				So(err, ShouldBeNil)
+				So(revisions, ShouldHaveLength, 3)
+				So(revisions[0].Revision, ShouldEqual, 3)
+			})
+		})
+	})
+}
+
+// setTestNewsState sets workflow state of News bypassing transitions.
+func setTestNewsState(ctx context.Context, id int, state workflow.State) {
+	_, err := db.NewNewsRepo(testDb).UpdateNews(ctx, &db.News{ID: id, State: string(state)}, db.WithColumns(db.Columns.News.State))
+	So(err, ShouldBeNil)
+}
+
+func TestDB_NewsBulkUpdate(t *testing.T) {
+	Convey("Test News bulk updates", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		authorCtx := testUserContext(context.Background(), workflow.RoleAuthor)
+		srv := NewNewsService(testDb, embedlog.Logger{})
+
+		category, target := addTestCategory(ctx, nil), addTestCategory(ctx, nil)
+		tag, extra := addTestTag(ctx), addTestTag(ctx)
+		draft := addTestNews(ctx, srv, category.ID, []int{tag.ID}, nil)
+		review := addTestNews(ctx, srv, category.ID, []int{tag.ID}, nil)
+		setTestNewsState(ctx, review.ID, workflow.StateReview)
+		const missingID = -1
+
+		Convey("SetStatus enables published News only", func() {
+			results, err := srv.SetStatus(ctx, StatusUpdate{StatusID: db.StatusEnabled, ObjectIDs: []int{draft.ID, missingID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: draft.ID, Result: BulkResultForbidden}, {ID: missingID, Result: BulkResultNotFound}})
+
+			results, err = srv.SetStatus(ctx, StatusUpdate{StatusID: db.StatusDisabled, ObjectIDs: []int{draft.ID, review.ID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: draft.ID, Result: BulkResultUpdated}, {ID: review.ID, Result: BulkResultUpdated}})
+
+			news, err := srv.GetByID(ctx, draft.ID)
+			So(err, ShouldBeNil)
+			So(news.StatusID, ShouldEqual, db.StatusDisabled)
+			So(news.Version, ShouldEqual, draft.Version+1)
+		})
+
+		Convey("SetStatus does not disable published News", func() {
+			setTestNewsState(ctx, review.ID, workflow.StatePublished)
+			results, err := srv.SetStatus(ctx, StatusUpdate{StatusID: db.StatusEnabled, ObjectIDs: []int{review.ID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: review.ID, Result: BulkResultUpdated}})
+
+			results, err = srv.SetStatus(ctx, StatusUpdate{StatusID: db.StatusDisabled, ObjectIDs: []int{review.ID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: review.ID, Result: BulkResultForbidden}})
+
+			news, err := srv.GetByID(ctx, review.ID)
+			So(err, ShouldBeNil)
+			So(news.StatusID, ShouldEqual, db.StatusEnabled)
+		})
+
+		Convey("BulkMove, BulkAddTags and BulkRemoveTags change News", func() {
+			results, err := srv.BulkMove(ctx, NewsCategoryUpdate{CategoryID: target.ID, ObjectIDs: []int{draft.ID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: draft.ID, Result: BulkResultUpdated}})
+
+			results, err = srv.BulkAddTags(ctx, NewsTagsUpdate{TagIDs: []int{extra.ID, tag.ID}, ObjectIDs: []int{draft.ID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: draft.ID, Result: BulkResultUpdated}})
+
+			news, err := srv.GetByID(ctx, draft.ID)
+			So(err, ShouldBeNil)
+			So(news.CategoryID, ShouldEqual, target.ID)
+			So(news.TagIDs, ShouldResemble, []int{tag.ID, extra.ID})
+
+			_, err = srv.BulkRemoveTags(ctx, NewsTagsUpdate{TagIDs: []int{tag.ID}, ObjectIDs: []int{draft.ID}})
+			So(err, ShouldBeNil)
+
+			news, err = srv.GetByID(ctx, draft.ID)
+			So(err, ShouldBeNil)
+			So(news.TagIDs, ShouldResemble, []int{extra.ID})
+
+			revs, err := db.NewNewsRepo(testDb).NewsRevisionsByFilters(ctx, &db.NewsRevisionSearch{NewsID: &draft.ID}, db.PagerOne, db.WithSort(db.NewSortField(db.Columns.NewsRevision.Revision, true)))
+			So(err, ShouldBeNil)
+			So(revs, ShouldHaveLength, 1)
+			So(revs[0].Revision, ShouldBeGreaterThanOrEqualTo, 4)
+			So(revs[0].CategoryID, ShouldEqual, target.ID)
+			So(revs[0].TagIDs, ShouldResemble, []int{extra.ID})
+		})
+
+		Convey("BulkDelete moves News to trash", func() {
+			results, err := srv.BulkDelete(ctx, []int{review.ID, missingID})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: review.ID, Result: BulkResultUpdated}, {ID: missingID, Result: BulkResultNotFound}})
+
+			_, err = srv.GetByID(ctx, review.ID)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("Authors could change draft News only", func() {
+			results, err := srv.BulkMove(authorCtx, NewsCategoryUpdate{CategoryID: target.ID, ObjectIDs: []int{draft.ID, review.ID}})
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []BulkResult{{ID: draft.ID, Result: BulkResultUpdated}, {ID: review.ID, Result: BulkResultForbidden}})
+
+			cur, err := srv.GetByID(ctx, review.ID)
+			So(err, ShouldBeNil)
+			So(cur.CategoryID, ShouldEqual, category.ID)
+
+			cur.Title += " changed"
+			_, err = srv.Update(authorCtx, *cur)
+			So(err, ShouldEqual, ErrForbidden)
+
+			_, err = srv.Delete(authorCtx, review.ID)
+			So(err, ShouldEqual, ErrForbidden)
+
+			own, err := srv.GetByID(ctx, draft.ID)
+			So(err, ShouldBeNil)
+			own.Title += " changed"
+			ok, err := srv.Update(authorCtx, *own)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+	})
+}
+
+func TestDB_NewsTransition(t *testing.T) {
+	Convey("Test News transitions", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv, repo := NewNewsService(testDb, embedlog.Logger{}), db.NewNewsRepo(testDb)
+
+		news := addTestNews(ctx, srv, addTestCategory(ctx, nil).ID, nil, nil)
+		stale, err := repo.NewsByID(ctx, news.ID)
+		So(err, ShouldBeNil)
+
+		ok, err := srv.Transition(ctx, news.ID, string(workflow.StateReview), nil)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+
+		cur, err := srv.GetByID(ctx, news.ID)
+		So(err, ShouldBeNil)
+		So(cur.State, ShouldEqual, string(workflow.StateReview))
+		So(cur.Version, ShouldEqual, news.Version+1)
+
+		Convey("Concurrent transition from outdated state is not saved", func() {
+			stale.State = string(workflow.StateApproved)
+			ok, err := repo.UpdateNewsState(ctx, stale, string(workflow.StateDraft))
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+			So(stale.Version, ShouldEqual, news.Version)
+
+			cur, err := srv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+			So(cur.State, ShouldEqual, string(workflow.StateReview))
+		})
+	})
+}
+
+func TestDB_CategoryDelete(t *testing.T) {
+	Convey("Test Category delete strategies", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv, newsSrv := NewCategoryService(testDb, embedlog.Logger{}), NewNewsService(testDb, embedlog.Logger{})
+		strategy := func(s string) *string { return &s }
+
+		category, target := addTestCategory(ctx, nil), addTestCategory(ctx, nil)
+		news := addTestNews(ctx, newsSrv, category.ID, nil, nil)
+
+		Convey("Category with News is not deleted by restrict strategy", func() {
+			for _, s := range []*string{nil, strategy(DeleteStrategyRestrict)} {
+				ok, err := srv.Delete(ctx, category.ID, s, &target.ID)
+				So(err, ShouldResemble, ValidationError([]FieldError{{Field: "id", Error: FieldErrorInUse}}))
+				So(ok, ShouldBeFalse)
+			}
+		})
+
+		Convey("Category with subcategories is not deleted", func() {
+			addTestCategory(ctx, &target.ID)
+			ok, err := srv.Delete(ctx, target.ID, nil, nil)
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "id", Error: FieldErrorInUse}}))
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Reassign strategy requires existing target Category", func() {
+			_, err := srv.Delete(ctx, category.ID, strategy("cascade"), nil)
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "strategy", Error: FieldErrorIncorrect}}))
+
+			_, err = srv.Delete(ctx, category.ID, strategy(DeleteStrategyReassign), nil)
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "reassignTo", Error: FieldErrorRequired}}))
+
+			_, err = srv.Delete(ctx, category.ID, strategy(DeleteStrategyReassign), &category.ID)
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "reassignTo", Error: FieldErrorIncorrect}}))
+
+			deleted := addTestCategory(ctx, nil)
+			ok, err := srv.Delete(ctx, deleted.ID, nil, nil)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			_, err = srv.Delete(ctx, category.ID, strategy(DeleteStrategyReassign), &deleted.ID)
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "reassignTo", Error: FieldErrorIncorrect}}))
+
+			cur, err := newsSrv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+			So(cur.CategoryID, ShouldEqual, category.ID)
+		})
+
+		Convey("Reassign strategy moves News and deletes Category", func() {
+			ok, err := srv.Delete(ctx, category.ID, strategy(DeleteStrategyReassign), &target.ID)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			cur, err := newsSrv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+			So(cur.CategoryID, ShouldEqual, target.ID)
+
+			_, err = srv.GetByID(ctx, category.ID)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+	})
+}
+
+func TestDB_CategoryPurge(t *testing.T) {
+	Convey("Test purge of Category with deleted subcategory", t, func() {
+		ctx, repo := context.Background(), db.NewNewsRepo(testDb)
+		parent := addTestCategory(ctx, nil)
+		child := addTestCategory(ctx, &parent.ID)
+
+		for _, c := range []*db.Category{parent, child} {
+			c.StatusID = db.StatusDeleted
+			_, err := repo.UpdateCategory(ctx, c, db.WithColumns(db.Columns.Category.StatusID))
+			So(err, ShouldBeNil)
+		}
+
+		ok, err := repo.PurgeCategory(ctx, parent.ID)
+		So(err, ShouldEqual, db.ErrEntityInUse)
+		So(ok, ShouldBeFalse)
+
+		ok, err = repo.PurgeCategory(ctx, child.ID)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+
+		ok, err = repo.PurgeCategory(ctx, parent.ID)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+	})
+}
+
+func TestDB_TagDelete(t *testing.T) {
+	Convey("Test Tag delete strategies", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv, newsSrv := NewTagService(testDb, embedlog.Logger{}), NewNewsService(testDb, embedlog.Logger{})
+		strategy := func(s string) *string { return &s }
+
+		category := addTestCategory(ctx, nil)
+		tag, other := addTestTag(ctx), addTestTag(ctx)
+		news := addTestNews(ctx, newsSrv, category.ID, []int{tag.ID, other.ID}, nil)
+
+		Convey("Tag used by News is not deleted by restrict strategy", func() {
+			for _, s := range []*string{nil, strategy(DeleteStrategyRestrict)} {
+				ok, err := srv.Delete(ctx, tag.ID, s)
+				So(err, ShouldResemble, ValidationError([]FieldError{{Field: "id", Error: FieldErrorInUse}}))
+				So(ok, ShouldBeFalse)
+			}
+
+			_, err := srv.Delete(ctx, tag.ID, strategy(DeleteStrategyReassign))
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "strategy", Error: FieldErrorIncorrect}}))
+		})
+
+		Convey("Strip strategy removes Tag from News and deletes it", func() {
+			ok, err := srv.Delete(ctx, tag.ID, strategy(DeleteStrategyStrip))
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			cur, err := newsSrv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+			So(cur.TagIDs, ShouldResemble, []int{other.ID})
+
+			_, err = srv.GetByID(ctx, tag.ID)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("Unused Tag is deleted by restrict strategy", func() {
+			unused := addTestTag(ctx)
+			ok, err := srv.Delete(ctx, unused.ID, nil)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+	})
+}
+
+// applyTestPatch applies SQL patch from docs/patches in the transaction.
+// Tables created by setup in the new schema shadow public tables, so the patch could be applied to them repeatedly.
+func applyTestPatch(tx *pg.Tx, name, setup string) {
+	patch, err := os.ReadFile(filepath.Join("..", "..", "docs", "patches", name))
+	So(err, ShouldBeNil)
+
+	schema := "patch_" + testSuffix()
+	_, err = tx.Exec(`CREATE SCHEMA "` + schema + `"; SET LOCAL search_path TO "` + schema + `", public; ` + setup)
+	So(err, ShouldBeNil)
+
+	// patches are run in their own transactions
+	body := strings.NewReplacer("BEGIN;", "", "COMMIT;", "").Replace(string(patch))
+	_, err = tx.Exec(body)
+	So(err, ShouldBeNil)
+}
+
+func TestDB_AuthorService(t *testing.T) {
+	Convey("Test Author service", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv, newsSrv := NewAuthorService(testDb, embedlog.Logger{}), NewNewsService(testDb, embedlog.Logger{})
+		s := testSuffix()
+
+		author, err := srv.Add(ctx, Author{Name: "Author " + s, Alias: "author-" + s, StatusID: db.StatusEnabled})
+		So(err, ShouldBeNil)
+		So(author.ID, ShouldBeGreaterThan, 0)
+		So(author.Version, ShouldEqual, 1)
+
+		Convey("Alias is unique", func() {
+			_, err := srv.Add(ctx, Author{Name: "Other " + s, Alias: author.Alias, StatusID: db.StatusEnabled})
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "alias", Error: FieldErrorUnique}}))
+		})
+
+		Convey("Update checks version", func() {
+			author.Name += " changed"
+			ok, err := srv.Update(ctx, *author)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			cur, err := srv.GetByID(ctx, author.ID)
+			So(err, ShouldBeNil)
+			So(cur.Name, ShouldEqual, author.Name)
+			So(cur.Version, ShouldEqual, 2)
+
+			_, err = srv.Update(ctx, *author)
+			So(err, ShouldResemble, ConflictError(2))
+		})
+
+		Convey("Deleted Author keeps links to News", func() {
+			news := addTestNews(ctx, newsSrv, addTestCategory(ctx, nil).ID, nil, []int{author.ID})
+
+			deps, err := srv.Dependencies(ctx, author.ID)
+			So(err, ShouldBeNil)
+			So(deps, ShouldResemble, &Dependencies{News: 1})
+
+			ok, err := srv.Delete(ctx, author.ID)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			_, err = srv.GetByID(ctx, author.ID)
+			So(err, ShouldEqual, ErrNotFound)
+
+			count, err := db.NewNewsRepo(testDb).CountNewsByAuthor(ctx, author.ID)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+
+			cur, err := newsSrv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+			So(cur.AuthorIDs, ShouldResemble, []int{author.ID})
+		})
+	})
+}
+
+func TestDB_SetNewsAuthors(t *testing.T) {
+	Convey("Test News authors", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		repo, newsSrv := db.NewNewsRepo(testDb), NewNewsService(testDb, embedlog.Logger{})
+
+		first, second := addTestAuthor(ctx, db.StatusEnabled), addTestAuthor(ctx, db.StatusEnabled)
+		news := addTestNews(ctx, newsSrv, addTestCategory(ctx, nil).ID, nil, []int{first.ID})
+
+		Convey("Authors are replaced in the given order", func() {
+			So(repo.SetNewsAuthors(ctx, news.ID, []int{second.ID, first.ID, second.ID}), ShouldBeNil)
+
+			links, err := repo.NewsAuthorsByNewsIDs(ctx, []int{news.ID})
+			So(err, ShouldBeNil)
+			So(links, ShouldResemble, []db.NewsAuthor{
+				{NewsID: news.ID, AuthorID: second.ID, Position: 1},
+				{NewsID: news.ID, AuthorID: first.ID, Position: 2},
+			})
+
+			cur, err := newsSrv.GetByID(ctx, news.ID)
+			So(err, ShouldBeNil)
+			So(cur.AuthorIDs, ShouldResemble, []int{second.ID, first.ID})
+		})
+
+		Convey("Empty list removes Authors", func() {
+			So(repo.SetNewsAuthors(ctx, news.ID, nil), ShouldBeNil)
+
+			count, err := repo.CountNewsByAuthor(ctx, first.ID)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestDB_AuthorsPatch(t *testing.T) {
+	Convey("Test authors patch", t, func() {
+		tx, err := testDb.Begin()
+		So(err, ShouldBeNil)
+		defer func() { _ = tx.Rollback() }()
+
+		applyTestPatch(tx, "002-authors.sql", `
+			CREATE TABLE "news" ("newsId" int4 PRIMARY KEY, "author" varchar(64) NOT NULL);
+			INSERT INTO "news" VALUES (1, 'Ivan Petrov'), (2, ' ivan petrov '), (3, 'Ivan Petrov'), (4, 'Ivan-Petrov'), (5, 'Иван'), (6, ' ');`)
+
+		var authors []struct {
+			ID    int `pg:"authorId"`
+			Name  string
+			Alias string
+		}
+		_, err = tx.Query(&authors, `SELECT "authorId", "name", "alias" FROM "authors" ORDER BY "name", "authorId"`)
+		So(err, ShouldBeNil)
+		So(authors, ShouldHaveLength, 3)
+
+		byName := make(map[string]int)
+		for _, a := range authors {
+			byName[a.Name] = a.ID
+		}
+
+		Convey("Names differing in case and spaces are one Author with the most frequent spelling", func() {
+			So(byName, ShouldContainKey, "Ivan Petrov")
+			So(byName, ShouldContainKey, "Ivan-Petrov")
+			So(byName, ShouldContainKey, "Иван")
+		})
+
+		Convey("Non-latin and duplicate aliases get id suffix", func() {
+			aliases := make(map[string]string)
+			for _, a := range authors {
+				aliases[a.Name] = a.Alias
+			}
+			So(aliases["Иван"], ShouldEqual, "author-"+strconv.Itoa(byName["Иван"]))
+
+			// the first Author keeps the slug
+			first, second := "Ivan Petrov", "Ivan-Petrov"
+			if byName[first] > byName[second] {
+				first, second = second, first
+			}
+			So(aliases[first], ShouldEqual, "ivan-petrov")
+			So(aliases[second], ShouldEqual, "ivan-petrov-author-"+strconv.Itoa(byName[second]))
+		})
+
+		Convey("News are linked to deduplicated Authors", func() {
+			var links []db.NewsAuthor
+			_, err = tx.Query(&links, `SELECT "newsId", "authorId", "position" FROM "newsAuthors" ORDER BY "newsId"`)
+			So(err, ShouldBeNil)
+			So(links, ShouldResemble, []db.NewsAuthor{
+				{NewsID: 1, AuthorID: byName["Ivan Petrov"], Position: 1},
+				{NewsID: 2, AuthorID: byName["Ivan Petrov"], Position: 1},
+				{NewsID: 3, AuthorID: byName["Ivan Petrov"], Position: 1},
+				{NewsID: 4, AuthorID: byName["Ivan-Petrov"], Position: 1},
+				{NewsID: 5, AuthorID: byName["Иван"], Position: 1},
+			})
+		})
+	})
+}
+
+func TestDB_CategoryMove(t *testing.T) {
+	Convey("Test Category tree moves", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv, repo := NewCategoryService(testDb, embedlog.Logger{}), db.NewNewsRepo(testDb)
+		cycleErr := ValidationError([]FieldError{{Field: "parentCategoryId", Error: FieldErrorCycle}})
+
+		root, other := addTestCategory(ctx, nil), addTestCategory(ctx, nil)
+		a, b, c := addTestCategory(ctx, &root.ID), addTestCategory(ctx, &root.ID), addTestCategory(ctx, &root.ID)
+		d := addTestCategory(ctx, &other.ID)
+		grandchild := addTestCategory(ctx, &a.ID)
+
+		// children returns ids of children in tree order and checks that orderNumber is renumbered from 1
+		children := func(parentID int) []int {
+			list, err := repo.CategoriesByFilters(ctx, &db.CategorySearch{ParentCategoryID: &parentID}, db.PagerNoLimit, categoryTreeSort)
+			So(err, ShouldBeNil)
+
+			ids := make([]int, 0, len(list))
+			for i, cat := range list {
+				So(cat.OrderNumber, ShouldNotBeNil)
+				So(*cat.OrderNumber, ShouldEqual, i+1)
+				ids = append(ids, cat.ID)
+			}
+			return ids
+		}
+
+		Convey("Category could not be moved under itself or its descendants", func() {
+			for _, parentID := range []int{root.ID, a.ID, grandchild.ID} {
+				ok, err := srv.Move(ctx, CategoryMove{ID: root.ID, ParentCategoryID: &parentID})
+				So(err, ShouldResemble, cycleErr)
+				So(ok, ShouldBeFalse)
+			}
+
+			cur, err := srv.GetByID(ctx, root.ID)
+			So(err, ShouldBeNil)
+			cur.ParentCategoryID = &grandchild.ID
+			_, err = srv.Update(ctx, *cur)
+			So(err, ShouldResemble, cycleErr)
+		})
+
+		Convey("Move within the same parent renumbers siblings", func() {
+			for i, id := range []int{a.ID, b.ID, c.ID} {
+				_, err := srv.Move(ctx, CategoryMove{ID: id, ParentCategoryID: &root.ID, Position: i})
+				So(err, ShouldBeNil)
+			}
+			So(children(root.ID), ShouldResemble, []int{a.ID, b.ID, c.ID})
+
+			ok, err := srv.Move(ctx, CategoryMove{ID: c.ID, ParentCategoryID: &root.ID, Position: 0})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(children(root.ID), ShouldResemble, []int{c.ID, a.ID, b.ID})
+
+			ok, err = srv.Move(ctx, CategoryMove{ID: c.ID, ParentCategoryID: &root.ID, Position: 10})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(children(root.ID), ShouldResemble, []int{a.ID, b.ID, c.ID})
+		})
+
+		Convey("Move to other parent renumbers old and new siblings", func() {
+			_, err := srv.Move(ctx, CategoryMove{ID: a.ID, ParentCategoryID: &root.ID, Position: 0})
+			So(err, ShouldBeNil)
+
+			ok, err := srv.Move(ctx, CategoryMove{ID: a.ID, ParentCategoryID: &other.ID, Position: 1})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			So(children(other.ID), ShouldResemble, []int{d.ID, a.ID})
+			So(children(root.ID), ShouldHaveLength, 2)
+			So(children(root.ID), ShouldNotContain, a.ID)
+
+			cur, err := srv.GetByID(ctx, a.ID)
+			So(err, ShouldBeNil)
+			So(cur.ParentCategoryID, ShouldResemble, &other.ID)
+		})
+	})
+}
+
+func TestDB_TagMerge(t *testing.T) {
+	Convey("Test Tags merge", t, func() {
+		ctx := testUserContext(context.Background(), workflow.RoleAdmin)
+		srv, newsSrv, repo := NewTagService(testDb, embedlog.Logger{}), NewNewsService(testDb, embedlog.Logger{}), db.NewNewsRepo(testDb)
+
+		category := addTestCategory(ctx, nil)
+		first, second, target := addTestTag(ctx), addTestTag(ctx), addTestTag(ctx)
+		withBoth := addTestNews(ctx, newsSrv, category.ID, []int{first.ID, second.ID}, nil)
+		withTarget := addTestNews(ctx, newsSrv, category.ID, []int{target.ID, first.ID}, nil)
+		withSecond := addTestNews(ctx, newsSrv, category.ID, []int{second.ID}, nil)
+
+		Convey("Target and sources must exist and differ", func() {
+			_, err := srv.Merge(ctx, TagMerge{SourceIDs: []int{first.ID, target.ID}, TargetID: target.ID})
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "sourceIds", Error: FieldErrorIncorrect}}))
+
+			_, err = srv.Merge(ctx, TagMerge{SourceIDs: []int{first.ID, -1}, TargetID: target.ID})
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "sourceIds", Error: FieldErrorIncorrect}}))
+
+			_, err = srv.Merge(ctx, TagMerge{SourceIDs: []int{first.ID}, TargetID: -1})
+			So(err, ShouldResemble, ValidationError([]FieldError{{Field: "targetId", Error: FieldErrorIncorrect}}))
+		})
+
+		Convey("News get the target Tag and revisions", func() {
+			count, err := srv.Merge(ctx, TagMerge{SourceIDs: []int{first.ID, second.ID}, TargetID: target.ID})
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 3)
+
+			for _, n := range []*News{withBoth, withTarget, withSecond} {
+				cur, err := newsSrv.GetByID(ctx, n.ID)
+				So(err, ShouldBeNil)
+				So(cur.TagIDs, ShouldResemble, []int{target.ID})
+				So(cur.Version, ShouldEqual, n.Version+1)
+
+				rev, err := repo.NewsRevisionByNumber(ctx, n.ID, 2)
+				So(err, ShouldBeNil)
+				So(rev, ShouldNotBeNil)
+				So(rev.TagIDs, ShouldResemble, []int{target.ID})
+			}
+
+			_, err = srv.GetByID(ctx, first.ID)
+			So(err, ShouldEqual, ErrNotFound)
+
+			ids, err := repo.ResolveTagIDs(ctx, []int{first.ID, second.ID, target.ID, -1})
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []int{target.ID, target.ID, target.ID, -1})
+
+			Convey("Merged Tags could not be merged again", func() {
+				_, err := srv.Merge(ctx, TagMerge{SourceIDs: []int{first.ID}, TargetID: target.ID})
+				So(err, ShouldResemble, ValidationError([]FieldError{{Field: "sourceIds", Error: FieldErrorIncorrect}}))
+			})
+
+			Convey("Merge chains are resolved in one step", func() {
+				next := addTestTag(ctx)
+				count, err := srv.Merge(ctx, TagMerge{SourceIDs: []int{target.ID}, TargetID: next.ID})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+
+				ids, err := repo.ResolveTagIDs(ctx, []int{first.ID, second.ID, target.ID})
+				So(err, ShouldBeNil)
+				So(ids, ShouldResemble, []int{next.ID, next.ID, next.ID})
+			})
+		})
+	})
+}
+
+func TestDB_TagMergesPatch(t *testing.T) {
+	Convey("Test tag merges patch", t, func() {
+		tx, err := testDb.Begin()
+		So(err, ShouldBeNil)
+		defer func() { _ = tx.Rollback() }()
+
+		applyTestPatch(tx, "004-tagMerges.sql", `
+			CREATE TABLE "tags" ("tagId" int4 PRIMARY KEY, "title" varchar(128) NOT NULL, "statusId" int4 NOT NULL);
+			INSERT INTO "tags" VALUES (1, 'Go Lang', 1), (2, 'go-lang', 1), (3, 'Новости', 1), (4, 'C++', 3);`)
+
+		var aliases []string
+		_, err = tx.Query(&aliases, `SELECT "alias" FROM "tags" ORDER BY "tagId"`)
+		So(err, ShouldBeNil)
+		So(aliases, ShouldResemble, []string{"go-lang", "go-lang-tag-2", "tag-3", "c"})
+	})
+}
@@ -1,10 +1,14 @@
 package vt
 
 import (
+	"log/slog"
 	"net/http"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mail"
+	"apisrv/pkg/timeout"
+	"apisrv/pkg/tracing"
 
 	zm "github.com/vmkteam/zenrpc-middleware"
 	"github.com/vmkteam/zenrpc/v2"
@@ -13,15 +17,21 @@ import (
 //go:generate zenrpc
 
 const (
-	AuthKey = "Authorization2"
+	AuthKey     = "Authorization2"
+	APITokenKey = "X-Api-Token"
 )
 
 const (
 	NSAuth     = "auth"
 	NSUser     = "user"
 	NSCategory = "category"
+	NSAuthor   = "author"
 	NSNews     = "news"
 	NSTag      = "tag"
+	NSAPIToken = "apiToken"
+	NSAudit    = "audit"
+	NSVFS      = "vfs"
+	NSTrash    = "trash"
 )
 
 var (
@@ -43,7 +53,7 @@ func httpAsRpcError(code int) *zenrpc.Error {
 }
 
 // New returns new zenrpc Server.
-func New(dbo db.DB, logger embedlog.Logger, isDevel bool) zenrpc.Server {
+func New(dbo db.DB, logger embedlog.Logger, isDevel bool, authCfg AuthConfig, mailer *mail.Mailer, timeouts timeout.Config) zenrpc.Server {
 	rpc := zenrpc.NewServer(zenrpc.Options{
 		ExposeSMD: true,
 		AllowCORS: true,
@@ -51,32 +61,58 @@ func New(dbo db.DB, logger embedlog.Logger, isDevel bool) zenrpc.Server {
 
 	commonRepo := db.NewCommonRepo(dbo)
 
+	// services
+	userService := NewUserService(dbo, logger, authCfg, mailer)
+	categoryService := NewCategoryService(dbo, logger)
+	authorService := NewAuthorService(dbo, logger)
+	newsService := NewNewsService(dbo, logger)
+	tagService := NewTagService(dbo, logger)
+	apiTokenService := NewAPITokenService(dbo, logger)
+
+	audit := auditLogger{
+		Logger:     logger,
+		commonRepo: commonRepo,
+		snapshots: map[string]auditSnapshotFunc{
+			NSUser:     newAuditSnapshot(userService.GetByID),
+			NSCategory: newAuditSnapshot(categoryService.GetByID),
+			NSAuthor:   newAuditSnapshot(authorService.GetByID),
+			NSNews:     newAuditSnapshot(newsService.GetByID),
+			NSTag:      newAuditSnapshot(tagService.GetByID),
+			NSAPIToken: newAuditSnapshot(apiTokenService.GetByID),
+		},
+	}
+
 	// middleware
 	rpc.Use(
+		embedlog.RPCMiddleware(),
 		authMiddleware(&commonRepo, logger),
 		zm.WithDevel(isDevel),
 		zm.WithHeaders(),
 		zm.WithSentry(zm.DefaultServerName),
-		zm.WithNoCancelContext(),
+		timeout.Middleware(timeouts, "vt"),
+		audit.middleware(),
 		zm.WithMetrics("vt"),
+		tracing.RPCMiddleware(),
 		zm.WithTiming(isDevel, allowDebugFn()),
 		zm.WithSQLLogger(dbo.DB, isDevel, allowDebugFn(), allowDebugFn()),
 	)
 
-	if errlog, stdlog := logger.Loggers(); errlog != nil && stdlog != nil {
-		rpc.Use(
-			zm.WithAPILogger(stdlog.Printf, zm.DefaultServerName),
-			zm.WithErrorLogger(errlog.Printf, zm.DefaultServerName),
-		)
-	}
+	// requests are logged with debug level, so they are written only in verbose mode
+	rpc.Use(
+		zm.WithAPILogger(logger.PrintfFunc(slog.LevelDebug), zm.DefaultServerName),
+		zm.WithErrorLogger(logger.PrintfFunc(slog.LevelError), zm.DefaultServerName),
+	)
 
-	// services
 	rpc.RegisterAll(map[string]zenrpc.Invoker{
-		NSAuth:     NewAuthService(dbo, logger),
-		NSUser:     NewUserService(dbo, logger),
-		NSCategory: NewCategoryService(dbo, logger),
-		NSNews:     NewNewsService(dbo, logger),
-		NSTag:      NewTagService(dbo, logger),
+		NSAuth:     NewAuthService(dbo, logger, authCfg, mailer),
+		NSUser:     userService,
+		NSCategory: categoryService,
+		NSAuthor:   authorService,
+		NSNews:     newsService,
+		NSTag:      tagService,
+		NSAPIToken: apiTokenService,
+		NSAudit:    NewAuditService(dbo, logger),
+		NSTrash:    NewTrashService(dbo, logger),
 	})
 
 	return rpc
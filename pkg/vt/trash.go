@@ -0,0 +1,283 @@
+package vt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/embedlog"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+// ErrEntityInUse is returned when deleted entity could not be purged because other entities reference it.
+var ErrEntityInUse = zenrpc.NewStringError(http.StatusConflict, "Entity is in use")
+
+type TrashConfig struct {
+	Retention time.Duration // deleted entities lifetime, zero keeps entities forever
+}
+
+// trashHandler restores or purges deleted entity of one type within transaction.
+type trashHandler struct {
+	restore func(ctx context.Context, tx *pg.Tx, id, statusID int) (bool, error)
+	purge   func(ctx context.Context, tx *pg.Tx, id int) (bool, error)
+}
+
+// trashHandlers are handlers by entity type, entity type is VT namespace of entity.
+var trashHandlers = map[string]trashHandler{
+	NSNews: {
+		restore: func(ctx context.Context, tx *pg.Tx, id, statusID int) (bool, error) {
+			return db.NewNewsRepo(tx).RestoreNews(ctx, id, statusID)
+		},
+		purge: func(ctx context.Context, tx *pg.Tx, id int) (bool, error) {
+			return db.NewNewsRepo(tx).PurgeNews(ctx, id)
+		},
+	},
+	NSCategory: {
+		restore: func(ctx context.Context, tx *pg.Tx, id, statusID int) (bool, error) {
+			return db.NewNewsRepo(tx).RestoreCategory(ctx, id, statusID)
+		},
+		purge: func(ctx context.Context, tx *pg.Tx, id int) (bool, error) {
+			return db.NewNewsRepo(tx).PurgeCategory(ctx, id)
+		},
+	},
+	NSAuthor: {
+		restore: func(ctx context.Context, tx *pg.Tx, id, statusID int) (bool, error) {
+			return db.NewNewsRepo(tx).RestoreAuthor(ctx, id, statusID)
+		},
+		purge: func(ctx context.Context, tx *pg.Tx, id int) (bool, error) {
+			return db.NewNewsRepo(tx).PurgeAuthor(ctx, id)
+		},
+	},
+	NSTag: {
+		restore: func(ctx context.Context, tx *pg.Tx, id, statusID int) (bool, error) {
+			return db.NewNewsRepo(tx).RestoreTag(ctx, id, statusID)
+		},
+		purge: func(ctx context.Context, tx *pg.Tx, id int) (bool, error) {
+			return db.NewNewsRepo(tx).PurgeTag(ctx, id)
+		},
+	},
+	NSUser: {
+		restore: func(ctx context.Context, tx *pg.Tx, id, statusID int) (bool, error) {
+			return db.NewCommonRepo(tx).RestoreUser(ctx, id, statusID)
+		},
+		purge: func(ctx context.Context, tx *pg.Tx, id int) (bool, error) {
+			return db.NewCommonRepo(tx).PurgeUser(ctx, id)
+		},
+	},
+}
+
+// addTrashItem records deleted entity with current user or api token, it must be called in the same transaction as delete.
+func addTrashItem(ctx context.Context, tx *pg.Tx, entityType string, id int, title string, previousStatusID int) error {
+	item := &db.TrashItem{
+		EntityType:       entityType,
+		EntityID:         id,
+		Title:            title,
+		PreviousStatusID: previousStatusID,
+		UserID:           currentUserID(ctx),
+	}
+	if token := APITokenFromContext(ctx); token != nil {
+		item.APITokenID = &token.ID
+	}
+
+	_, err := db.NewCommonRepo(tx).AddTrashItem(ctx, item)
+	return err
+}
+
+// purgeTrashItem removes deleted entity and all its trash records within transaction.
+// Trash records are removed also when entity was already restored or removed in other way.
+func purgeTrashItem(ctx context.Context, tx *pg.Tx, item *db.TrashItem) error {
+	if h, ok := trashHandlers[item.EntityType]; ok {
+		if _, err := h.purge(ctx, tx, item.EntityID); err != nil {
+			return err
+		}
+	}
+
+	return db.NewCommonRepo(tx).DeleteTrashItemsByEntity(ctx, item.EntityType, item.EntityID)
+}
+
+// PurgeTrash permanently removes entities deleted before retention period.
+// Entities that are still referenced by other entities are skipped and counted as inUse,
+// other failed entities are logged and skipped, so one broken item does not stop the purge.
+func PurgeTrash(ctx context.Context, dbo db.DB, logger embedlog.Logger, cfg TrashConfig) (purged, inUse int, err error) {
+	if cfg.Retention <= 0 {
+		return 0, 0, nil
+	}
+
+	deletedAt := time.Now().Add(-cfg.Retention)
+	list, err := db.NewCommonRepo(dbo).TrashItemsByFilters(ctx, &db.TrashItemSearch{DeletedAtTo: &deletedAt}, db.PagerNoLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := range list {
+		err = dbo.RunInTransaction(ctx, func(tx *pg.Tx) error {
+			return purgeTrashItem(ctx, tx, &list[i])
+		})
+
+		switch {
+		case errors.Is(err, db.ErrEntityInUse):
+			inUse++
+		case err != nil:
+			if ctx.Err() != nil {
+				return purged, inUse, err
+			}
+			logger.Error(ctx, "purge trash item", "entityType", list[i].EntityType, "entityId", list[i].EntityID, "err", err)
+		default:
+			purged++
+		}
+	}
+
+	return purged, inUse, nil
+}
+
+type TrashService struct {
+	zenrpc.Service
+	embedlog.Logger
+	db         db.DB
+	commonRepo db.CommonRepo
+}
+
+func NewTrashService(dbo db.DB, logger embedlog.Logger) *TrashService {
+	return &TrashService{
+		Logger:     logger,
+		db:         dbo,
+		commonRepo: db.NewCommonRepo(dbo),
+	}
+}
+
+func (s TrashService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.commonRepo.DefaultTrashItemSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.TrashItem.ID, db.Columns.TrashItem.DeletedAt, db.Columns.TrashItem.EntityType, db.Columns.TrashItem.Title:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count TrashItems according to conditions in search params
+//
+//zenrpc:search TrashItemSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s TrashService) Count(ctx context.Context, search *TrashItemSearch) (int, error) {
+	count, err := s.commonRepo.CountTrashItems(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get а list of deleted entities according to conditions in search params
+//
+//zenrpc:search TrashItemSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []TrashItem
+//zenrpc:500 Internal Error
+func (s TrashService) Get(ctx context.Context, search *TrashItemSearch, viewOps *ViewOps) ([]TrashItem, error) {
+	list, err := s.commonRepo.TrashItemsByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.commonRepo.FullTrashItem())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	items := make([]TrashItem, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if item := NewTrashItem(&list[i]); item != nil {
+			items = append(items, *item)
+		}
+	}
+	return items, nil
+}
+
+// GetByID returns a TrashItem by its ID.
+//
+//zenrpc:id int
+//zenrpc:return TrashItem
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s TrashService) GetByID(ctx context.Context, id int) (*TrashItem, error) {
+	db, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewTrashItem(db), nil
+}
+
+func (s TrashService) byID(ctx context.Context, id int) (*db.TrashItem, error) {
+	db, err := s.commonRepo.TrashItemByID(ctx, id, s.commonRepo.FullTrashItem())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return db, nil
+}
+
+// Restore sets previous status of deleted entity and removes it from trash.
+// It returns false if entity was already restored or removed in other way.
+//
+//zenrpc:id int
+//zenrpc:return isRestored
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s TrashService) Restore(ctx context.Context, id int) (bool, error) {
+	item, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	h, ok := trashHandlers[item.EntityType]
+	if !ok {
+		return false, ErrNotFound
+	}
+
+	var restored bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if restored, err = h.restore(ctx, tx, item.EntityID, item.PreviousStatusID); err != nil {
+			return err
+		}
+		return db.NewCommonRepo(tx).DeleteTrashItemsByEntity(ctx, item.EntityType, item.EntityID)
+	})
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return restored, nil
+}
+
+// Purge permanently removes deleted entity. Entity could not be purged while other entities reference it.
+//
+//zenrpc:id int
+//zenrpc:return isPurged
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+//zenrpc:409 Entity Is In Use
+func (s TrashService) Purge(ctx context.Context, id int) (bool, error) {
+	item, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return purgeTrashItem(ctx, tx, item)
+	})
+	if errors.Is(err, db.ErrEntityInUse) {
+		return false, ErrEntityInUse
+	} else if err != nil {
+		return false, InternalError(err)
+	}
+	return true, nil
+}
+
+// EntityTypes returns list of entity types that could be in trash.
+//
+//zenrpc:return []string
+func (s TrashService) EntityTypes() []string {
+	return []string{NSNews, NSCategory, NSAuthor, NSTag, NSUser}
+}
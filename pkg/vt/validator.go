@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 
+	"apisrv/pkg/passwd"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/vmkteam/zenrpc/v2"
 )
@@ -16,11 +18,15 @@ import (
 const (
 	FieldErrorRequired  = "required"
 	FieldErrorMax       = "max"
+	FieldErrorMaxBytes  = "maxBytes"
 	FieldErrorMin       = "min"
 	FieldErrorIncorrect = "incorrect"
 	FieldErrorUnique    = "unique"
 	FieldErrorFormat    = "format"
 	FieldErrorLen       = "len"
+	FieldErrorBanned    = "banned"
+	FieldErrorInUse     = "inUse"
+	FieldErrorCycle     = "cycle"
 )
 
 const (
@@ -36,6 +42,7 @@ var errorMap = map[string]string{
 	"required":      FieldErrorRequired,
 	"gt":            FieldErrorRequired,
 	"len":           FieldErrorLen,
+	"email":         FieldErrorFormat,
 	CustomStatusTag: FieldErrorIncorrect,
 	CustomAliasTag:  FieldErrorFormat,
 }
@@ -176,6 +183,22 @@ func (v *Validator) CheckBasic(ctx context.Context, item interface{}) {
 	}
 }
 
+// CheckPassword appends password policy violations as field errors.
+func (v *Validator) CheckPassword(field, password, login string, policy passwd.Policy) {
+	for _, violation := range policy.Check(password, login) {
+		switch violation.Code {
+		case passwd.ViolationMinLength:
+			v.Append(field, FieldErrorMin, func(c *FieldErrorConstraint) { c.Min = violation.Limit })
+		case passwd.ViolationMaxLength:
+			v.Append(field, FieldErrorMax, func(c *FieldErrorConstraint) { c.Max = violation.Limit })
+		case passwd.ViolationMaxBytes:
+			v.Append(field, FieldErrorMaxBytes, func(c *FieldErrorConstraint) { c.Max = violation.Limit })
+		case passwd.ViolationBanned:
+			v.Append(field, FieldErrorBanned)
+		}
+	}
+}
+
 func InternalError(err error) *zenrpc.Error {
 	return zenrpc.NewError(http.StatusInternalServerError, err)
 }
@@ -183,3 +206,13 @@ func InternalError(err error) *zenrpc.Error {
 func ValidationError(fieldErrors []FieldError) *zenrpc.Error {
 	return &zenrpc.Error{Code: http.StatusBadRequest, Data: fieldErrors, Message: "Validation err"}
 }
+
+// VersionConflict is a data of conflict error, it contains current version of entity on server.
+type VersionConflict struct {
+	CurrentVersion int `json:"currentVersion"`
+}
+
+// ConflictError returns error for update of outdated entity version.
+func ConflictError(currentVersion int) *zenrpc.Error {
+	return &zenrpc.Error{Code: http.StatusConflict, Data: VersionConflict{CurrentVersion: currentVersion}, Message: "Version conflict"}
+}
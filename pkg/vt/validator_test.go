@@ -2,8 +2,12 @@ package vt
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
 
+	"apisrv/pkg/passwd"
+
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -177,3 +181,41 @@ func TestValidator_CheckBasic(t *testing.T) {
 		})
 	})
 }
+
+func TestValidator_CheckPassword(t *testing.T) {
+	Convey("Test password policy", t, func() {
+		var v Validator
+		policy := passwd.Policy{MinLength: 8, Banned: []string{"newsportal"}}
+
+		Convey("Good password", func() {
+			v.CheckPassword("password", "c0rrect-h0rse", "editor", policy)
+			So(v.HasErrors(), ShouldBeFalse)
+		})
+
+		Convey("Short and banned password", func() {
+			v.CheckPassword("password", "qwerty", "editor", policy)
+			So(v.Fields(), ShouldResemble, []FieldError{
+				{Field: "password", Error: FieldErrorMin, Constraint: &FieldErrorConstraint{Min: 8}},
+				{Field: "password", Error: FieldErrorBanned},
+			})
+		})
+
+		Convey("Password equal to login", func() {
+			v.CheckPassword("password", "NewsEditor", "newseditor", policy)
+			So(v.Fields(), ShouldResemble, []FieldError{{Field: "password", Error: FieldErrorBanned}})
+		})
+
+		Convey("Cyrillic password longer than bcrypt limit", func() {
+			v.CheckPassword("password", strings.Repeat("пароль", 7), "editor", policy)
+			So(v.Fields(), ShouldResemble, []FieldError{{Field: "password", Error: FieldErrorMaxBytes, Constraint: &FieldErrorConstraint{Max: 72}}})
+		})
+	})
+}
+
+func TestConflictError(t *testing.T) {
+	Convey("Conflict error contains current version", t, func() {
+		err := ConflictError(7)
+		So(err.Code, ShouldEqual, http.StatusConflict)
+		So(err.Data, ShouldResemble, VersionConflict{CurrentVersion: 7})
+	})
+}
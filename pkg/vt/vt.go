@@ -53,3 +53,90 @@ type StatusUpdate struct {
 	StatusID  int   `json:"statusId" validate:"required,status"`
 	ObjectIDs []int `json:"ids" validate:"required,gt=0"`
 }
+
+const (
+	BulkResultUpdated   = "updated"
+	BulkResultNotFound  = "notFound"
+	BulkResultForbidden = "forbidden"
+	BulkResultConflict  = "conflict"
+)
+
+// BulkResult is a result of bulk operation for single object, result is one of: updated, notFound, forbidden, conflict.
+type BulkResult struct {
+	ID     int    `json:"id"`
+	Result string `json:"result"`
+}
+
+const (
+	DeleteStrategyRestrict = "restrict"
+	DeleteStrategyReassign = "reassign"
+	DeleteStrategyStrip    = "strip"
+)
+
+// Dependencies is a count of entities referencing the entity, deleted News are counted too.
+type Dependencies struct {
+	News       int `json:"news"`
+	Categories int `json:"categories"` // subcategories, only for Category
+}
+
+type NewsCategoryUpdate struct {
+	CategoryID int   `json:"categoryId" validate:"required"`
+	ObjectIDs  []int `json:"ids" validate:"required,gt=0"`
+}
+
+type NewsTagsUpdate struct {
+	TagIDs    []int `json:"tagIds" validate:"required,gt=0"`
+	ObjectIDs []int `json:"ids" validate:"required,gt=0"`
+}
+
+func containsInt(list []int, v int) bool {
+	for _, i := range list {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalIntPtrs reports whether a and b are both nil or point to equal values.
+func equalIntPtrs(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// insertInt returns copy of list with v inserted at position, position is clamped to list bounds.
+func insertInt(list []int, v, position int) []int {
+	if position < 0 {
+		position = 0
+	} else if position > len(list) {
+		position = len(list)
+	}
+
+	r := make([]int, 0, len(list)+1)
+	r = append(r, list[:position]...)
+	r = append(r, v)
+	return append(r, list[position:]...)
+}
+
+// newsPointers returns pointers to elements of News slice.
+func newsPointers(list []db.News) []*db.News {
+	r := make([]*db.News, 0, len(list))
+	for i := range list {
+		r = append(r, &list[i])
+	}
+	return r
+}
@@ -13,8 +13,11 @@ func NewUser(in *db.User) *User {
 		ID:             in.ID,
 		CreatedAt:      in.CreatedAt,
 		Login:          in.Login,
+		Email:          in.Email,
 		LastActivityAt: in.LastActivityAt,
 		StatusID:       in.StatusID,
+		Version:        in.Version,
+		Role:           in.Role,
 		Status:         NewStatus(in.StatusID),
 	}
 
@@ -30,7 +33,9 @@ func NewUserSummary(in *db.User) *UserSummary {
 		ID:             in.ID,
 		CreatedAt:      in.CreatedAt,
 		Login:          in.Login,
+		Email:          in.Email,
 		LastActivityAt: in.LastActivityAt,
+		Role:           in.Role,
 		Status:         NewStatus(in.StatusID),
 	}
 }
@@ -44,7 +49,109 @@ func NewUserProfile(in *db.User) *UserProfile {
 		ID:             in.ID,
 		CreatedAt:      in.CreatedAt,
 		Login:          in.Login,
+		Email:          in.Email,
 		LastActivityAt: in.LastActivityAt,
 		StatusID:       in.StatusID,
+		Role:           in.Role,
+	}
+}
+
+func NewAPIToken(in *db.APIToken) *APIToken {
+	if in == nil {
+		return nil
+	}
+
+	return &APIToken{
+		ID:         in.ID,
+		Title:      in.Title,
+		Prefix:     in.Prefix,
+		Scopes:     in.Scopes,
+		CreatedAt:  in.CreatedAt,
+		ExpiresAt:  in.ExpiresAt,
+		LastUsedAt: in.LastUsedAt,
+		UserID:     in.UserID,
+		StatusID:   in.StatusID,
+		User:       NewUserSummary(in.User),
+		Status:     NewStatus(in.StatusID),
+	}
+}
+
+func NewAPITokenSummary(in *db.APIToken) *APITokenSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &APITokenSummary{
+		ID:         in.ID,
+		Title:      in.Title,
+		Prefix:     in.Prefix,
+		Scopes:     in.Scopes,
+		CreatedAt:  in.CreatedAt,
+		ExpiresAt:  in.ExpiresAt,
+		LastUsedAt: in.LastUsedAt,
+		User:       NewUserSummary(in.User),
+		Status:     NewStatus(in.StatusID),
+	}
+}
+
+func NewAuditLog(in *db.AuditLog) *AuditLog {
+	if in == nil {
+		return nil
+	}
+
+	return &AuditLog{
+		ID:         in.ID,
+		CreatedAt:  in.CreatedAt,
+		UserID:     in.UserID,
+		APITokenID: in.APITokenID,
+		Namespace:  in.Namespace,
+		Method:     in.Method,
+		EntityType: in.EntityType,
+		EntityID:   in.EntityID,
+		Before:     in.Before,
+		After:      in.After,
+		RequestID:  in.RequestID,
+		IP:         in.IP,
+		User:       NewUserSummary(in.User),
+		APIToken:   NewAPITokenSummary(in.APIToken),
+	}
+}
+
+func NewAuditLogSummary(in *db.AuditLog) *AuditLogSummary {
+	if in == nil {
+		return nil
+	}
+
+	return &AuditLogSummary{
+		ID:         in.ID,
+		CreatedAt:  in.CreatedAt,
+		Namespace:  in.Namespace,
+		Method:     in.Method,
+		EntityType: in.EntityType,
+		EntityID:   in.EntityID,
+		RequestID:  in.RequestID,
+		IP:         in.IP,
+		User:       NewUserSummary(in.User),
+		APIToken:   NewAPITokenSummary(in.APIToken),
+	}
+}
+
+func NewTrashItem(in *db.TrashItem) *TrashItem {
+	if in == nil {
+		return nil
+	}
+
+	return &TrashItem{
+		ID:               in.ID,
+		EntityType:       in.EntityType,
+		EntityID:         in.EntityID,
+		Title:            in.Title,
+		PreviousStatusID: in.PreviousStatusID,
+		DeletedAt:        in.DeletedAt,
+		UserID:           in.UserID,
+		APITokenID:       in.APITokenID,
+		PreviousStatus:   NewStatus(in.PreviousStatusID),
+		User:             NewUserSummary(in.User),
+		APIToken:         NewAPITokenSummary(in.APIToken),
 	}
 }
@@ -11,9 +11,12 @@ type User struct {
 	ID             int        `json:"id"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	Login          string     `json:"login" validate:"required,max=64"`
-	Password       string     `json:"password" validate:"max=64"`
+	Password       string     `json:"password"`
+	Email          *string    `json:"email" validate:"omitempty,email,max=255"`
 	LastActivityAt *time.Time `json:"lastActivityAt"`
 	StatusID       int        `json:"statusId" validate:"required,status"`
+	Role           string     `json:"role" validate:"omitempty,oneof=admin editor author"`
+	Version        int        `json:"version"`
 
 	Status *Status `json:"status"`
 }
@@ -26,8 +29,11 @@ func (u *User) ToDB() *db.User {
 	user := &db.User{
 		ID:             u.ID,
 		Login:          u.Login,
+		Email:          u.Email,
 		LastActivityAt: u.LastActivityAt,
 		StatusID:       u.StatusID,
+		Role:           u.Role,
+		Version:        u.Version,
 	}
 
 	return user
@@ -36,7 +42,9 @@ func (u *User) ToDB() *db.User {
 type UserSearch struct {
 	ID                 *int       `json:"id"`
 	Login              *string    `json:"login" validate:"max=64"`
+	Email              *string    `json:"email" validate:"max=255"`
 	StatusID           *int       `json:"statusId" validate:"status"`
+	Role               *string    `json:"role"`
 	LastActivityAtFrom *time.Time `json:"lastActivityAtFrom"`
 	LastActivityAtTo   *time.Time `json:"lastActivityAtTo"`
 	IDs                []int      `json:"ids"`
@@ -51,7 +59,9 @@ func (us *UserSearch) ToDB() *db.UserSearch {
 	return &db.UserSearch{
 		ID:                 us.ID,
 		LoginILike:         us.Login,
+		EmailILike:         us.Email,
 		StatusID:           us.StatusID,
+		Role:               us.Role,
 		LastActivityAtFrom: us.LastActivityAtFrom,
 		LastActivityAtTo:   us.LastActivityAtTo,
 		IDs:                us.IDs,
@@ -63,7 +73,9 @@ type UserSummary struct {
 	ID             int        `json:"id"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	Login          string     `json:"login"`
+	Email          *string    `json:"email"`
 	LastActivityAt *time.Time `json:"lastActivityAt"`
+	Role           string     `json:"role"`
 
 	Status *Status `json:"status"`
 }
@@ -72,6 +84,191 @@ type UserProfile struct {
 	ID             int        `json:"id"`
 	CreatedAt      time.Time  `json:"createdAt"`
 	Login          string     `json:"login"`
+	Email          *string    `json:"email"`
 	LastActivityAt *time.Time `json:"lastActivityAt"`
 	StatusID       int        `json:"statusId"`
+	Role           string     `json:"role"`
+}
+
+type APIToken struct {
+	ID         int        `json:"id"`
+	Title      string     `json:"title" validate:"required,max=128"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes" validate:"required"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	UserID     int        `json:"userId"`
+	StatusID   int        `json:"statusId" validate:"required,status"`
+
+	User   *UserSummary `json:"user"`
+	Status *Status      `json:"status"`
+}
+
+func (at *APIToken) ToDB() *db.APIToken {
+	if at == nil {
+		return nil
+	}
+
+	return &db.APIToken{
+		ID:        at.ID,
+		Title:     at.Title,
+		Scopes:    at.Scopes,
+		ExpiresAt: at.ExpiresAt,
+		UserID:    at.UserID,
+		StatusID:  at.StatusID,
+	}
+}
+
+// APITokenWithSecret is a newly created APIToken with plain token value. Token is not stored and returned only once.
+type APITokenWithSecret struct {
+	APIToken *APIToken `json:"apiToken"`
+	Token    string    `json:"token"`
+}
+
+type APITokenSearch struct {
+	ID       *int    `json:"id"`
+	Title    *string `json:"title" validate:"max=128"`
+	Prefix   *string `json:"prefix"`
+	UserID   *int    `json:"userId"`
+	StatusID *int    `json:"statusId" validate:"status"`
+	IDs      []int   `json:"ids"`
+}
+
+func (ats *APITokenSearch) ToDB() *db.APITokenSearch {
+	if ats == nil {
+		return nil
+	}
+
+	return &db.APITokenSearch{
+		ID:         ats.ID,
+		TitleILike: ats.Title,
+		Prefix:     ats.Prefix,
+		UserID:     ats.UserID,
+		StatusID:   ats.StatusID,
+		IDs:        ats.IDs,
+	}
+}
+
+type APITokenSummary struct {
+	ID         int        `json:"id"`
+	Title      string     `json:"title"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+
+	User   *UserSummary `json:"user"`
+	Status *Status      `json:"status"`
+}
+
+type AuditLog struct {
+	ID         int                    `json:"id"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	UserID     *int                   `json:"userId"`
+	APITokenID *int                   `json:"apiTokenId"`
+	Namespace  string                 `json:"namespace"`
+	Method     string                 `json:"method"`
+	EntityType *string                `json:"entityType"`
+	EntityID   *int                   `json:"entityId"`
+	Before     map[string]interface{} `json:"before"`
+	After      map[string]interface{} `json:"after"`
+	RequestID  *string                `json:"requestId"`
+	IP         *string                `json:"ip"`
+
+	User     *UserSummary     `json:"user"`
+	APIToken *APITokenSummary `json:"apiToken"`
+}
+
+type AuditLogSearch struct {
+	ID            *int       `json:"id"`
+	UserID        *int       `json:"userId"`
+	APITokenID    *int       `json:"apiTokenId"`
+	Namespace     *string    `json:"namespace"`
+	Method        *string    `json:"method"`
+	EntityType    *string    `json:"entityType"`
+	EntityID      *int       `json:"entityId"`
+	RequestID     *string    `json:"requestId"`
+	IP            *string    `json:"ip"`
+	CreatedAtFrom *time.Time `json:"createdAtFrom"`
+	CreatedAtTo   *time.Time `json:"createdAtTo"`
+	IDs           []int      `json:"ids"`
+}
+
+func (als *AuditLogSearch) ToDB() *db.AuditLogSearch {
+	if als == nil {
+		return nil
+	}
+
+	return &db.AuditLogSearch{
+		ID:            als.ID,
+		UserID:        als.UserID,
+		APITokenID:    als.APITokenID,
+		Namespace:     als.Namespace,
+		Method:        als.Method,
+		EntityType:    als.EntityType,
+		EntityID:      als.EntityID,
+		RequestID:     als.RequestID,
+		IP:            als.IP,
+		CreatedAtFrom: als.CreatedAtFrom,
+		CreatedAtTo:   als.CreatedAtTo,
+		IDs:           als.IDs,
+	}
+}
+
+type AuditLogSummary struct {
+	ID         int       `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Namespace  string    `json:"namespace"`
+	Method     string    `json:"method"`
+	EntityType *string   `json:"entityType"`
+	EntityID   *int      `json:"entityId"`
+	RequestID  *string   `json:"requestId"`
+	IP         *string   `json:"ip"`
+
+	User     *UserSummary     `json:"user"`
+	APIToken *APITokenSummary `json:"apiToken"`
+}
+
+// TrashItem is a deleted entity, entityType is one of: news, category, tag, user.
+type TrashItem struct {
+	ID               int       `json:"id"`
+	EntityType       string    `json:"entityType"`
+	EntityID         int       `json:"entityId"`
+	Title            string    `json:"title"`
+	PreviousStatusID int       `json:"previousStatusId"`
+	DeletedAt        time.Time `json:"deletedAt"`
+	UserID           *int      `json:"userId"`
+	APITokenID       *int      `json:"apiTokenId"`
+
+	PreviousStatus *Status          `json:"previousStatus"`
+	User           *UserSummary     `json:"user"`
+	APIToken       *APITokenSummary `json:"apiToken"`
+}
+
+type TrashItemSearch struct {
+	ID          *int       `json:"id"`
+	EntityType  *string    `json:"entityType"`
+	EntityID    *int       `json:"entityId"`
+	Title       *string    `json:"title"`
+	UserID      *int       `json:"userId"`
+	DeletedAtTo *time.Time `json:"deletedAtTo"`
+	IDs         []int      `json:"ids"`
+}
+
+func (tis *TrashItemSearch) ToDB() *db.TrashItemSearch {
+	if tis == nil {
+		return nil
+	}
+
+	return &db.TrashItemSearch{
+		ID:          tis.ID,
+		EntityType:  tis.EntityType,
+		EntityID:    tis.EntityID,
+		TitleILike:  tis.Title,
+		UserID:      tis.UserID,
+		DeletedAtTo: tis.DeletedAtTo,
+		IDs:         tis.IDs,
+	}
 }
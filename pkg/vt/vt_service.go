@@ -3,6 +3,7 @@ package vt
 import (
 	"context"
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -10,25 +11,38 @@ import (
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mail"
+	"apisrv/pkg/passwd"
+	"apisrv/pkg/workflow"
 
+	"github.com/go-pg/pg/v10"
 	"github.com/vmkteam/zenrpc/v2"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db         db.DB
 	commonRepo db.CommonRepo
+	tokens     userTokenSender
+	hasher     passwd.Hasher
+	policy     passwd.Policy
 }
 
 var (
 	errInvalidLoginPassword = zenrpc.NewStringError(http.StatusBadRequest, "invalid login or password")
+	errInvalidToken         = zenrpc.NewStringError(http.StatusBadRequest, "invalid or expired token")
 )
 
-func NewAuthService(dbo db.DB, logger embedlog.Logger) *AuthService {
+func NewAuthService(dbo db.DB, logger embedlog.Logger, cfg AuthConfig, mailer *mail.Mailer) *AuthService {
+	commonRepo := db.NewCommonRepo(dbo)
 	return &AuthService{
 		Logger:     logger,
-		commonRepo: db.NewCommonRepo(dbo),
+		db:         dbo,
+		commonRepo: commonRepo,
+		tokens:     userTokenSender{commonRepo: commonRepo, mailer: mailer, cfg: cfg},
+		hasher:     passwd.New(cfg.Hasher),
+		policy:     cfg.Policy,
 	}
 }
 
@@ -51,10 +65,15 @@ func (s AuthService) Login(ctx context.Context, login, password string, remember
 		return "", errInvalidLoginPassword
 	}
 
-	if ok := s.checkHash(password, dbu.Password); !ok {
+	if ok := s.hasher.Verify(password, dbu.Password); !ok {
 		return "", errInvalidLoginPassword
 	}
 
+	// upgrade outdated hash, remember auth key depends on it so it must be done before generating the key
+	if s.hasher.NeedsRehash(dbu.Password) {
+		s.rehash(ctx, dbu, password)
+	}
+
 	if ok, err := s.commonRepo.AuthenticateUser(ctx, dbu, s.generateAuthKey(dbu, remember)); err != nil || !ok {
 		return "", InternalError(err)
 	}
@@ -97,6 +116,7 @@ func (s AuthService) Profile(ctx context.Context) (*UserProfile, error) {
 //
 //zenrpc:password New user password
 //zenrpc:return New user authentication key
+//zenrpc:400 Validation Error
 //zenrpc:401 Invalid authentication credentials
 //zenrpc:500 Internal Error
 func (s AuthService) ChangePassword(ctx context.Context, password string) (string, error) {
@@ -105,7 +125,12 @@ func (s AuthService) ChangePassword(ctx context.Context, password string) (strin
 		return "", ErrUnauthorized
 	}
 
-	p, err := passwordHash(password)
+	var v Validator
+	if v.CheckPassword("password", password, user.Login, s.policy); v.HasErrors() {
+		return "", v.Error()
+	}
+
+	p, err := s.hasher.Hash(password)
 	if err != nil {
 		return "", InternalError(err)
 	}
@@ -118,15 +143,102 @@ func (s AuthService) ChangePassword(ctx context.Context, password string) (strin
 	return user.AuthKey, nil
 }
 
+// RequestPasswordReset sends one-time password reset link to user email.
+// It always succeeds to prevent checking for existing users.
+//
+//zenrpc:login User login or email
+//zenrpc:return Request accepted
+//zenrpc:500 Internal Error
+func (s AuthService) RequestPasswordReset(ctx context.Context, login string) (bool, error) {
+	if login == "" {
+		return true, nil
+	}
+
+	dbu, err := s.commonRepo.EnabledUserByLoginOrEmail(ctx, login)
+	if err != nil {
+		return false, InternalError(err)
+	} else if dbu == nil || dbu.Email == nil || *dbu.Email == "" {
+		return true, nil
+	}
+
+	// failure is not returned, otherwise response would differ for existing and unknown logins
+	if err = s.tokens.Send(ctx, dbu, db.UserTokenReset); err != nil {
+		s.Error(ctx, "send password reset", "userId", dbu.ID, "err", err)
+	}
+
+	return true, nil
+}
+
+// ResetPassword sets new password by one-time invitation or password reset token.
+//
+//zenrpc:token One-time token from email
+//zenrpc:password New user password
+//zenrpc:return User authentication key
+//zenrpc:400 Invalid or expired token, Validation Error
+//zenrpc:500 Internal Error
+func (s AuthService) ResetPassword(ctx context.Context, token, password string) (string, error) {
+	ut, err := s.commonRepo.ActiveUserToken(ctx, hashToken(token))
+	if err != nil {
+		return "", InternalError(err)
+	} else if ut == nil || ut.User == nil || ut.User.StatusID != db.StatusEnabled {
+		return "", errInvalidToken
+	}
+
+	user := ut.User
+
+	var v Validator
+	if v.CheckPassword("password", password, user.Login, s.policy); v.HasErrors() {
+		return "", v.Error()
+	}
+
+	if user.Password, err = s.hasher.Hash(password); err != nil {
+		return "", InternalError(err)
+	}
+	user.AuthKey = s.generateAuthKey(user, false)
+
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		cr := s.commonRepo.WithTransaction(tx)
+		// token is consumed first, so concurrent requests with the same token could not both succeed
+		if used, err := cr.UseUserToken(ctx, ut.Token); err != nil {
+			return err
+		} else if used == nil || used.UserID != user.ID {
+			return errInvalidToken
+		}
+
+		if _, err := cr.UpdateUserPassword(ctx, user); err != nil {
+			return err
+		}
+		return cr.UseUserTokens(ctx, user.ID)
+	})
+	if errors.Is(err, errInvalidToken) {
+		return "", errInvalidToken
+	} else if err != nil {
+		return "", InternalError(err)
+	}
+
+	return user.AuthKey, nil
+}
+
 // VfsAuthToken get auth token for VFS requests
 func (s AuthService) VfsAuthToken(ctx context.Context) (string, error) {
 	user := UserFromContext(ctx)
 	return user.AuthKey, nil
 }
 
-func (s AuthService) checkHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// rehash updates user password hash with current hasher. Errors are only logged, login must not fail because of them.
+func (s AuthService) rehash(ctx context.Context, u *db.User, password string) {
+	p, err := s.hasher.Hash(password)
+	if err != nil {
+		s.Error(ctx, "rehash password", "userId", u.ID, "err", err)
+		return
+	}
+
+	prev := u.Password
+	u.Password = p
+	if _, err = s.commonRepo.UpdateUser(ctx, u, db.WithColumns(db.Columns.User.Password)); err != nil {
+		s.Error(ctx, "update rehashed password", "userId", u.ID, "err", err)
+		u.Password = prev
+	}
 }
 
 func (s AuthService) generateAuthKey(u *db.User, remember bool) string {
@@ -147,21 +259,25 @@ func (s AuthService) generateRandom(length int) string {
 	return string(b)
 }
 
-func passwordHash(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
-}
-
 type UserService struct {
 	zenrpc.Service
 	embedlog.Logger
+	db         db.DB
 	commonRepo db.CommonRepo
+	tokens     userTokenSender
+	hasher     passwd.Hasher
+	policy     passwd.Policy
 }
 
-func NewUserService(dbo db.DB, logger embedlog.Logger) *UserService {
+func NewUserService(dbo db.DB, logger embedlog.Logger, cfg AuthConfig, mailer *mail.Mailer) *UserService {
+	commonRepo := db.NewCommonRepo(dbo)
 	return &UserService{
 		Logger:     logger,
-		commonRepo: db.NewCommonRepo(dbo),
+		db:         dbo,
+		commonRepo: commonRepo,
+		tokens:     userTokenSender{commonRepo: commonRepo, mailer: mailer, cfg: cfg},
+		hasher:     passwd.New(cfg.Hasher),
+		policy:     cfg.Policy,
 	}
 }
 
@@ -236,7 +352,8 @@ func (s UserService) byID(ctx context.Context, id int) (*db.User, error) {
 	return db, nil
 }
 
-// Add a User from the query
+// Add a User from the query. If password is empty, invitation is sent to user email.
+// User is created even if invitation could not be sent, it could be sent again by Invite.
 //
 //zenrpc:user User
 //zenrpc:return User
@@ -247,21 +364,76 @@ func (s UserService) Add(ctx context.Context, user User) (*User, error) {
 		return nil, ve.Error()
 	}
 
-	p, err := passwordHash(user.Password)
-	if err != nil {
-		return nil, InternalError(err)
+	if user.Role == "" {
+		user.Role = string(workflow.RoleAuthor)
+	}
+
+	if !canChangeRole(ctx, workflow.RoleAuthor, workflow.Role(user.Role)) {
+		return nil, ErrForbidden
 	}
 
 	u := user.ToDB()
-	u.Password = p
+	if user.Password != "" {
+		p, err := s.hasher.Hash(user.Password)
+		if err != nil {
+			return nil, InternalError(err)
+		}
+		u.Password = p
+	}
+
+	var (
+		dbc    *db.User
+		invite *userTokenMessage
+	)
+	err := s.db.RunInTransaction(ctx, func(tx *pg.Tx) (err error) {
+		cr := s.commonRepo.WithTransaction(tx)
+		if dbc, err = cr.AddUser(ctx, u); err != nil || user.Password != "" {
+			return err
+		}
 
-	dbc, err := s.commonRepo.AddUser(ctx, u)
+		invite, err = s.tokens.Issue(ctx, cr, dbc, db.UserTokenInvite)
+		return err
+	})
 	if err != nil {
 		return nil, InternalError(err)
 	}
+
+	// invitation is sent after commit, so link is never sent for user which is not created
+	if invite != nil {
+		if err = s.tokens.Deliver(ctx, invite); err != nil {
+			s.Error(ctx, "send invitation", "userId", dbc.ID, "err", err)
+		}
+	}
+
 	return NewUser(dbc), nil
 }
 
+// Invite sends new invitation link to User email.
+//
+//zenrpc:id int
+//zenrpc:return isSent
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s UserService) Invite(ctx context.Context, id int) (bool, error) {
+	user, err := s.byID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if user.Email == nil || *user.Email == "" {
+		var v Validator
+		v.Append("email", FieldErrorRequired)
+		return false, v.Error()
+	}
+
+	if err = s.tokens.Send(ctx, user, db.UserTokenInvite); err != nil {
+		return false, InternalError(err)
+	}
+
+	return true, nil
+}
+
 // Update updates the User data identified by id from the query
 //
 //zenrpc:users User
@@ -269,6 +441,7 @@ func (s UserService) Add(ctx context.Context, user User) (*User, error) {
 //zenrpc:500 Internal Error
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
+//zenrpc:409 Version Conflict
 func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 	orig, err := s.byID(ctx, user.ID)
 	if err != nil {
@@ -277,6 +450,16 @@ func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 
 	if ve := s.isValid(ctx, user, true); ve.HasErrors() {
 		return false, ve.Error()
+	} else if orig.Version != user.Version {
+		return false, ConflictError(orig.Version)
+	}
+
+	if user.Role == "" {
+		user.Role = orig.Role
+	}
+
+	if !canChangeRole(ctx, workflow.Role(orig.Role), workflow.Role(user.Role)) {
+		return false, ErrForbidden
 	}
 
 	cur := user.ToDB()
@@ -284,7 +467,7 @@ func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 	cur.AuthKey = orig.AuthKey
 
 	if user.Password != "" {
-		p, err := passwordHash(user.Password)
+		p, err := s.hasher.Hash(user.Password)
 		if err != nil {
 			return false, InternalError(err)
 		}
@@ -292,13 +475,24 @@ func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 		cur.AuthKey = ""
 	}
 
-	ok, err := s.commonRepo.UpdateUser(ctx, cur)
+	ok, err := s.commonRepo.UpdateUserVersion(ctx, cur)
 	if err != nil {
 		return false, InternalError(err)
+	} else if !ok {
+		return false, s.conflict(ctx, user.ID)
 	}
 	return ok, nil
 }
 
+// conflict returns conflict error with current User version.
+func (s UserService) conflict(ctx context.Context, id int) error {
+	cur, err := s.byID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ConflictError(cur.Version)
+}
+
 // Delete deletes the User by its ID.
 //
 //zenrpc:id int
@@ -307,15 +501,22 @@ func (s UserService) Update(ctx context.Context, user User) (bool, error) {
 //zenrpc:400 Validation Error
 //zenrpc:404 Not Found
 func (s UserService) Delete(ctx context.Context, id int) (bool, error) {
-	if _, err := s.byID(ctx, id); err != nil {
+	user, err := s.byID(ctx, id)
+	if err != nil {
 		return false, err
 	}
 
-	ok, err := s.commonRepo.DeleteUser(ctx, id)
+	var ok bool
+	err = s.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if ok, err = s.commonRepo.WithTransaction(tx).DeleteUser(ctx, id); err != nil || !ok {
+			return err
+		}
+		return addTrashItem(ctx, tx, NSUser, id, user.Login, user.StatusID)
+	})
 	if err != nil {
 		return false, InternalError(err)
 	}
-	return ok, err
+	return ok, nil
 }
 
 // Validate Verifies that User data is valid.
@@ -355,9 +556,252 @@ func (s UserService) isValid(ctx context.Context, user User, isUpdate bool) Vali
 		v.Append("login", FieldErrorUnique)
 	}
 
-	// check empty password for add
-	if !isUpdate && user.Password == "" {
+	// check email unique
+	if user.Email != nil && *user.Email != "" {
+		item, err = s.commonRepo.UserByEmail(ctx, *user.Email)
+		if err != nil {
+			v.SetInternalError(err)
+		} else if item != nil && item.ID != user.ID {
+			v.Append("email", FieldErrorUnique)
+		}
+	}
+
+	if isUpdate && user.Version == 0 {
+		v.Append("version", FieldErrorRequired)
+	}
+
+	// check empty password for add without invitation
+	if !isUpdate && user.Password == "" && (user.Email == nil || *user.Email == "") {
 		v.Append("password", FieldErrorRequired)
+	} else if user.Password != "" {
+		v.CheckPassword("password", user.Password, user.Login, s.policy)
+	}
+
+	return v
+}
+
+// canChangeRole checks that current user could change role of the user, only admins could do it.
+func canChangeRole(ctx context.Context, from, to workflow.Role) bool {
+	return from == to || currentRole(ctx) == workflow.RoleAdmin
+}
+
+type APITokenService struct {
+	zenrpc.Service
+	embedlog.Logger
+	commonRepo db.CommonRepo
+}
+
+func NewAPITokenService(dbo db.DB, logger embedlog.Logger) *APITokenService {
+	return &APITokenService{
+		Logger:     logger,
+		commonRepo: db.NewCommonRepo(dbo),
+	}
+}
+
+func (s APITokenService) dbSort(ops *ViewOps) db.OpFunc {
+	v := s.commonRepo.DefaultAPITokenSort()
+	if ops == nil {
+		return v
+	}
+
+	switch ops.SortColumn {
+	case db.Columns.APIToken.ID, db.Columns.APIToken.Title, db.Columns.APIToken.CreatedAt, db.Columns.APIToken.ExpiresAt, db.Columns.APIToken.LastUsedAt, db.Columns.APIToken.StatusID:
+		v = db.WithSort(db.NewSortField(ops.SortColumn, ops.SortDesc))
+	}
+
+	return v
+}
+
+// Count APITokens according to conditions in search params
+//
+//zenrpc:search APITokenSearch
+//zenrpc:return int
+//zenrpc:500 Internal Error
+func (s APITokenService) Count(ctx context.Context, search *APITokenSearch) (int, error) {
+	count, err := s.commonRepo.CountAPITokens(ctx, search.ToDB())
+	if err != nil {
+		return 0, InternalError(err)
+	}
+	return count, nil
+}
+
+// Get а list of APITokens according to conditions in search params
+//
+//zenrpc:search APITokenSearch
+//zenrpc:viewOps ViewOps
+//zenrpc:return []APITokenSummary
+//zenrpc:500 Internal Error
+func (s APITokenService) Get(ctx context.Context, search *APITokenSearch, viewOps *ViewOps) ([]APITokenSummary, error) {
+	list, err := s.commonRepo.APITokensByFilters(ctx, search.ToDB(), viewOps.Pager(), s.dbSort(viewOps), s.commonRepo.FullAPIToken())
+	if err != nil {
+		return nil, InternalError(err)
+	}
+	tokens := make([]APITokenSummary, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		if token := NewAPITokenSummary(&list[i]); token != nil {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+// GetByID returns a APIToken by its ID.
+//
+//zenrpc:id int
+//zenrpc:return APIToken
+//zenrpc:500 Internal Error
+//zenrpc:404 Not Found
+func (s APITokenService) GetByID(ctx context.Context, id int) (*APIToken, error) {
+	db, err := s.byID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIToken(db), nil
+}
+
+func (s APITokenService) byID(ctx context.Context, id int) (*db.APIToken, error) {
+	db, err := s.commonRepo.APITokenByID(ctx, id, s.commonRepo.FullAPIToken())
+	if err != nil {
+		return nil, InternalError(err)
+	} else if db == nil {
+		return nil, ErrNotFound
+	}
+	return db, nil
+}
+
+// Scopes returns all scopes that could be assigned to APIToken.
+// Scope is "*" for all namespaces, "<namespace>" for all namespace methods or "<namespace>:read" for read only methods.
+//
+//zenrpc:return []string
+func (s APITokenService) Scopes() []string {
+	return APITokenScopes()
+}
+
+// Add creates a APIToken for current user. Plain token is returned only once and could not be restored.
+//
+//zenrpc:apiToken APIToken
+//zenrpc:return APITokenWithSecret
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:401 Unauthorized
+func (s APITokenService) Add(ctx context.Context, apiToken APIToken) (*APITokenWithSecret, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthorized
+	}
+
+	if ve := s.isValid(ctx, apiToken); ve.HasErrors() {
+		return nil, ve.Error()
+	}
+
+	token, prefix, err := newAPIToken()
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	at := apiToken.ToDB()
+	at.UserID = user.ID
+	at.Token = hashToken(token)
+	at.Prefix = prefix
+
+	dbc, err := s.commonRepo.AddAPIToken(ctx, at)
+	if err != nil {
+		return nil, InternalError(err)
+	}
+
+	dbc, err = s.byID(ctx, dbc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APITokenWithSecret{APIToken: NewAPIToken(dbc), Token: token}, nil
+}
+
+// Update updates title, scopes, expiration time and status of the APIToken identified by id from the query.
+//
+//zenrpc:apiToken APIToken
+//zenrpc:return bool
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s APITokenService) Update(ctx context.Context, apiToken APIToken) (bool, error) {
+	if _, err := s.byID(ctx, apiToken.ID); err != nil {
+		return false, err
+	}
+
+	if ve := s.isValid(ctx, apiToken); ve.HasErrors() {
+		return false, ve.Error()
+	}
+
+	ok, err := s.commonRepo.UpdateAPIToken(ctx, apiToken.ToDB(), db.WithColumns(
+		db.Columns.APIToken.Title,
+		db.Columns.APIToken.Scopes,
+		db.Columns.APIToken.ExpiresAt,
+		db.Columns.APIToken.StatusID,
+	))
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, nil
+}
+
+// Delete revokes the APIToken by its ID.
+//
+//zenrpc:id int
+//zenrpc:return isDeleted
+//zenrpc:500 Internal Error
+//zenrpc:400 Validation Error
+//zenrpc:404 Not Found
+func (s APITokenService) Delete(ctx context.Context, id int) (bool, error) {
+	if _, err := s.byID(ctx, id); err != nil {
+		return false, err
+	}
+
+	ok, err := s.commonRepo.DeleteAPIToken(ctx, id)
+	if err != nil {
+		return false, InternalError(err)
+	}
+	return ok, err
+}
+
+// Validate Verifies that APIToken data is valid.
+//
+//zenrpc:apiToken APIToken
+//zenrpc:return []FieldError
+//zenrpc:500 Internal Error
+func (s APITokenService) Validate(ctx context.Context, apiToken APIToken) ([]FieldError, error) {
+	if apiToken.ID != 0 {
+		if _, err := s.byID(ctx, apiToken.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	ve := s.isValid(ctx, apiToken)
+	if ve.HasInternalError() {
+		return nil, ve.Error()
+	}
+
+	return ve.Fields(), nil
+}
+
+func (s APITokenService) isValid(ctx context.Context, apiToken APIToken) Validator {
+	var v Validator
+
+	if v.CheckBasic(ctx, apiToken); v.HasInternalError() {
+		return v
+	}
+
+	// check scopes are known
+	for _, scope := range apiToken.Scopes {
+		if !isValidScope(scope) {
+			v.Append("scopes", FieldErrorIncorrect)
+			break
+		}
+	}
+
+	// check expiration time
+	if apiToken.ExpiresAt != nil && apiToken.ExpiresAt.Before(time.Now()) {
+		v.Append("expiresAt", FieldErrorIncorrect)
 	}
 
 	return v
@@ -2,20 +2,56 @@ package vt
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"apisrv/pkg/db"
 	"apisrv/pkg/embedlog"
+	"apisrv/pkg/mail"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+type testMailSender struct {
+	messages []mail.Message
+}
+
+func (s *testMailSender) Send(_ context.Context, _ string, msg mail.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// failingMailSender fails to send all messages.
+type failingMailSender struct{}
+
+func (failingMailSender) Send(context.Context, string, mail.Message) error {
+	return errors.New("smtp is unavailable")
+}
+
+// lastToken returns token from the last sent message.
+func (s *testMailSender) lastToken() string {
+	if len(s.messages) == 0 {
+		return ""
+	}
+
+	body := s.messages[len(s.messages)-1].Body
+	i := strings.Index(body, "token=")
+	if i == -1 {
+		return ""
+	}
+
+	return strings.Fields(body[i+len("token="):])[0]
+}
+
 func TestDB_AuthService(t *testing.T) {
 	Convey("Test AuthService", t, func() {
 		ctx := context.Background()
-		srv := NewAuthService(testDb, embedlog.Logger{})
+		sender := &testMailSender{}
+		srv := NewAuthService(testDb, embedlog.Logger{}, AuthConfig{ResetURL: "https://vt.local/reset"}, mail.NewMailer(sender, mail.Config{}))
 		So(srv, ShouldNotBeNil)
 
 		Convey("Positive testing", func() {
@@ -49,6 +85,72 @@ func TestDB_AuthService(t *testing.T) {
 					So(ok, ShouldBeTrue)
 				})
 			})
+
+			Convey("Reset password by email token", func() {
+				login := fmt.Sprintf("reset%d", time.Now().UnixNano())
+				email := login + "@vt.local"
+				u, err := srv.commonRepo.AddUser(ctx, &db.User{Login: login, Email: &email, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+
+				ok, err := srv.RequestPasswordReset(ctx, email)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(sender.messages, ShouldHaveLength, 1)
+				So(sender.messages[0].To, ShouldEqual, email)
+
+				token := sender.lastToken()
+				So(token, ShouldNotBeEmpty)
+
+				authKey, err := srv.ResetPassword(ctx, token, "new-password")
+				So(err, ShouldBeNil)
+				So(authKey, ShouldHaveLength, 32)
+
+				_, err = srv.Login(ctx, login, "new-password", false)
+				So(err, ShouldBeNil)
+
+				// token is one-time
+				_, err = srv.ResetPassword(ctx, token, "other-password")
+				So(err, ShouldEqual, errInvalidToken)
+
+				_, err = srv.commonRepo.DeleteUser(ctx, u.ID)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Reset password token is used once by concurrent requests", func() {
+				login := fmt.Sprintf("resetrace%d", time.Now().UnixNano())
+				email := login + "@vt.local"
+				u, err := srv.commonRepo.AddUser(ctx, &db.User{Login: login, Email: &email, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+
+				_, err = srv.RequestPasswordReset(ctx, email)
+				So(err, ShouldBeNil)
+				token := sender.lastToken()
+
+				const requests = 5
+				errs := make([]error, requests)
+				var wg sync.WaitGroup
+				for i := range errs {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						_, errs[i] = srv.ResetPassword(ctx, token, fmt.Sprintf("new-password-%d", i))
+					}(i)
+				}
+				wg.Wait()
+
+				var succeeded int
+				for _, err := range errs {
+					if err == nil {
+						succeeded++
+					} else {
+						So(err, ShouldEqual, errInvalidToken)
+					}
+				}
+				So(succeeded, ShouldEqual, 1)
+
+				_, err = srv.commonRepo.DeleteUser(ctx, u.ID)
+				So(err, ShouldBeNil)
+			})
 		})
 
 		Convey("Negative testing", func() {
@@ -63,6 +165,33 @@ func TestDB_AuthService(t *testing.T) {
 				So(err, ShouldBeError)
 			})
 
+			Convey("Reset password with invalid token", func() {
+				_, err := srv.ResetPassword(ctx, "invalid", "password")
+				So(err, ShouldEqual, errInvalidToken)
+			})
+
+			Convey("Password reset for unknown user is silent", func() {
+				ok, err := srv.RequestPasswordReset(ctx, "unknown-user")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(sender.messages, ShouldBeEmpty)
+			})
+
+			Convey("Password reset with failed mail is not distinguishable from unknown user", func() {
+				login := fmt.Sprintf("resetfail%d", time.Now().UnixNano())
+				email := login + "@vt.local"
+				u, err := srv.commonRepo.AddUser(ctx, &db.User{Login: login, Email: &email, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+
+				failing := NewAuthService(testDb, embedlog.Logger{}, AuthConfig{}, mail.NewMailer(failingMailSender{}, mail.Config{}))
+				ok, err := failing.RequestPasswordReset(ctx, email)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				_, err = srv.commonRepo.DeleteUser(ctx, u.ID)
+				So(err, ShouldBeNil)
+			})
+
 			Convey("Empty login/password", func() {
 				_, err := srv.Login(ctx, "", "", false)
 				So(err, ShouldBeError)
@@ -89,7 +218,7 @@ func TestDB_AuthService(t *testing.T) {
 func TestDB_UserService(t *testing.T) {
 	Convey("Test UserService", t, func() {
 		ctx := context.Background()
-		srv := NewUserService(testDb, embedlog.Logger{})
+		srv := NewUserService(testDb, embedlog.Logger{}, AuthConfig{}, mail.NewMailer(&testMailSender{}, mail.Config{}))
 		So(srv, ShouldNotBeNil)
 
 		Convey("Positive testing", func() {
@@ -139,6 +268,38 @@ func TestDB_UserService(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(ok, ShouldBeTrue)
 			})
+
+			Convey("Update keeps creation and activity time", func() {
+				login := fmt.Sprintf("times_%d", time.Now().UnixNano())
+				added, err := srv.Add(ctx, User{Login: login, Password: "pwd_" + login, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+
+				before, err := srv.commonRepo.UserByID(ctx, added.ID)
+				So(err, ShouldBeNil)
+				So(before.CreatedAt.IsZero(), ShouldBeFalse)
+
+				u, err := srv.GetByID(ctx, added.ID)
+				So(err, ShouldBeNil)
+
+				activity := time.Now().Add(time.Hour)
+				u.CreatedAt = time.Time{}
+				u.LastActivityAt = &activity
+				u.Password = ""
+
+				ok, err := srv.Update(ctx, *u)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				after, err := srv.commonRepo.UserByID(ctx, added.ID)
+				So(err, ShouldBeNil)
+				So(after.CreatedAt.Equal(before.CreatedAt), ShouldBeTrue)
+				So(before.LastActivityAt, ShouldBeNil)
+				So(after.LastActivityAt, ShouldBeNil)
+				So(after.Version, ShouldEqual, before.Version+1)
+
+				_, err = srv.Delete(ctx, added.ID)
+				So(err, ShouldBeNil)
+			})
 		})
 
 		Convey("Negative testing", func() {
@@ -165,6 +326,23 @@ func TestDB_UserService(t *testing.T) {
 				So(u, ShouldBeNil)
 			})
 
+			Convey("User is created when invitation could not be sent", func() {
+				login := fmt.Sprintf("invite_%d", time.Now().UnixNano())
+				email := login + "@vt.local"
+				failing := NewUserService(testDb, embedlog.Logger{}, AuthConfig{}, mail.NewMailer(failingMailSender{}, mail.Config{}))
+
+				u, err := failing.Add(ctx, User{Login: login, Email: &email, StatusID: db.StatusEnabled})
+				So(err, ShouldBeNil)
+				So(u, ShouldNotBeNil)
+
+				ok, err := failing.Invite(ctx, u.ID)
+				So(err, ShouldNotBeNil)
+				So(ok, ShouldBeFalse)
+
+				_, err = srv.Delete(ctx, u.ID)
+				So(err, ShouldBeNil)
+			})
+
 			Convey("Create user with duplicate login", func() {
 				user := User{
 					Login:     "unique",
@@ -0,0 +1,222 @@
+package vt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/mail"
+	"apisrv/pkg/passwd"
+)
+
+const (
+	defaultInviteTTL = 72 * time.Hour
+	defaultResetTTL  = time.Hour
+)
+
+type AuthConfig struct {
+	InviteTTL time.Duration // invitation token lifetime, default 72h
+	ResetTTL  time.Duration // password reset token lifetime, default 1h
+	InviteURL string        // VT page for accepting invitation, token is passed as "token" query param
+	ResetURL  string        // VT page for password reset, token is passed as "token" query param
+
+	Hasher passwd.Config // password hashing algorithm and its parameters
+	Policy passwd.Policy // requirements for new passwords
+}
+
+// userTokenSender issues one-time user tokens and delivers them by email.
+type userTokenSender struct {
+	commonRepo db.CommonRepo
+	mailer     *mail.Mailer
+	cfg        AuthConfig
+}
+
+// userTokenMessage is an email with issued user token.
+type userTokenMessage struct {
+	to       string
+	template string
+	data     map[string]interface{}
+}
+
+// Send creates new token of given type for the user and sends link with it to user email.
+func (s userTokenSender) Send(ctx context.Context, user *db.User, tokenType string) error {
+	msg, err := s.Issue(ctx, s.commonRepo, user, tokenType)
+	if err != nil {
+		return err
+	}
+
+	return s.Deliver(ctx, msg)
+}
+
+// Issue creates new token of given type for the user with commonRepo, which could be bound to transaction.
+// Returned message should be delivered after token is committed.
+func (s userTokenSender) Issue(ctx context.Context, commonRepo db.CommonRepo, user *db.User, tokenType string) (*userTokenMessage, error) {
+	token, err := newUserToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, tpl, link := s.cfg.ResetTTL, mail.TemplatePasswordReset, s.cfg.ResetURL
+	if tokenType == db.UserTokenInvite {
+		ttl, tpl, link = s.cfg.InviteTTL, mail.TemplateInvite, s.cfg.InviteURL
+	}
+
+	if ttl == 0 {
+		ttl = defaultResetTTL
+		if tokenType == db.UserTokenInvite {
+			ttl = defaultInviteTTL
+		}
+	}
+
+	ut := &db.UserToken{
+		UserID:    user.ID,
+		Type:      tokenType,
+		Token:     hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if _, err = commonRepo.AddUserToken(ctx, ut); err != nil {
+		return nil, err
+	}
+
+	return &userTokenMessage{
+		to:       *user.Email,
+		template: tpl,
+		data: map[string]interface{}{
+			"Login":     user.Login,
+			"URL":       userTokenURL(link, token),
+			"ExpiresAt": ut.ExpiresAt,
+		},
+	}, nil
+}
+
+// Deliver sends message with issued token.
+func (s userTokenSender) Deliver(ctx context.Context, msg *userTokenMessage) error {
+	return s.mailer.Send(ctx, msg.to, msg.template, msg.data)
+}
+
+// newUserToken returns random token for sending to user.
+func newUserToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken returns user or api token hash for storing in DB.
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// userTokenURL adds token query param to link.
+func userTokenURL(link, token string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link + token
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+const (
+	apiTokenPrefix = "vt_"
+	// ScopeAll allows api token to call all namespaces available for api tokens.
+	ScopeAll = "*"
+	// scopeReadSuffix limits namespace scope to read only methods, e.g. "news:read".
+	scopeReadSuffix = ":read"
+)
+
+// apiTokenNamespaces are namespaces that could be called with api token.
+// Auth, apiToken and audit namespaces are available only for users.
+var apiTokenNamespaces = []string{NSCategory, NSAuthor, NSNews, NSTag, NSUser, NSVFS}
+
+// apiTokenReadOnlyNamespaces are namespaces where api token could call only read methods.
+// Users could not be created or changed with api token, otherwise token of admin could create new admins.
+var apiTokenReadOnlyNamespaces = map[string]struct{}{
+	NSUser: {},
+}
+
+// apiTokenReadMethods are methods allowed by read only scope.
+var apiTokenReadMethods = map[string]struct{}{
+	"count":                {},
+	"get":                  {},
+	"getbyid":              {},
+	"validate":             {},
+	"revisions":            {},
+	"revisiondiff":         {},
+	"transitions":          {},
+	"availabletransitions": {},
+	"states":               {},
+	"scheduled":            {},
+	"dependencies":         {},
+	"tree":                 {},
+}
+
+// newAPIToken returns random api token and its public prefix for showing in lists.
+func newAPIToken() (token, prefix string, err error) {
+	token, err = newUserToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	return apiTokenPrefix + token, token[:8], nil
+}
+
+// APITokenScopes returns all scopes that could be assigned to api token.
+func APITokenScopes() []string {
+	scopes := []string{ScopeAll}
+	for _, ns := range apiTokenNamespaces {
+		if _, ok := apiTokenReadOnlyNamespaces[ns]; !ok {
+			scopes = append(scopes, ns)
+		}
+		scopes = append(scopes, ns+scopeReadSuffix)
+	}
+
+	return scopes
+}
+
+// isValidScope checks that scope is known.
+func isValidScope(scope string) bool {
+	for _, s := range APITokenScopes() {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiTokenAllows checks that method of namespace could be called with given scopes.
+func apiTokenAllows(scopes []string, ns, method string) bool {
+	if ns == NSAuth || ns == NSAPIToken || ns == NSAudit || ns == NSTrash {
+		return false
+	}
+
+	_, isRead := apiTokenReadMethods[strings.ToLower(method)]
+	if _, ok := apiTokenReadOnlyNamespaces[ns]; ok && !isRead {
+		return false
+	}
+
+	for _, scope := range scopes {
+		switch {
+		case scope == ScopeAll, scope == ns:
+			return true
+		case isRead && scope == ns+scopeReadSuffix:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package vt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apisrv/pkg/db"
+	"apisrv/pkg/workflow"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPITokenAllows(t *testing.T) {
+	Convey("Test api token scopes", t, func() {
+		Convey("All scope", func() {
+			So(apiTokenAllows([]string{ScopeAll}, NSNews, RPC.NewsService.Update), ShouldBeTrue)
+			So(apiTokenAllows([]string{ScopeAll}, NSVFS, ""), ShouldBeTrue)
+		})
+
+		Convey("Namespace scope", func() {
+			So(apiTokenAllows([]string{NSNews}, NSNews, RPC.NewsService.Delete), ShouldBeTrue)
+			So(apiTokenAllows([]string{NSNews}, NSTag, RPC.TagService.Get), ShouldBeFalse)
+		})
+
+		Convey("Read only scope", func() {
+			scopes := []string{NSTag + scopeReadSuffix}
+			So(apiTokenAllows(scopes, NSTag, RPC.TagService.Get), ShouldBeTrue)
+			So(apiTokenAllows(scopes, NSTag, RPC.TagService.GetByID), ShouldBeTrue)
+			So(apiTokenAllows(scopes, NSTag, RPC.TagService.Add), ShouldBeFalse)
+		})
+
+		Convey("User only namespaces", func() {
+			So(apiTokenAllows([]string{ScopeAll}, NSAuth, RPC.AuthService.Profile), ShouldBeFalse)
+			So(apiTokenAllows([]string{ScopeAll}, NSAPIToken, RPC.APITokenService.Add), ShouldBeFalse)
+		})
+
+		Convey("Users could only be read", func() {
+			So(apiTokenAllows([]string{ScopeAll}, NSUser, RPC.UserService.Get), ShouldBeTrue)
+			So(apiTokenAllows([]string{NSUser + scopeReadSuffix}, NSUser, RPC.UserService.GetByID), ShouldBeTrue)
+			So(apiTokenAllows([]string{ScopeAll}, NSUser, RPC.UserService.Add), ShouldBeFalse)
+			So(apiTokenAllows([]string{NSUser}, NSUser, RPC.UserService.Update), ShouldBeFalse)
+			So(isValidScope(NSUser), ShouldBeFalse)
+			So(isValidScope(NSUser+scopeReadSuffix), ShouldBeTrue)
+		})
+
+		Convey("Known scopes", func() {
+			So(isValidScope("news:read"), ShouldBeTrue)
+			So(isValidScope("auth"), ShouldBeFalse)
+			So(isValidScope("news:write"), ShouldBeFalse)
+		})
+	})
+}
+
+func TestRequestAPIToken(t *testing.T) {
+	Convey("Test api token from request", t, func() {
+		r, _ := http.NewRequest(http.MethodPost, "/v1/vt/", nil)
+		So(requestAPIToken(r), ShouldBeEmpty)
+
+		r.Header.Set("Authorization", "Bearer vt_123")
+		So(requestAPIToken(r), ShouldEqual, "vt_123")
+
+		r.Header.Set(APITokenKey, "vt_456")
+		So(requestAPIToken(r), ShouldEqual, "vt_456")
+
+		token, prefix, err := newAPIToken()
+		So(err, ShouldBeNil)
+		So(strings.HasPrefix(token, apiTokenPrefix), ShouldBeTrue)
+		So(token, ShouldContainSubstring, prefix)
+		So(hashToken(token), ShouldHaveLength, 64)
+	})
+}
+
+func TestDB_APITokenAuth(t *testing.T) {
+	Convey("Test api token of disabled user", t, func() {
+		ctx, repo := context.Background(), db.NewCommonRepo(testDb)
+		login := fmt.Sprintf("token_%d", time.Now().UnixNano())
+		user, err := repo.AddUser(ctx, &db.User{Login: login, Role: string(workflow.RoleAdmin), StatusID: db.StatusEnabled})
+		So(err, ShouldBeNil)
+
+		token, prefix, err := newAPIToken()
+		So(err, ShouldBeNil)
+		_, err = repo.AddAPIToken(ctx, &db.APIToken{UserID: user.ID, Title: login, Token: hashToken(token), Prefix: prefix, Scopes: []string{ScopeAll}, StatusID: db.StatusEnabled})
+		So(err, ShouldBeNil)
+
+		handler := HTTPAuthMiddleware(repo, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			So(currentRole(r.Context()), ShouldEqual, workflow.RoleAdmin)
+		}))
+		serve := func() int {
+			r := httptest.NewRequest(http.MethodGet, "/v1/vfs/", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			return w.Code
+		}
+		So(serve(), ShouldEqual, http.StatusOK)
+
+		user.StatusID = db.StatusDisabled
+		_, err = repo.UpdateUser(ctx, user, db.WithColumns(db.Columns.User.StatusID))
+		So(err, ShouldBeNil)
+		So(serve(), ShouldEqual, http.StatusUnauthorized)
+
+		at, err := repo.EnabledAPITokenByHash(ctx, hashToken(token))
+		So(err, ShouldBeNil)
+		So(at, ShouldBeNil)
+	})
+}
@@ -11,50 +11,110 @@ import (
 )
 
 var RPC = struct {
-	CategoryService struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	NewsService     struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	TagService      struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
-	AuthService     struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }
-	UserService     struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }
+	AuditService    struct{ Count, Get, GetByID string }
+	AuthorService   struct{ Count, Get, GetByID, Add, Update, Dependencies, Delete, Validate string }
+	CategoryService struct{ Count, Get, GetByID, Add, Update, Dependencies, Delete, SetStatus, Validate, Tree, Move string }
+	NewsService     struct{ Count, Get, GetByID, Add, Update, Delete, SetStatus, BulkDelete, BulkMove, BulkAddTags, BulkRemoveTags, Validate, Revisions, RevisionDiff, RestoreRevision, Transition, Transitions, AvailableTransitions, Scheduled, States string }
+	TagService      struct{ Count, Get, GetByID, Add, Update, Dependencies, Delete, SetStatus, Merge, Validate string }
+	TrashService    struct{ Count, Get, GetByID, Restore, Purge, EntityTypes string }
+	AuthService     struct{ Login, Logout, Profile, ChangePassword, RequestPasswordReset, ResetPassword, VfsAuthToken string }
+	UserService     struct{ Count, Get, GetByID, Add, Invite, Update, Delete, Validate string }
+	APITokenService struct{ Count, Get, GetByID, Scopes, Add, Update, Delete, Validate string }
 }{
-	CategoryService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
-		Count:    "count",
-		Get:      "get",
-		GetByID:  "getbyid",
-		Add:      "add",
-		Update:   "update",
-		Delete:   "delete",
-		Validate: "validate",
+	AuditService: struct{ Count, Get, GetByID string }{
+		Count:   "count",
+		Get:     "get",
+		GetByID: "getbyid",
 	},
-	NewsService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
-		Count:    "count",
-		Get:      "get",
-		GetByID:  "getbyid",
-		Add:      "add",
-		Update:   "update",
-		Delete:   "delete",
-		Validate: "validate",
+	AuthorService: struct{ Count, Get, GetByID, Add, Update, Dependencies, Delete, Validate string }{
+		Count:        "count",
+		Get:          "get",
+		GetByID:      "getbyid",
+		Add:          "add",
+		Update:       "update",
+		Dependencies: "dependencies",
+		Delete:       "delete",
+		Validate:     "validate",
+	},
+	CategoryService: struct{ Count, Get, GetByID, Add, Update, Dependencies, Delete, SetStatus, Validate, Tree, Move string }{
+		Count:        "count",
+		Get:          "get",
+		GetByID:      "getbyid",
+		Add:          "add",
+		Update:       "update",
+		Dependencies: "dependencies",
+		Delete:       "delete",
+		SetStatus:    "setstatus",
+		Validate:     "validate",
+		Tree:         "tree",
+		Move:         "move",
+	},
+	NewsService: struct{ Count, Get, GetByID, Add, Update, Delete, SetStatus, BulkDelete, BulkMove, BulkAddTags, BulkRemoveTags, Validate, Revisions, RevisionDiff, RestoreRevision, Transition, Transitions, AvailableTransitions, Scheduled, States string }{
+		Count:                "count",
+		Get:                  "get",
+		GetByID:              "getbyid",
+		Add:                  "add",
+		Update:               "update",
+		Delete:               "delete",
+		SetStatus:            "setstatus",
+		BulkDelete:           "bulkdelete",
+		BulkMove:             "bulkmove",
+		BulkAddTags:          "bulkaddtags",
+		BulkRemoveTags:       "bulkremovetags",
+		Validate:             "validate",
+		Revisions:            "revisions",
+		RevisionDiff:         "revisiondiff",
+		RestoreRevision:      "restorerevision",
+		Transition:           "transition",
+		Transitions:          "transitions",
+		AvailableTransitions: "availabletransitions",
+		Scheduled:            "scheduled",
+		States:               "states",
 	},
-	TagService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
+	TagService: struct{ Count, Get, GetByID, Add, Update, Dependencies, Delete, SetStatus, Merge, Validate string }{
+		Count:        "count",
+		Get:          "get",
+		GetByID:      "getbyid",
+		Add:          "add",
+		Update:       "update",
+		Dependencies: "dependencies",
+		Delete:       "delete",
+		SetStatus:    "setstatus",
+		Merge:        "merge",
+		Validate:     "validate",
+	},
+	TrashService: struct{ Count, Get, GetByID, Restore, Purge, EntityTypes string }{
+		Count:       "count",
+		Get:         "get",
+		GetByID:     "getbyid",
+		Restore:     "restore",
+		Purge:       "purge",
+		EntityTypes: "entitytypes",
+	},
+	AuthService: struct{ Login, Logout, Profile, ChangePassword, RequestPasswordReset, ResetPassword, VfsAuthToken string }{
+		Login:                "login",
+		Logout:               "logout",
+		Profile:              "profile",
+		ChangePassword:       "changepassword",
+		RequestPasswordReset: "requestpasswordreset",
+		ResetPassword:        "resetpassword",
+		VfsAuthToken:         "vfsauthtoken",
+	},
+	UserService: struct{ Count, Get, GetByID, Add, Invite, Update, Delete, Validate string }{
 		Count:    "count",
 		Get:      "get",
 		GetByID:  "getbyid",
 		Add:      "add",
+		Invite:   "invite",
 		Update:   "update",
 		Delete:   "delete",
 		Validate: "validate",
 	},
-	AuthService: struct{ Login, Logout, Profile, ChangePassword, VfsAuthToken string }{
-		Login:          "login",
-		Logout:         "logout",
-		Profile:        "profile",
-		ChangePassword: "changepassword",
-		VfsAuthToken:   "vfsauthtoken",
-	},
-	UserService: struct{ Count, Get, GetByID, Add, Update, Delete, Validate string }{
+	APITokenService: struct{ Count, Get, GetByID, Scopes, Add, Update, Delete, Validate string }{
 		Count:    "count",
 		Get:      "get",
 		GetByID:  "getbyid",
+		Scopes:   "scopes",
 		Add:      "add",
 		Update:   "update",
 		Delete:   "delete",
@@ -62,18 +122,18 @@ var RPC = struct {
 	},
 }
 
-func (CategoryService) SMD() smd.ServiceInfo {
+func (AuditService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
 			"Count": {
-				Description: `Count returns count Categories according to conditions in search params.`,
+				Description: `Count AuditLogs according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `CategorySearch`,
+						Description: `AuditLogSearch`,
 						Type:        smd.Object,
-						TypeName:    "CategorySearch",
+						TypeName:    "AuditLogSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -81,25 +141,55 @@ func (CategoryService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "userId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "orderNumber",
+								Name:     "apiTokenId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "alias",
+								Name:     "namespace",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "statusId",
+								Name:     "method",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "entityType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "entityId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:     "requestId",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "ip",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "createdAtFrom",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "createdAtTo",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "ids",
 								Type: smd.Array,
@@ -107,11 +197,6 @@ func (CategoryService) SMD() smd.ServiceInfo {
 									"type": smd.Integer,
 								},
 							},
-							{
-								Name:     "notId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
 						},
 					},
 				},
@@ -124,14 +209,14 @@ func (CategoryService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Get": {
-				Description: `Get returns а list of Categories according to conditions in search params.`,
+				Description: `Get а list of AuditLogs according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `CategorySearch`,
+						Description: `AuditLogSearch`,
 						Type:        smd.Object,
-						TypeName:    "CategorySearch",
+						TypeName:    "AuditLogSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -139,25 +224,55 @@ func (CategoryService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "userId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "orderNumber",
+								Name:     "apiTokenId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "alias",
+								Name:     "namespace",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "statusId",
+								Name:     "method",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "entityType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "entityId",
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:     "requestId",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "ip",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "createdAtFrom",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "createdAtTo",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "ids",
 								Type: smd.Array,
@@ -165,11 +280,6 @@ func (CategoryService) SMD() smd.ServiceInfo {
 									"type": smd.Integer,
 								},
 							},
-							{
-								Name:     "notId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
 						},
 					},
 					{
@@ -203,14 +313,14 @@ func (CategoryService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]CategorySummary`,
+					Description: `[]AuditLogSummary`,
 					Type:        smd.Array,
-					TypeName:    "[]CategorySummary",
+					TypeName:    "[]AuditLogSummary",
 					Items: map[string]string{
-						"$ref": "#/definitions/CategorySummary",
+						"$ref": "#/definitions/AuditLogSummary",
 					},
 					Definitions: map[string]smd.Definition{
-						"CategorySummary": {
+						"AuditLogSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -218,16 +328,78 @@ func (CategoryService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "title",
+									Name: "createdAt",
 									Type: smd.String,
 								},
 								{
-									Name:     "orderNumber",
+									Name: "namespace",
+									Type: smd.String,
+								},
+								{
+									Name: "method",
+									Type: smd.String,
+								},
+								{
+									Name:     "entityType",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "entityId",
 									Optional: true,
 									Type:     smd.Integer,
 								},
 								{
-									Name: "alias",
+									Name:     "requestId",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "ip",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "apiToken",
+									Optional: true,
+									Ref:      "#/definitions/APITokenSummary",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
 									Type: smd.String,
 								},
 								{
@@ -255,6 +427,56 @@ func (CategoryService) SMD() smd.ServiceInfo {
 								},
 							},
 						},
+						"APITokenSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "prefix",
+									Type: smd.String,
+								},
+								{
+									Name: "scopes",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.String,
+									},
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
 					},
 				},
 				Errors: map[int]string{
@@ -262,7 +484,7 @@ func (CategoryService) SMD() smd.ServiceInfo {
 				},
 			},
 			"GetByID": {
-				Description: `GetByID returns a Category by its ID.`,
+				Description: `GetByID returns a AuditLog with entity snapshots by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -271,41 +493,80 @@ func (CategoryService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Category`,
+					Description: `AuditLog`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "Category",
+					TypeName:    "AuditLog",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
 						{
-							Name: "title",
+							Name: "createdAt",
 							Type: smd.String,
 						},
 						{
-							Name:     "orderNumber",
+							Name:     "userId",
 							Optional: true,
 							Type:     smd.Integer,
 						},
 						{
-							Name: "alias",
+							Name:     "apiTokenId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "namespace",
 							Type: smd.String,
 						},
 						{
-							Name: "statusId",
-							Type: smd.Integer,
+							Name: "method",
+							Type: smd.String,
 						},
 						{
-							Name:     "status",
+							Name:     "entityType",
 							Optional: true,
-							Ref:      "#/definitions/Status",
+							Type:     smd.String,
+						},
+						{
+							Name:     "entityId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "before",
+							Type: smd.Object,
+						},
+						{
+							Name: "after",
+							Type: smd.Object,
+						},
+						{
+							Name:     "requestId",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "ip",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "user",
+							Optional: true,
+							Ref:      "#/definitions/UserSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "apiToken",
+							Optional: true,
+							Ref:      "#/definitions/APITokenSummary",
 							Type:     smd.Object,
 						},
 					},
 					Definitions: map[string]smd.Definition{
-						"Status": {
+						"UserSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -313,115 +574,35 @@ func (CategoryService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "alias",
+									Name: "createdAt",
 									Type: smd.String,
 								},
 								{
-									Name: "title",
+									Name: "login",
 									Type: smd.String,
 								},
-							},
-						},
-					},
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					404: "Not Found",
-				},
-			},
-			"Add": {
-				Description: `Add adds a Category from the query.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "category",
-						Description: `Category`,
-						Type:        smd.Object,
-						TypeName:    "Category",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name:     "orderNumber",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name: "alias",
-								Type: smd.String,
-							},
-							{
-								Name: "statusId",
-								Type: smd.Integer,
-							},
-							{
-								Name:     "status",
-								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
-							},
-						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `Category`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "Category",
-					Properties: smd.PropertyList{
-						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "title",
-							Type: smd.String,
-						},
-						{
-							Name:     "orderNumber",
-							Optional: true,
-							Type:     smd.Integer,
-						},
-						{
-							Name: "alias",
-							Type: smd.String,
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
-						},
-					},
-					Definitions: map[string]smd.Definition{
 						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -439,198 +620,332 @@ func (CategoryService) SMD() smd.ServiceInfo {
 								},
 							},
 						},
+						"APITokenSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "prefix",
+									Type: smd.String,
+								},
+								{
+									Name: "scopes",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.String,
+									},
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
 					},
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					400: "Validation Error",
+					404: "Not Found",
 				},
 			},
-			"Update": {
-				Description: `Update updates the Category data identified by id from the query.`,
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s AuditService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.AuditService.Count:
+		var args = struct {
+			Search *AuditLogSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.AuditService.Get:
+		var args = struct {
+			Search  *AuditLogSearch `json:"search"`
+			ViewOps *ViewOps        `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.AuditService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (AuthorService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count Authors according to conditions in search params.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "category",
-						Type:     smd.Object,
-						TypeName: "Category",
+						Name:        "search",
+						Optional:    true,
+						Description: `AuthorSearch`,
+						Type:        smd.Object,
+						TypeName:    "AuthorSearch",
 						Properties: smd.PropertyList{
 							{
-								Name: "id",
-								Type: smd.Integer,
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "title",
-								Type: smd.String,
+								Name:     "name",
+								Optional: true,
+								Type:     smd.String,
 							},
 							{
-								Name:     "orderNumber",
+								Name:     "alias",
 								Optional: true,
-								Type:     smd.Integer,
+								Type:     smd.String,
 							},
 							{
-								Name: "alias",
-								Type: smd.String,
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "statusId",
-								Type: smd.Integer,
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
 							},
 							{
-								Name:     "status",
+								Name:     "notId",
 								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
-								},
-							},
-						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `Category`,
-					Type:        smd.Boolean,
-					TypeName:    "Category",
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
-				},
-			},
-			"Delete": {
-				Description: `Delete deletes the Category by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
-					Type:        smd.Boolean,
+					Description: `int`,
+					Type:        smd.Integer,
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
 				},
 			},
-			"Validate": {
-				Description: `Validate verifies that Category data is valid.`,
+			"Get": {
+				Description: `Get returns а list of Authors according to conditions in search params.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "category",
-						Description: `Category`,
+						Name:        "search",
+						Optional:    true,
+						Description: `AuthorSearch`,
 						Type:        smd.Object,
-						TypeName:    "Category",
+						TypeName:    "AuthorSearch",
 						Properties: smd.PropertyList{
 							{
-								Name: "id",
-								Type: smd.Integer,
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "title",
-								Type: smd.String,
+								Name:     "name",
+								Optional: true,
+								Type:     smd.String,
 							},
 							{
-								Name:     "orderNumber",
+								Name:     "alias",
 								Optional: true,
-								Type:     smd.Integer,
+								Type:     smd.String,
 							},
 							{
-								Name: "alias",
-								Type: smd.String,
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "statusId",
-								Type: smd.Integer,
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
 							},
 							{
-								Name:     "status",
+								Name:     "notId",
 								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
-								},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
 							},
 						},
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
+					Description: `[]AuthorSummary`,
 					Type:        smd.Array,
-					TypeName:    "[]FieldError",
+					TypeName:    "[]AuthorSummary",
 					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
+						"$ref": "#/definitions/AuthorSummary",
 					},
 					Definitions: map[string]smd.Definition{
-						"FieldError": {
+						"AuthorSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "field",
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "name",
 									Type: smd.String,
 								},
 								{
-									Name: "error",
+									Name: "alias",
 									Type: smd.String,
 								},
 								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
+									Name:     "avatar",
+									Optional: true,
+									Ref:      "#/definitions/VfsHashImage",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
-						"FieldErrorConstraint": {
+						"VfsHashImage": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
+									Name: "hash",
+									Type: smd.String,
 								},
 								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
+									Name: "webPath",
+									Type: smd.String,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
 								},
 							},
 						},
@@ -640,462 +955,228 @@ func (CategoryService) SMD() smd.ServiceInfo {
 					500: "Internal Error",
 				},
 			},
-		},
-	}
-}
-
-// Invoke is as generated code from zenrpc cmd
-func (s CategoryService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
-	resp := zenrpc.Response{}
-	var err error
-
-	switch method {
-	case RPC.CategoryService.Count:
-		var args = struct {
-			Search *CategorySearch `json:"search"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Count(ctx, args.Search))
-
-	case RPC.CategoryService.Get:
-		var args = struct {
-			Search  *CategorySearch `json:"search"`
-			ViewOps *ViewOps        `json:"viewOps"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
-
-	case RPC.CategoryService.GetByID:
-		var args = struct {
-			Id int `json:"id"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.GetByID(ctx, args.Id))
-
-	case RPC.CategoryService.Add:
-		var args = struct {
-			Category Category `json:"category"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Add(ctx, args.Category))
-
-	case RPC.CategoryService.Update:
-		var args = struct {
-			Category Category `json:"category"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Update(ctx, args.Category))
-
-	case RPC.CategoryService.Delete:
-		var args = struct {
-			Id int `json:"id"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Delete(ctx, args.Id))
-
-	case RPC.CategoryService.Validate:
-		var args = struct {
-			Category Category `json:"category"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Validate(ctx, args.Category))
-
-	default:
-		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
-	}
-
-	return resp
-}
-
-func (NewsService) SMD() smd.ServiceInfo {
-	return smd.ServiceInfo{
-		Methods: map[string]smd.Service{
-			"Count": {
-				Description: `Count returns count News according to conditions in search params.`,
+			"GetByID": {
+				Description: `GetByID returns a Author by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "search",
-						Optional:    true,
-						Description: `NewsSearch`,
-						Type:        smd.Object,
-						TypeName:    "NewsSearch",
-						Properties: smd.PropertyList{
-							{
-								Name:     "id",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name:     "title",
-								Optional: true,
-								Type:     smd.String,
-							},
-							{
-								Name:     "categoryId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name:     "foreword",
-								Optional: true,
-								Type:     smd.String,
-							},
-							{
-								Name:     "author",
-								Optional: true,
-								Type:     smd.String,
-							},
-							{
-								Name:     "publishedAt",
-								Optional: true,
-								Type:     smd.String,
-							},
-							{
-								Name:     "statusId",
-								Optional: true,
-								Type:     smd.Integer,
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Author`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Author",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "name",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name:     "bio",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "avatarHash",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "avatar",
+							Optional: true,
+							Ref:      "#/definitions/VfsHashImage",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"VfsHashImage": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "hash",
+									Type: smd.String,
+								},
+								{
+									Name: "webPath",
+									Type: smd.String,
+								},
 							},
-							{
-								Name: "ids",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
 								},
 							},
 						},
 					},
 				},
-				Returns: smd.JSONSchema{
-					Description: `int`,
-					Type:        smd.Integer,
-				},
 				Errors: map[int]string{
 					500: "Internal Error",
+					404: "Not Found",
 				},
 			},
-			"Get": {
-				Description: `Get returns а list of News according to conditions in search params.`,
+			"Add": {
+				Description: `Add adds a Author from the query.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "search",
-						Optional:    true,
-						Description: `NewsSearch`,
+						Name:        "author",
+						Description: `Author`,
 						Type:        smd.Object,
-						TypeName:    "NewsSearch",
+						TypeName:    "Author",
 						Properties: smd.PropertyList{
 							{
-								Name:     "id",
-								Optional: true,
-								Type:     smd.Integer,
-							},
-							{
-								Name:     "title",
-								Optional: true,
-								Type:     smd.String,
+								Name: "id",
+								Type: smd.Integer,
 							},
 							{
-								Name:     "categoryId",
-								Optional: true,
-								Type:     smd.Integer,
+								Name: "name",
+								Type: smd.String,
 							},
 							{
-								Name:     "foreword",
-								Optional: true,
-								Type:     smd.String,
+								Name: "alias",
+								Type: smd.String,
 							},
 							{
-								Name:     "author",
+								Name:     "bio",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "publishedAt",
+								Name:     "avatarHash",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "statusId",
-								Optional: true,
-								Type:     smd.Integer,
+								Name: "statusId",
+								Type: smd.Integer,
 							},
 							{
-								Name: "ids",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
+								Name: "version",
+								Type: smd.Integer,
 							},
-						},
-					},
-					{
-						Name:        "viewOps",
-						Optional:    true,
-						Description: `ViewOps`,
-						Type:        smd.Object,
-						TypeName:    "ViewOps",
-						Properties: smd.PropertyList{
 							{
-								Name:        "page",
-								Description: `page number, default - 1`,
-								Type:        smd.Integer,
+								Name:     "avatar",
+								Optional: true,
+								Ref:      "#/definitions/VfsHashImage",
+								Type:     smd.Object,
 							},
 							{
-								Name:        "pageSize",
-								Description: `items count per page, max - 500`,
-								Type:        smd.Integer,
-							},
-							{
-								Name:        "sortColumn",
-								Description: `sort by column name`,
-								Type:        smd.String,
-							},
-							{
-								Name:        "sortDesc",
-								Description: `descending sort`,
-								Type:        smd.Boolean,
-							},
-						},
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `[]NewsSummary`,
-					Type:        smd.Array,
-					TypeName:    "[]NewsSummary",
-					Items: map[string]string{
-						"$ref": "#/definitions/NewsSummary",
-					},
-					Definitions: map[string]smd.Definition{
-						"NewsSummary": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-								{
-									Name: "categoryId",
-									Type: smd.Integer,
-								},
-								{
-									Name: "author",
-									Type: smd.String,
-								},
-								{
-									Name: "publishedAt",
-									Type: smd.String,
-								},
-								{
-									Name:     "category",
-									Optional: true,
-									Ref:      "#/definitions/CategorySummary",
-									Type:     smd.Object,
-								},
-								{
-									Name:     "status",
-									Optional: true,
-									Ref:      "#/definitions/Status",
-									Type:     smd.Object,
-								},
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
 							},
 						},
-						"CategorySummary": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-								{
-									Name:     "orderNumber",
-									Optional: true,
-									Type:     smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
-								},
-								{
-									Name:     "status",
-									Optional: true,
-									Ref:      "#/definitions/Status",
-									Type:     smd.Object,
+						Definitions: map[string]smd.Definition{
+							"VfsHashImage": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "hash",
+										Type: smd.String,
+									},
+									{
+										Name: "webPath",
+										Type: smd.String,
+									},
 								},
 							},
-						},
-						"Status": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
 								},
 							},
 						},
 					},
 				},
-				Errors: map[int]string{
-					500: "Internal Error",
-				},
-			},
-			"GetByID": {
-				Description: `GetByID returns a News by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
-					},
-				},
 				Returns: smd.JSONSchema{
-					Description: `News`,
+					Description: `Author`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "News",
+					TypeName:    "Author",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
 						{
-							Name: "title",
+							Name: "name",
 							Type: smd.String,
 						},
 						{
-							Name: "categoryId",
-							Type: smd.Integer,
-						},
-						{
-							Name: "foreword",
+							Name: "alias",
 							Type: smd.String,
 						},
 						{
-							Name:     "content",
+							Name:     "bio",
 							Optional: true,
 							Type:     smd.String,
 						},
 						{
-							Name: "tagIds",
-							Type: smd.Array,
-							Items: map[string]string{
-								"type": smd.Integer,
-							},
-						},
-						{
-							Name: "author",
-							Type: smd.String,
+							Name:     "avatarHash",
+							Optional: true,
+							Type:     smd.String,
 						},
 						{
-							Name: "publishedAt",
-							Type: smd.String,
+							Name: "statusId",
+							Type: smd.Integer,
 						},
 						{
-							Name: "statusId",
+							Name: "version",
 							Type: smd.Integer,
 						},
 						{
-							Name:     "category",
+							Name:     "avatar",
 							Optional: true,
-							Ref:      "#/definitions/CategorySummary",
+							Ref:      "#/definitions/VfsHashImage",
 							Type:     smd.Object,
 						},
 						{
@@ -1106,32 +1187,17 @@ func (NewsService) SMD() smd.ServiceInfo {
 						},
 					},
 					Definitions: map[string]smd.Definition{
-						"CategorySummary": {
+						"VfsHashImage": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "title",
+									Name: "hash",
 									Type: smd.String,
 								},
 								{
-									Name:     "orderNumber",
-									Optional: true,
-									Type:     smd.Integer,
-								},
-								{
-									Name: "alias",
+									Name: "webPath",
 									Type: smd.String,
 								},
-								{
-									Name:     "status",
-									Optional: true,
-									Ref:      "#/definitions/Status",
-									Type:     smd.Object,
-								},
 							},
 						},
 						"Status": {
@@ -1155,62 +1221,51 @@ func (NewsService) SMD() smd.ServiceInfo {
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					404: "Not Found",
+					400: "Validation Error",
 				},
 			},
-			"Add": {
-				Description: `Add adds a News from the query.`,
+			"Update": {
+				Description: `Update updates the Author data identified by id from the query.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "news",
-						Description: `News`,
-						Type:        smd.Object,
-						TypeName:    "News",
+						Name:     "author",
+						Type:     smd.Object,
+						TypeName: "Author",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "title",
+								Name: "name",
 								Type: smd.String,
 							},
 							{
-								Name: "categoryId",
-								Type: smd.Integer,
-							},
-							{
-								Name: "foreword",
+								Name: "alias",
 								Type: smd.String,
 							},
 							{
-								Name:     "content",
+								Name:     "bio",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name: "tagIds",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
-							},
-							{
-								Name: "author",
-								Type: smd.String,
+								Name:     "avatarHash",
+								Optional: true,
+								Type:     smd.String,
 							},
 							{
-								Name: "publishedAt",
-								Type: smd.String,
+								Name: "statusId",
+								Type: smd.Integer,
 							},
 							{
-								Name: "statusId",
+								Name: "version",
 								Type: smd.Integer,
 							},
 							{
-								Name:     "category",
+								Name:     "avatar",
 								Optional: true,
-								Ref:      "#/definitions/CategorySummary",
+								Ref:      "#/definitions/VfsHashImage",
 								Type:     smd.Object,
 							},
 							{
@@ -1221,32 +1276,17 @@ func (NewsService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"CategorySummary": {
+							"VfsHashImage": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "title",
+										Name: "hash",
 										Type: smd.String,
 									},
 									{
-										Name:     "orderNumber",
-										Optional: true,
-										Type:     smd.Integer,
-									},
-									{
-										Name: "alias",
+										Name: "webPath",
 										Type: smd.String,
 									},
-									{
-										Name:     "status",
-										Optional: true,
-										Ref:      "#/definitions/Status",
-										Type:     smd.Object,
-									},
 								},
 							},
 							"Status": {
@@ -1270,169 +1310,110 @@ func (NewsService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `News`,
+					Description: `Author`,
+					Type:        smd.Boolean,
+					TypeName:    "Author",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Dependencies": {
+				Description: `Dependencies returns count of News of the Author.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Dependencies`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "News",
+					TypeName:    "Dependencies",
 					Properties: smd.PropertyList{
 						{
-							Name: "id",
+							Name: "news",
 							Type: smd.Integer,
 						},
 						{
-							Name: "title",
-							Type: smd.String,
-						},
-						{
-							Name: "categoryId",
-							Type: smd.Integer,
-						},
-						{
-							Name: "foreword",
-							Type: smd.String,
-						},
-						{
-							Name:     "content",
-							Optional: true,
-							Type:     smd.String,
-						},
-						{
-							Name: "tagIds",
-							Type: smd.Array,
-							Items: map[string]string{
-								"type": smd.Integer,
-							},
-						},
-						{
-							Name: "author",
-							Type: smd.String,
-						},
-						{
-							Name: "publishedAt",
-							Type: smd.String,
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-						{
-							Name:     "category",
-							Optional: true,
-							Ref:      "#/definitions/CategorySummary",
-							Type:     smd.Object,
-						},
-						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
+							Name:        "categories",
+							Description: `subcategories, only for Category`,
+							Type:        smd.Integer,
 						},
 					},
-					Definitions: map[string]smd.Definition{
-						"CategorySummary": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-								{
-									Name:     "orderNumber",
-									Optional: true,
-									Type:     smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
-								},
-								{
-									Name:     "status",
-									Optional: true,
-									Ref:      "#/definitions/Status",
-									Type:     smd.Object,
-								},
-							},
-						},
-						"Status": {
-							Type: "object",
-							Properties: smd.PropertyList{
-								{
-									Name: "id",
-									Type: smd.Integer,
-								},
-								{
-									Name: "alias",
-									Type: smd.String,
-								},
-								{
-									Name: "title",
-									Type: smd.String,
-								},
-							},
-						},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the Author by its ID. Links to News are kept, so Author could be restored from trash.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
 					},
 				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
 				Errors: map[int]string{
 					500: "Internal Error",
 					400: "Validation Error",
+					404: "Not Found",
 				},
 			},
-			"Update": {
-				Description: `Update updates the News data identified by id from the query.`,
+			"Validate": {
+				Description: `Validate verifies that Author data is valid.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "news",
-						Type:     smd.Object,
-						TypeName: "News",
+						Name:        "author",
+						Description: `Author`,
+						Type:        smd.Object,
+						TypeName:    "Author",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "title",
+								Name: "name",
 								Type: smd.String,
 							},
 							{
-								Name: "categoryId",
-								Type: smd.Integer,
-							},
-							{
-								Name: "foreword",
+								Name: "alias",
 								Type: smd.String,
 							},
 							{
-								Name:     "content",
+								Name:     "bio",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name: "tagIds",
-								Type: smd.Array,
-								Items: map[string]string{
-									"type": smd.Integer,
-								},
-							},
-							{
-								Name: "author",
-								Type: smd.String,
+								Name:     "avatarHash",
+								Optional: true,
+								Type:     smd.String,
 							},
 							{
-								Name: "publishedAt",
-								Type: smd.String,
+								Name: "statusId",
+								Type: smd.Integer,
 							},
 							{
-								Name: "statusId",
+								Name: "version",
 								Type: smd.Integer,
 							},
 							{
-								Name:     "category",
+								Name:     "avatar",
 								Optional: true,
-								Ref:      "#/definitions/CategorySummary",
+								Ref:      "#/definitions/VfsHashImage",
 								Type:     smd.Object,
 							},
 							{
@@ -1443,32 +1424,17 @@ func (NewsService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
-							"CategorySummary": {
+							"VfsHashImage": {
 								Type: "object",
 								Properties: smd.PropertyList{
 									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "title",
+										Name: "hash",
 										Type: smd.String,
 									},
 									{
-										Name:     "orderNumber",
-										Optional: true,
-										Type:     smd.Integer,
-									},
-									{
-										Name: "alias",
+										Name: "webPath",
 										Type: smd.String,
 									},
-									{
-										Name:     "status",
-										Optional: true,
-										Ref:      "#/definitions/Status",
-										Type:     smd.Object,
-									},
 								},
 							},
 							"Status": {
@@ -1492,186 +1458,5278 @@ func (NewsService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `News`,
-					Type:        smd.Boolean,
-					TypeName:    "News",
-				},
-				Errors: map[int]string{
-					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
-				},
-			},
-			"Delete": {
-				Description: `Delete deletes the News by its ID.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "id",
-						Description: `int`,
-						Type:        smd.Integer,
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
 					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `isDeleted`,
-					Type:        smd.Boolean,
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
-					400: "Validation Error",
-					404: "Not Found",
 				},
 			},
-			"Validate": {
-				Description: `Validate verifies that News data is valid.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "news",
-						Description: `News`,
-						Type:        smd.Object,
-						TypeName:    "News",
-						Properties: smd.PropertyList{
-							{
-								Name: "id",
-								Type: smd.Integer,
-							},
-							{
-								Name: "title",
-								Type: smd.String,
-							},
-							{
-								Name: "categoryId",
-								Type: smd.Integer,
-							},
-							{
-								Name: "foreword",
-								Type: smd.String,
-							},
-							{
-								Name:     "content",
-								Optional: true,
-								Type:     smd.String,
-							},
-							{
-								Name: "tagIds",
-								Type: smd.Array,
-								Items: map[string]string{
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s AuthorService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.AuthorService.Count:
+		var args = struct {
+			Search *AuthorSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.AuthorService.Get:
+		var args = struct {
+			Search  *AuthorSearch `json:"search"`
+			ViewOps *ViewOps      `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.AuthorService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.AuthorService.Add:
+		var args = struct {
+			Author Author `json:"author"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"author"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.Author))
+
+	case RPC.AuthorService.Update:
+		var args = struct {
+			Author Author `json:"author"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"author"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.Author))
+
+	case RPC.AuthorService.Dependencies:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Dependencies(ctx, args.Id))
+
+	case RPC.AuthorService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.AuthorService.Validate:
+		var args = struct {
+			Author Author `json:"author"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"author"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.Author))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (CategoryService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count Categories according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `CategorySearch`,
+						Type:        smd.Object,
+						TypeName:    "CategorySearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "orderNumber",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of Categories according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `CategorySearch`,
+						Type:        smd.Object,
+						TypeName:    "CategorySearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "orderNumber",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]CategorySummary`,
+					Type:        smd.Array,
+					TypeName:    "[]CategorySummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/CategorySummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a Category by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Category`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Category",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name:     "parentCategoryId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name:     "orderNumber",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "parentCategory",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a Category from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "category",
+						Description: `Category`,
+						Type:        smd.Object,
+						TypeName:    "Category",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "orderNumber",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "parentCategory",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "orderNumber",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Category`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Category",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name:     "parentCategoryId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name:     "orderNumber",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "parentCategory",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the Category data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "category",
+						Type:     smd.Object,
+						TypeName: "Category",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "orderNumber",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "parentCategory",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "orderNumber",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Category`,
+					Type:        smd.Boolean,
+					TypeName:    "Category",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Dependencies": {
+				Description: `Dependencies returns count of News in the Category.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Dependencies`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Dependencies",
+					Properties: smd.PropertyList{
+						{
+							Name: "news",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "categories",
+							Description: `subcategories, only for Category`,
+							Type:        smd.Integer,
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the Category by its ID.
+Category with News is deleted only with reassign strategy, News are moved to reassignTo Category in the same transaction.
+Category with subcategories could not be deleted, subcategories must be moved or deleted first.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "strategy",
+						Optional:    true,
+						Description: `delete strategy: restrict (default), reassign`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "reassignTo",
+						Optional:    true,
+						Description: `Category id for News of deleted Category, required for reassign strategy`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"SetStatus": {
+				Description: `SetStatus sets status of Categories in one transaction. Categories with News or subcategories could not be deleted.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
+						Type:        smd.Object,
+						TypeName:    "StatusUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that Category data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "category",
+						Description: `Category`,
+						Type:        smd.Object,
+						TypeName:    "Category",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name:     "parentCategoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "orderNumber",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "parentCategory",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "orderNumber",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Tree": {
+				Description: `Tree returns all Categories as a tree, siblings are ordered by orderNumber and title.
+Categories with deleted parent are returned as roots.`,
+				Parameters: []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]CategoryNode`,
+					Type:        smd.Array,
+					TypeName:    "[]CategoryNode",
+					Items: map[string]string{
+						"$ref": "#/definitions/CategoryNode",
+					},
+					Definitions: map[string]smd.Definition{
+						"CategoryNode": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+								{
+									Name: "children",
+									Type: smd.Array,
+									Items: map[string]string{
+										"$ref": "#/definitions/CategoryNode",
+									},
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Move": {
+				Description: `Move moves the Category to the position among children of the new parent, siblings orderNumber is renumbered from 1.
+All changes are made in one transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "move",
+						Description: `CategoryMove`,
+						Type:        smd.Object,
+						TypeName:    "CategoryMove",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name:        "parentCategoryId",
+								Optional:    true,
+								Description: `new parent, nil for root`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "position",
+								Description: `zero-based position among new siblings`,
+								Type:        smd.Integer,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isMoved`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s CategoryService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.CategoryService.Count:
+		var args = struct {
+			Search *CategorySearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.CategoryService.Get:
+		var args = struct {
+			Search  *CategorySearch `json:"search"`
+			ViewOps *ViewOps        `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.CategoryService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.CategoryService.Add:
+		var args = struct {
+			Category Category `json:"category"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.Category))
+
+	case RPC.CategoryService.Update:
+		var args = struct {
+			Category Category `json:"category"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.Category))
+
+	case RPC.CategoryService.Dependencies:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Dependencies(ctx, args.Id))
+
+	case RPC.CategoryService.Delete:
+		var args = struct {
+			Id         int     `json:"id"`
+			Strategy   *string `json:"strategy"`
+			ReassignTo *int    `json:"reassignTo"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "strategy", "reassignTo"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id, args.Strategy, args.ReassignTo))
+
+	case RPC.CategoryService.SetStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.SetStatus(ctx, args.StatusUpdate))
+
+	case RPC.CategoryService.Validate:
+		var args = struct {
+			Category Category `json:"category"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"category"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.Category))
+
+	case RPC.CategoryService.Tree:
+		resp.Set(s.Tree(ctx))
+
+	case RPC.CategoryService.Move:
+		var args = struct {
+			Move CategoryMove `json:"move"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"move"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Move(ctx, args.Move))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (NewsService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count News according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `NewsSearch`,
+						Type:        smd.Object,
+						TypeName:    "NewsSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "categoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "foreword",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "author",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "publishedAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "state",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "tagId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "authorId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "withSubcategories",
+								Description: `categoryId matches News of all its descendants too`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of News according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `NewsSearch`,
+						Type:        smd.Object,
+						TypeName:    "NewsSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "categoryId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "foreword",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "author",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "publishedAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "state",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "tagId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "authorId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "withSubcategories",
+								Description: `categoryId matches News of all its descendants too`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "authorIds",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name: "author",
+									Type: smd.String,
+								},
+								{
+									Name: "publishedAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "unpublishAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "state",
+									Type: smd.String,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/CategorySummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a News by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "News",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "categoryId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "foreword",
+							Type: smd.String,
+						},
+						{
+							Name:     "content",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "tagIds",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:        "authorIds",
+							Description: `co-authors, the first one is the main author`,
+							Type:        smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name: "author",
+							Type: smd.String,
+						},
+						{
+							Name: "publishedAt",
+							Type: smd.String,
+						},
+						{
+							Name:     "unpublishAt",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "state",
+							Description: `read-only, changed via transition method`,
+							Type:        smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "category",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a News from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "news",
+						Description: `News`,
+						Type:        smd.Object,
+						TypeName:    "News",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "foreword",
+								Type: smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "authorIds",
+								Description: `co-authors, the first one is the main author`,
+								Type:        smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name: "author",
+								Type: smd.String,
+							},
+							{
+								Name: "publishedAt",
+								Type: smd.String,
+							},
+							{
+								Name:     "unpublishAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:        "state",
+								Description: `read-only, changed via transition method`,
+								Type:        smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "category",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "orderNumber",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "News",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "categoryId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "foreword",
+							Type: smd.String,
+						},
+						{
+							Name:     "content",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "tagIds",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:        "authorIds",
+							Description: `co-authors, the first one is the main author`,
+							Type:        smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name: "author",
+							Type: smd.String,
+						},
+						{
+							Name: "publishedAt",
+							Type: smd.String,
+						},
+						{
+							Name:     "unpublishAt",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "state",
+							Description: `read-only, changed via transition method`,
+							Type:        smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "category",
+							Optional: true,
+							Ref:      "#/definitions/CategorySummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the News data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "news",
+						Type:     smd.Object,
+						TypeName: "News",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "foreword",
+								Type: smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "authorIds",
+								Description: `co-authors, the first one is the main author`,
+								Type:        smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name: "author",
+								Type: smd.String,
+							},
+							{
+								Name: "publishedAt",
+								Type: smd.String,
+							},
+							{
+								Name:     "unpublishAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:        "state",
+								Description: `read-only, changed via transition method`,
+								Type:        smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "category",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "orderNumber",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `News`,
+					Type:        smd.Boolean,
+					TypeName:    "News",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					403: "Forbidden",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the News by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					403: "Forbidden",
+					404: "Not Found",
+				},
+			},
+			"SetStatus": {
+				Description: `SetStatus sets status of News in one transaction.
+Only published News could be enabled, published News could not be disabled: unpublish them first.
+Authors could change draft News only.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
+						Type:        smd.Object,
+						TypeName:    "StatusUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"BulkDelete": {
+				Description: `BulkDelete deletes News by their IDs in one transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "ids",
+						Description: `[]int`,
+						Type:        smd.Array,
+						TypeName:    "[]",
+						Items: map[string]string{
+							"type": smd.Integer,
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"BulkMove": {
+				Description: `BulkMove moves News to the Category in one transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "categoryUpdate",
+						Description: `NewsCategoryUpdate`,
+						Type:        smd.Object,
+						TypeName:    "NewsCategoryUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"BulkAddTags": {
+				Description: `BulkAddTags adds Tags to News in one transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tagsUpdate",
+						Description: `NewsTagsUpdate`,
+						Type:        smd.Object,
+						TypeName:    "NewsTagsUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"BulkRemoveTags": {
+				Description: `BulkRemoveTags removes Tags from News in one transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tagsUpdate",
+						Description: `NewsTagsUpdate`,
+						Type:        smd.Object,
+						TypeName:    "NewsTagsUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that News data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "news",
+						Description: `News`,
+						Type:        smd.Object,
+						TypeName:    "News",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "categoryId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "foreword",
+								Type: smd.String,
+							},
+							{
+								Name:     "content",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "tagIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name:        "authorIds",
+								Description: `co-authors, the first one is the main author`,
+								Type:        smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name: "author",
+								Type: smd.String,
+							},
+							{
+								Name: "publishedAt",
+								Type: smd.String,
+							},
+							{
+								Name:     "unpublishAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name:        "state",
+								Description: `read-only, changed via transition method`,
+								Type:        smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "category",
+								Optional: true,
+								Ref:      "#/definitions/CategorySummary",
+								Type:     smd.Object,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"CategorySummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+									{
+										Name:     "parentCategoryId",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name:     "orderNumber",
+										Optional: true,
+										Type:     smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Revisions": {
+				Description: `Revisions returns a list of News revisions, the latest revision is first.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsRevisionSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsRevisionSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsRevisionSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsRevisionSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "revision",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"RevisionDiff": {
+				Description: `RevisionDiff returns changed fields and text diff between two News revisions.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "fromRev",
+						Description: `revision number to compare from`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "toRev",
+						Description: `revision number to compare to`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `NewsRevisionDiff`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "NewsRevisionDiff",
+					Properties: smd.PropertyList{
+						{
+							Name: "newsId",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "from",
+							Optional: true,
+							Ref:      "#/definitions/NewsRevision",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "to",
+							Optional: true,
+							Ref:      "#/definitions/NewsRevision",
+							Type:     smd.Object,
+						},
+						{
+							Name: "fields",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/NewsFieldDiff",
+							},
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsRevision": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "newsId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "revision",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "foreword",
+									Type: smd.String,
+								},
+								{
+									Name:     "content",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "tagIds",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name: "authorIds",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name: "author",
+									Type: smd.String,
+								},
+								{
+									Name: "publishedAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "unpublishAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "statusId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"NewsFieldDiff": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "old",
+									Type: smd.Object,
+								},
+								{
+									Name: "new",
+									Type: smd.Object,
+								},
+								{
+									Name: "text",
+									Type: smd.Array,
+									Items: map[string]string{
+										"$ref": "#/definitions/DiffChunk",
+									},
+								},
+							},
+						},
+						"DiffChunk": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "op",
+									Type: smd.String,
+								},
+								{
+									Name: "text",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"RestoreRevision": {
+				Description: `RestoreRevision restores News fields from the revision. News status is not changed.
+Restored News is validated and saved as a new revision.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "revision",
+						Description: `revision number`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `bool`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					403: "Forbidden",
+					404: "Not Found",
+				},
+			},
+			"Transition": {
+				Description: `Transition moves News to the new workflow state.
+Allowed transitions depend on current user role, some of them require a reason.
+News becomes enabled when it is published and disabled when it leaves published state.
+If News was changed concurrently, version conflict is returned.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "state",
+						Description: `new state: draft, review, approved, scheduled, published, archived`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "reason",
+						Optional:    true,
+						Description: `comment for transition`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `bool`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					403: "Forbidden",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Transitions": {
+				Description: `Transitions returns a history of News state changes, the latest change is first.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]NewsTransition`,
+					Type:        smd.Array,
+					TypeName:    "[]NewsTransition",
+					Items: map[string]string{
+						"$ref": "#/definitions/NewsTransition",
+					},
+					Definitions: map[string]smd.Definition{
+						"NewsTransition": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "newsId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "fromState",
+									Type: smd.String,
+								},
+								{
+									Name: "toState",
+									Type: smd.String,
+								},
+								{
+									Name:     "reason",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "userId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "apiTokenId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"AvailableTransitions": {
+				Description: `AvailableTransitions returns states that current user could move News to.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]AvailableTransition`,
+					Type:        smd.Array,
+					TypeName:    "[]AvailableTransition",
+					Items: map[string]string{
+						"$ref": "#/definitions/AvailableTransition",
+					},
+					Definitions: map[string]smd.Definition{
+						"AvailableTransition": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "state",
+									Type: smd.String,
+								},
+								{
+									Name: "reasonRequired",
+									Type: smd.Boolean,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Scheduled": {
+				Description: `Scheduled returns upcoming automatic publications and unpublications of News ordered by time.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "limit",
+						Optional:    true,
+						Description: `max number of actions, default is 50`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]ScheduledAction`,
+					Type:        smd.Array,
+					TypeName:    "[]ScheduledAction",
+					Items: map[string]string{
+						"$ref": "#/definitions/ScheduledAction",
+					},
+					Definitions: map[string]smd.Definition{
+						"ScheduledAction": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "action",
+									Type: smd.String,
+								},
+								{
+									Name: "at",
+									Type: smd.String,
+								},
+								{
+									Name:     "news",
+									Optional: true,
+									Ref:      "#/definitions/NewsSummary",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"NewsSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "categoryId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "authorIds",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name: "author",
+									Type: smd.String,
+								},
+								{
+									Name: "publishedAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "unpublishAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "state",
+									Type: smd.String,
+								},
+								{
+									Name:     "category",
+									Optional: true,
+									Ref:      "#/definitions/CategorySummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"CategorySummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "parentCategoryId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "orderNumber",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"States": {
+				Description: `States returns all News workflow states in lifecycle order.`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]string`,
+					Type:        smd.Array,
+					TypeName:    "[]",
+					Items: map[string]string{
+						"type": smd.String,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s NewsService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.NewsService.Count:
+		var args = struct {
+			Search *NewsSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.NewsService.Get:
+		var args = struct {
+			Search  *NewsSearch `json:"search"`
+			ViewOps *ViewOps    `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.NewsService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.NewsService.Add:
+		var args = struct {
+			News News `json:"news"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.News))
+
+	case RPC.NewsService.Update:
+		var args = struct {
+			News News `json:"news"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.News))
+
+	case RPC.NewsService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.NewsService.SetStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.SetStatus(ctx, args.StatusUpdate))
+
+	case RPC.NewsService.BulkDelete:
+		var args = struct {
+			Ids []int `json:"ids"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"ids"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.BulkDelete(ctx, args.Ids))
+
+	case RPC.NewsService.BulkMove:
+		var args = struct {
+			CategoryUpdate NewsCategoryUpdate `json:"categoryUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"categoryUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.BulkMove(ctx, args.CategoryUpdate))
+
+	case RPC.NewsService.BulkAddTags:
+		var args = struct {
+			TagsUpdate NewsTagsUpdate `json:"tagsUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tagsUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.BulkAddTags(ctx, args.TagsUpdate))
+
+	case RPC.NewsService.BulkRemoveTags:
+		var args = struct {
+			TagsUpdate NewsTagsUpdate `json:"tagsUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tagsUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.BulkRemoveTags(ctx, args.TagsUpdate))
+
+	case RPC.NewsService.Validate:
+		var args = struct {
+			News News `json:"news"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.News))
+
+	case RPC.NewsService.Revisions:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Revisions(ctx, args.Id))
+
+	case RPC.NewsService.RevisionDiff:
+		var args = struct {
+			Id      int `json:"id"`
+			FromRev int `json:"fromRev"`
+			ToRev   int `json:"toRev"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "fromRev", "toRev"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.RevisionDiff(ctx, args.Id, args.FromRev, args.ToRev))
+
+	case RPC.NewsService.RestoreRevision:
+		var args = struct {
+			Id       int `json:"id"`
+			Revision int `json:"revision"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "revision"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.RestoreRevision(ctx, args.Id, args.Revision))
+
+	case RPC.NewsService.Transition:
+		var args = struct {
+			Id     int     `json:"id"`
+			State  string  `json:"state"`
+			Reason *string `json:"reason"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "state", "reason"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Transition(ctx, args.Id, args.State, args.Reason))
+
+	case RPC.NewsService.Transitions:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Transitions(ctx, args.Id))
+
+	case RPC.NewsService.AvailableTransitions:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.AvailableTransitions(ctx, args.Id))
+
+	case RPC.NewsService.Scheduled:
+		var args = struct {
+			Limit *int `json:"limit"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"limit"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Scheduled(ctx, args.Limit))
+
+	case RPC.NewsService.States:
+		resp.Set(s.States())
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (TagService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count returns count Tags according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TagSearch`,
+						Type:        smd.Object,
+						TypeName:    "TagSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get returns а list of Tags according to conditions in search params.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TagSearch`,
+						Type:        smd.Object,
+						TypeName:    "TagSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "alias",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "statusId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]TagSummary`,
+					Type:        smd.Array,
+					TypeName:    "[]TagSummary",
+					Items: map[string]string{
+						"$ref": "#/definitions/TagSummary",
+					},
+					Definitions: map[string]smd.Definition{
+						"TagSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a Tag by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Tag`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Tag",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Add": {
+				Description: `Add adds a Tag from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tag",
+						Description: `Tag`,
+						Type:        smd.Object,
+						TypeName:    "Tag",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Tag`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Tag",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "alias",
+							Type: smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
+						{
+							Name:     "status",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Update": {
+				Description: `Update updates the Tag data identified by id from the query.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:     "tag",
+						Type:     smd.Object,
+						TypeName: "Tag",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Tag`,
+					Type:        smd.Boolean,
+					TypeName:    "Tag",
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+					409: "Version Conflict",
+				},
+			},
+			"Dependencies": {
+				Description: `Dependencies returns count of News with the Tag.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Dependencies`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "Dependencies",
+					Properties: smd.PropertyList{
+						{
+							Name: "news",
+							Type: smd.Integer,
+						},
+						{
+							Name:        "categories",
+							Description: `subcategories, only for Category`,
+							Type:        smd.Integer,
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Delete": {
+				Description: `Delete deletes the Tag by its ID.
+Tag used by News is deleted only with strip strategy, the Tag is removed from all News in the same transaction.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "strategy",
+						Optional:    true,
+						Description: `delete strategy: restrict (default), strip`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isDeleted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
+			"SetStatus": {
+				Description: `SetStatus sets status of Tags in one transaction. Tags used by News could not be deleted.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "statusUpdate",
+						Description: `StatusUpdate`,
+						Type:        smd.Object,
+						TypeName:    "StatusUpdate",
+						Properties: smd.PropertyList{
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]BulkResult`,
+					Type:        smd.Array,
+					TypeName:    "[]BulkResult",
+					Items: map[string]string{
+						"$ref": "#/definitions/BulkResult",
+					},
+					Definitions: map[string]smd.Definition{
+						"BulkResult": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "result",
+									Type: smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Merge": {
+				Description: `Merge merges source Tags into the target Tag in one transaction: News get the target Tag instead of source ones,
+source Tags are removed and recorded as merged, so their ids are resolved to the target Tag in public API.
+Revision is saved for every changed News.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "merge",
+						Description: `TagMerge`,
+						Type:        smd.Object,
+						TypeName:    "TagMerge",
+						Properties: smd.PropertyList{
+							{
+								Name: "sourceIds",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+							{
+								Name: "targetId",
+								Type: smd.Integer,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `count of changed News`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+				},
+			},
+			"Validate": {
+				Description: `Validate verifies that Tag data is valid.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "tag",
+						Description: `Tag`,
+						Type:        smd.Object,
+						TypeName:    "Tag",
+						Properties: smd.PropertyList{
+							{
+								Name: "id",
+								Type: smd.Integer,
+							},
+							{
+								Name: "title",
+								Type: smd.String,
+							},
+							{
+								Name: "alias",
+								Type: smd.String,
+							},
+							{
+								Name: "statusId",
+								Type: smd.Integer,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
+							{
+								Name:     "status",
+								Optional: true,
+								Ref:      "#/definitions/Status",
+								Type:     smd.Object,
+							},
+						},
+						Definitions: map[string]smd.Definition{
+							"Status": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "alias",
+										Type: smd.String,
+									},
+									{
+										Name: "title",
+										Type: smd.String,
+									},
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]FieldError`,
+					Type:        smd.Array,
+					TypeName:    "[]FieldError",
+					Items: map[string]string{
+						"$ref": "#/definitions/FieldError",
+					},
+					Definitions: map[string]smd.Definition{
+						"FieldError": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "field",
+									Type: smd.String,
+								},
+								{
+									Name: "error",
+									Type: smd.String,
+								},
+								{
+									Name:        "constraint",
+									Optional:    true,
+									Description: `Help with generating an error message.`,
+									Ref:         "#/definitions/FieldErrorConstraint",
+									Type:        smd.Object,
+								},
+							},
+						},
+						"FieldErrorConstraint": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name:        "max",
+									Description: `Max value for field.`,
+									Type:        smd.Integer,
+								},
+								{
+									Name:        "min",
+									Description: `Min value for field.`,
+									Type:        smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s TagService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.TagService.Count:
+		var args = struct {
+			Search *TagSearch `json:"search"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Count(ctx, args.Search))
+
+	case RPC.TagService.Get:
+		var args = struct {
+			Search  *TagSearch `json:"search"`
+			ViewOps *ViewOps   `json:"viewOps"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"search", "viewOps"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.TagService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.TagService.Add:
+		var args = struct {
+			Tag Tag `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Add(ctx, args.Tag))
+
+	case RPC.TagService.Update:
+		var args = struct {
+			Tag Tag `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.Tag))
+
+	case RPC.TagService.Dependencies:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Dependencies(ctx, args.Id))
+
+	case RPC.TagService.Delete:
+		var args = struct {
+			Id       int     `json:"id"`
+			Strategy *string `json:"strategy"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "strategy"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id, args.Strategy))
+
+	case RPC.TagService.SetStatus:
+		var args = struct {
+			StatusUpdate StatusUpdate `json:"statusUpdate"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"statusUpdate"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.SetStatus(ctx, args.StatusUpdate))
+
+	case RPC.TagService.Merge:
+		var args = struct {
+			Merge TagMerge `json:"merge"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"merge"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Merge(ctx, args.Merge))
+
+	case RPC.TagService.Validate:
+		var args = struct {
+			Tag Tag `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Validate(ctx, args.Tag))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (TrashService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Count": {
+				Description: `Count TrashItems according to conditions in search params`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TrashItemSearch`,
+						Type:        smd.Object,
+						TypeName:    "TrashItemSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "entityType",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "entityId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "userId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
+							{
+								Name:     "deletedAtTo",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
 									"type": smd.Integer,
 								},
 							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `int`,
+					Type:        smd.Integer,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"Get": {
+				Description: `Get а list of deleted entities according to conditions in search params`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "search",
+						Optional:    true,
+						Description: `TrashItemSearch`,
+						Type:        smd.Object,
+						TypeName:    "TrashItemSearch",
+						Properties: smd.PropertyList{
+							{
+								Name:     "id",
+								Optional: true,
+								Type:     smd.Integer,
+							},
 							{
-								Name: "author",
-								Type: smd.String,
+								Name:     "entityType",
+								Optional: true,
+								Type:     smd.String,
 							},
 							{
-								Name: "publishedAt",
-								Type: smd.String,
+								Name:     "entityId",
+								Optional: true,
+								Type:     smd.Integer,
 							},
 							{
-								Name: "statusId",
-								Type: smd.Integer,
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
 							},
 							{
-								Name:     "category",
+								Name:     "userId",
 								Optional: true,
-								Ref:      "#/definitions/CategorySummary",
-								Type:     smd.Object,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "status",
+								Name:     "deletedAtTo",
 								Optional: true,
-								Ref:      "#/definitions/Status",
-								Type:     smd.Object,
+								Type:     smd.String,
+							},
+							{
+								Name: "ids",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+					{
+						Name:        "viewOps",
+						Optional:    true,
+						Description: `ViewOps`,
+						Type:        smd.Object,
+						TypeName:    "ViewOps",
+						Properties: smd.PropertyList{
+							{
+								Name:        "page",
+								Description: `page number, default - 1`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "pageSize",
+								Description: `items count per page, max - 500`,
+								Type:        smd.Integer,
+							},
+							{
+								Name:        "sortColumn",
+								Description: `sort by column name`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "sortDesc",
+								Description: `descending sort`,
+								Type:        smd.Boolean,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `[]TrashItem`,
+					Type:        smd.Array,
+					TypeName:    "[]TrashItem",
+					Items: map[string]string{
+						"$ref": "#/definitions/TrashItem",
+					},
+					Definitions: map[string]smd.Definition{
+						"TrashItem": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "entityType",
+									Type: smd.String,
+								},
+								{
+									Name: "entityId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "previousStatusId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "deletedAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "userId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "apiTokenId",
+									Optional: true,
+									Type:     smd.Integer,
+								},
+								{
+									Name:     "previousStatus",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "apiToken",
+									Optional: true,
+									Ref:      "#/definitions/APITokenSummary",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"Status": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
+									Type: smd.String,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
 							},
 						},
-						Definitions: map[string]smd.Definition{
-							"CategorySummary": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
-									},
-									{
-										Name:     "orderNumber",
-										Optional: true,
-										Type:     smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name:     "status",
-										Optional: true,
-										Ref:      "#/definitions/Status",
-										Type:     smd.Object,
-									},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
-							"Status": {
-								Type: "object",
-								Properties: smd.PropertyList{
-									{
-										Name: "id",
-										Type: smd.Integer,
-									},
-									{
-										Name: "alias",
-										Type: smd.String,
-									},
-									{
-										Name: "title",
-										Type: smd.String,
+						},
+						"APITokenSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "prefix",
+									Type: smd.String,
+								},
+								{
+									Name: "scopes",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.String,
 									},
 								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
 							},
 						},
 					},
 				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"GetByID": {
+				Description: `GetByID returns a TrashItem by its ID.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
 				Returns: smd.JSONSchema{
-					Description: `[]FieldError`,
-					Type:        smd.Array,
-					TypeName:    "[]FieldError",
-					Items: map[string]string{
-						"$ref": "#/definitions/FieldError",
+					Description: `TrashItem`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "TrashItem",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "entityType",
+							Type: smd.String,
+						},
+						{
+							Name: "entityId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name: "previousStatusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "deletedAt",
+							Type: smd.String,
+						},
+						{
+							Name:     "userId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name:     "apiTokenId",
+							Optional: true,
+							Type:     smd.Integer,
+						},
+						{
+							Name:     "previousStatus",
+							Optional: true,
+							Ref:      "#/definitions/Status",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "user",
+							Optional: true,
+							Ref:      "#/definitions/UserSummary",
+							Type:     smd.Object,
+						},
+						{
+							Name:     "apiToken",
+							Optional: true,
+							Ref:      "#/definitions/APITokenSummary",
+							Type:     smd.Object,
+						},
 					},
 					Definitions: map[string]smd.Definition{
-						"FieldError": {
+						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name: "field",
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "alias",
 									Type: smd.String,
 								},
 								{
-									Name: "error",
+									Name: "title",
+									Type: smd.String,
+								},
+							},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
 									Type: smd.String,
 								},
 								{
-									Name:        "constraint",
-									Optional:    true,
-									Description: `Help with generating an error message.`,
-									Ref:         "#/definitions/FieldErrorConstraint",
-									Type:        smd.Object,
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
-						"FieldErrorConstraint": {
+						"APITokenSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
-									Name:        "max",
-									Description: `Max value for field.`,
-									Type:        smd.Integer,
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "prefix",
+									Type: smd.String,
+								},
+								{
+									Name: "scopes",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.String,
+									},
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
 								},
 								{
-									Name:        "min",
-									Description: `Min value for field.`,
-									Type:        smd.Integer,
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
 								},
 							},
 						},
@@ -1679,6 +6737,57 @@ func (NewsService) SMD() smd.ServiceInfo {
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Restore": {
+				Description: `Restore sets previous status of deleted entity and removes it from trash.
+It returns false if entity was already restored or removed in other way.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isRestored`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+				},
+			},
+			"Purge": {
+				Description: `Purge permanently removes deleted entity. Entity could not be purged while other entities reference it.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isPurged`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					404: "Not Found",
+					409: "Entity Is In Use",
+				},
+			},
+			"EntityTypes": {
+				Description: `EntityTypes returns list of entity types that could be in trash.`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]string`,
+					Type:        smd.Array,
+					TypeName:    "[]",
+					Items: map[string]string{
+						"type": smd.String,
+					},
 				},
 			},
 		},
@@ -1686,14 +6795,14 @@ func (NewsService) SMD() smd.ServiceInfo {
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s NewsService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s TrashService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.NewsService.Count:
+	case RPC.TrashService.Count:
 		var args = struct {
-			Search *NewsSearch `json:"search"`
+			Search *TrashItemSearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -1710,10 +6819,10 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.Count(ctx, args.Search))
 
-	case RPC.NewsService.Get:
+	case RPC.TrashService.Get:
 		var args = struct {
-			Search  *NewsSearch `json:"search"`
-			ViewOps *ViewOps    `json:"viewOps"`
+			Search  *TrashItemSearch `json:"search"`
+			ViewOps *ViewOps         `json:"viewOps"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -1728,15 +6837,249 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
+
+	case RPC.TrashService.GetByID:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.GetByID(ctx, args.Id))
+
+	case RPC.TrashService.Restore:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Restore(ctx, args.Id))
+
+	case RPC.TrashService.Purge:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Purge(ctx, args.Id))
+
+	case RPC.TrashService.EntityTypes:
+		resp.Set(s.EntityTypes())
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
+
+func (AuthService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Login": {
+				Description: `Login authenticates user.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "login",
+						Description: `User login`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "password",
+						Description: `User password`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "remember",
+						Description: `Remember for week`,
+						Type:        smd.Boolean,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `User authentication key`,
+					Type:        smd.String,
+				},
+				Errors: map[int]string{
+					400: "Invalid login or password",
+					500: "Internal Error",
+				},
+			},
+			"Logout": {
+				Description: `Logout current user from every session`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `Successful logout`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					401: "Invalid authentication credentials",
+					500: "Internal Error",
+				},
+			},
+			"Profile": {
+				Description: `Profile is a function that returns current user profile`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `UserProfile`,
+					Optional:    true,
+					Type:        smd.Object,
+					TypeName:    "UserProfile",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name: "login",
+							Type: smd.String,
+						},
+						{
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "lastActivityAt",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name: "statusId",
+							Type: smd.Integer,
+						},
+						{
+							Name: "role",
+							Type: smd.String,
+						},
+					},
+				},
+				Errors: map[int]string{
+					401: "Invalid authentication credentials",
+				},
+			},
+			"ChangePassword": {
+				Description: `ChangePassword changes current user password.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "password",
+						Description: `New user password`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `New user authentication key`,
+					Type:        smd.String,
+				},
+				Errors: map[int]string{
+					400: "Validation Error",
+					401: "Invalid authentication credentials",
+					500: "Internal Error",
+				},
+			},
+			"RequestPasswordReset": {
+				Description: `RequestPasswordReset sends one-time password reset link to user email.
+It always succeeds to prevent checking for existing users.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "login",
+						Description: `User login or email`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `Request accepted`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+				},
+			},
+			"ResetPassword": {
+				Description: `ResetPassword sets new password by one-time invitation or password reset token.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "token",
+						Description: `One-time token from email`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "password",
+						Description: `New user password`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `User authentication key`,
+					Type:        smd.String,
+				},
+				Errors: map[int]string{
+					400: "Invalid or expired token, Validation Error",
+					500: "Internal Error",
+				},
+			},
+			"VfsAuthToken": {
+				Description: `VfsAuthToken get auth token for VFS requests`,
+				Parameters:  []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Type: smd.String,
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s AuthService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
 
-	case RPC.NewsService.GetByID:
+	switch method {
+	case RPC.AuthService.Login:
 		var args = struct {
-			Id int `json:"id"`
+			Login    string `json:"login"`
+			Password string `json:"password"`
+			Remember bool   `json:"remember"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"login", "password", "remember"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -1747,34 +7090,21 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.GetByID(ctx, args.Id))
-
-	case RPC.NewsService.Add:
-		var args = struct {
-			News News `json:"news"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
+		resp.Set(s.Login(ctx, args.Login, args.Password, args.Remember))
 
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
+	case RPC.AuthService.Logout:
+		resp.Set(s.Logout(ctx))
 
-		resp.Set(s.Add(ctx, args.News))
+	case RPC.AuthService.Profile:
+		resp.Set(s.Profile(ctx))
 
-	case RPC.NewsService.Update:
+	case RPC.AuthService.ChangePassword:
 		var args = struct {
-			News News `json:"news"`
+			Password string `json:"password"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"password"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -1785,15 +7115,15 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Update(ctx, args.News))
+		resp.Set(s.ChangePassword(ctx, args.Password))
 
-	case RPC.NewsService.Delete:
+	case RPC.AuthService.RequestPasswordReset:
 		var args = struct {
-			Id int `json:"id"`
+			Login string `json:"login"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"login"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -1804,15 +7134,16 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Delete(ctx, args.Id))
+		resp.Set(s.RequestPasswordReset(ctx, args.Login))
 
-	case RPC.NewsService.Validate:
+	case RPC.AuthService.ResetPassword:
 		var args = struct {
-			News News `json:"news"`
+			Token    string `json:"token"`
+			Password string `json:"password"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"news"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"token", "password"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -1823,7 +7154,10 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Validate(ctx, args.News))
+		resp.Set(s.ResetPassword(ctx, args.Token, args.Password))
+
+	case RPC.AuthService.VfsAuthToken:
+		resp.Set(s.VfsAuthToken(ctx))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -1832,18 +7166,18 @@ func (s NewsService) Invoke(ctx context.Context, method string, params json.RawM
 	return resp
 }
 
-func (TagService) SMD() smd.ServiceInfo {
+func (UserService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
 			"Count": {
-				Description: `Count returns count Tags according to conditions in search params.`,
+				Description: `Count Users according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `TagSearch`,
+						Description: `UserSearch`,
 						Type:        smd.Object,
-						TypeName:    "TagSearch",
+						TypeName:    "UserSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -1851,7 +7185,12 @@ func (TagService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "login",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "email",
 								Optional: true,
 								Type:     smd.String,
 							},
@@ -1860,6 +7199,21 @@ func (TagService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:     "role",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAtFrom",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAtTo",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "ids",
 								Type: smd.Array,
@@ -1867,6 +7221,11 @@ func (TagService) SMD() smd.ServiceInfo {
 									"type": smd.Integer,
 								},
 							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
 						},
 					},
 				},
@@ -1879,14 +7238,14 @@ func (TagService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Get": {
-				Description: `Get returns а list of Tags according to conditions in search params.`,
+				Description: `Get а list of Users according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `TagSearch`,
+						Description: `UserSearch`,
 						Type:        smd.Object,
-						TypeName:    "TagSearch",
+						TypeName:    "UserSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -1894,7 +7253,12 @@ func (TagService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "title",
+								Name:     "login",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "email",
 								Optional: true,
 								Type:     smd.String,
 							},
@@ -1903,6 +7267,21 @@ func (TagService) SMD() smd.ServiceInfo {
 								Optional: true,
 								Type:     smd.Integer,
 							},
+							{
+								Name:     "role",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAtFrom",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAtTo",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "ids",
 								Type: smd.Array,
@@ -1910,6 +7289,11 @@ func (TagService) SMD() smd.ServiceInfo {
 									"type": smd.Integer,
 								},
 							},
+							{
+								Name:     "notId",
+								Optional: true,
+								Type:     smd.Integer,
+							},
 						},
 					},
 					{
@@ -1943,14 +7327,14 @@ func (TagService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]TagSummary`,
+					Description: `[]UserSummary`,
 					Type:        smd.Array,
-					TypeName:    "[]TagSummary",
+					TypeName:    "[]UserSummary",
 					Items: map[string]string{
-						"$ref": "#/definitions/TagSummary",
+						"$ref": "#/definitions/UserSummary",
 					},
 					Definitions: map[string]smd.Definition{
-						"TagSummary": {
+						"UserSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
 								{
@@ -1958,7 +7342,25 @@ func (TagService) SMD() smd.ServiceInfo {
 									Type: smd.Integer,
 								},
 								{
-									Name: "title",
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
 									Type: smd.String,
 								},
 								{
@@ -1993,7 +7395,7 @@ func (TagService) SMD() smd.ServiceInfo {
 				},
 			},
 			"GetByID": {
-				Description: `GetByID returns a Tag by its ID.`,
+				Description: `GetByID returns a User by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -2002,23 +7404,49 @@ func (TagService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Tag`,
+					Description: `User`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "Tag",
+					TypeName:    "User",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
 						{
-							Name: "title",
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name: "login",
+							Type: smd.String,
+						},
+						{
+							Name: "password",
 							Type: smd.String,
 						},
+						{
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "lastActivityAt",
+							Optional: true,
+							Type:     smd.String,
+						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name: "role",
+							Type: smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -2052,26 +7480,52 @@ func (TagService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Add": {
-				Description: `Add adds a Tag from the query.`,
+				Description: `Add a User from the query. If password is empty, invitation is sent to user email.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "tag",
-						Description: `Tag`,
+						Name:        "user",
+						Description: `User`,
 						Type:        smd.Object,
-						TypeName:    "Tag",
+						TypeName:    "User",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "title",
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "login",
+								Type: smd.String,
+							},
+							{
+								Name: "password",
 								Type: smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAt",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name: "role",
+								Type: smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -2101,23 +7555,49 @@ func (TagService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Tag`,
+					Description: `User`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "Tag",
+					TypeName:    "User",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
 						{
-							Name: "title",
+							Name: "createdAt",
+							Type: smd.String,
+						},
+						{
+							Name: "login",
+							Type: smd.String,
+						},
+						{
+							Name: "password",
 							Type: smd.String,
 						},
+						{
+							Name:     "email",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "lastActivityAt",
+							Optional: true,
+							Type:     smd.String,
+						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name: "role",
+							Type: smd.String,
+						},
+						{
+							Name: "version",
+							Type: smd.Integer,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -2150,26 +7630,71 @@ func (TagService) SMD() smd.ServiceInfo {
 					400: "Validation Error",
 				},
 			},
+			"Invite": {
+				Description: `Invite sends new invitation link to User email.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `int`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Description: `isSent`,
+					Type:        smd.Boolean,
+				},
+				Errors: map[int]string{
+					500: "Internal Error",
+					400: "Validation Error",
+					404: "Not Found",
+				},
+			},
 			"Update": {
-				Description: `Update updates the Tag data identified by id from the query.`,
+				Description: `Update updates the User data identified by id from the query`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "tag",
+						Name:     "user",
 						Type:     smd.Object,
-						TypeName: "Tag",
+						TypeName: "User",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "title",
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "login",
+								Type: smd.String,
+							},
+							{
+								Name: "password",
 								Type: smd.String,
 							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAt",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name: "role",
+								Type: smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -2199,18 +7724,19 @@ func (TagService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `Tag`,
+					Description: `User`,
 					Type:        smd.Boolean,
-					TypeName:    "Tag",
+					TypeName:    "User",
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
 					400: "Validation Error",
 					404: "Not Found",
+					409: "Version Conflict",
 				},
 			},
 			"Delete": {
-				Description: `Delete deletes the Tag by its ID.`,
+				Description: `Delete deletes the User by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -2229,26 +7755,52 @@ func (TagService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Validate": {
-				Description: `Validate verifies that Tag data is valid.`,
+				Description: `Validate Verifies that User data is valid.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "tag",
-						Description: `Tag`,
+						Name:        "user",
+						Description: `User`,
 						Type:        smd.Object,
-						TypeName:    "Tag",
+						TypeName:    "User",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "title",
+								Name: "createdAt",
+								Type: smd.String,
+							},
+							{
+								Name: "login",
 								Type: smd.String,
 							},
+							{
+								Name: "password",
+								Type: smd.String,
+							},
+							{
+								Name:     "email",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastActivityAt",
+								Optional: true,
+								Type:     smd.String,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name: "role",
+								Type: smd.String,
+							},
+							{
+								Name: "version",
+								Type: smd.Integer,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -2331,14 +7883,14 @@ func (TagService) SMD() smd.ServiceInfo {
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s TagService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s UserService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.TagService.Count:
+	case RPC.UserService.Count:
 		var args = struct {
-			Search *TagSearch `json:"search"`
+			Search *UserSearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -2355,10 +7907,10 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 
 		resp.Set(s.Count(ctx, args.Search))
 
-	case RPC.TagService.Get:
+	case RPC.UserService.Get:
 		var args = struct {
-			Search  *TagSearch `json:"search"`
-			ViewOps *ViewOps   `json:"viewOps"`
+			Search  *UserSearch `json:"search"`
+			ViewOps *ViewOps    `json:"viewOps"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -2375,7 +7927,7 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 
 		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
 
-	case RPC.TagService.GetByID:
+	case RPC.UserService.GetByID:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -2394,32 +7946,13 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 
 		resp.Set(s.GetByID(ctx, args.Id))
 
-	case RPC.TagService.Add:
-		var args = struct {
-			Tag Tag `json:"tag"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Add(ctx, args.Tag))
-
-	case RPC.TagService.Update:
+	case RPC.UserService.Add:
 		var args = struct {
-			Tag Tag `json:"tag"`
+			User User `json:"user"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"user"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2430,9 +7963,9 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 			}
 		}
 
-		resp.Set(s.Update(ctx, args.Tag))
+		resp.Set(s.Add(ctx, args.User))
 
-	case RPC.TagService.Delete:
+	case RPC.UserService.Invite:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -2445,161 +7978,19 @@ func (s TagService) Invoke(ctx context.Context, method string, params json.RawMe
 
 		if len(params) > 0 {
 			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Delete(ctx, args.Id))
-
-	case RPC.TagService.Validate:
-		var args = struct {
-			Tag Tag `json:"tag"`
-		}{}
-
-		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"tag"}, params); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		if len(params) > 0 {
-			if err := json.Unmarshal(params, &args); err != nil {
-				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
-			}
-		}
-
-		resp.Set(s.Validate(ctx, args.Tag))
-
-	default:
-		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
-	}
-
-	return resp
-}
-
-func (AuthService) SMD() smd.ServiceInfo {
-	return smd.ServiceInfo{
-		Methods: map[string]smd.Service{
-			"Login": {
-				Description: `Login authenticates user.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "login",
-						Description: `User login`,
-						Type:        smd.String,
-					},
-					{
-						Name:        "password",
-						Description: `User password`,
-						Type:        smd.String,
-					},
-					{
-						Name:        "remember",
-						Description: `Remember for week`,
-						Type:        smd.Boolean,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `User authentication key`,
-					Type:        smd.String,
-				},
-				Errors: map[int]string{
-					400: "Invalid login or password",
-					500: "Internal Error",
-				},
-			},
-			"Logout": {
-				Description: `Logout current user from every session`,
-				Parameters:  []smd.JSONSchema{},
-				Returns: smd.JSONSchema{
-					Description: `Successful logout`,
-					Type:        smd.Boolean,
-				},
-				Errors: map[int]string{
-					401: "Invalid authentication credentials",
-					500: "Internal Error",
-				},
-			},
-			"Profile": {
-				Description: `Profile is a function that returns current user profile`,
-				Parameters:  []smd.JSONSchema{},
-				Returns: smd.JSONSchema{
-					Description: `UserProfile`,
-					Optional:    true,
-					Type:        smd.Object,
-					TypeName:    "UserProfile",
-					Properties: smd.PropertyList{
-						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "createdAt",
-							Type: smd.String,
-						},
-						{
-							Name: "login",
-							Type: smd.String,
-						},
-						{
-							Name:     "lastActivityAt",
-							Optional: true,
-							Type:     smd.String,
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
-						},
-					},
-				},
-				Errors: map[int]string{
-					401: "Invalid authentication credentials",
-				},
-			},
-			"ChangePassword": {
-				Description: `ChangePassword changes current user password.`,
-				Parameters: []smd.JSONSchema{
-					{
-						Name:        "password",
-						Description: `New user password`,
-						Type:        smd.String,
-					},
-				},
-				Returns: smd.JSONSchema{
-					Description: `New user authentication key`,
-					Type:        smd.String,
-				},
-				Errors: map[int]string{
-					401: "Invalid authentication credentials",
-					500: "Internal Error",
-				},
-			},
-			"VfsAuthToken": {
-				Description: `VfsAuthToken get auth token for VFS requests`,
-				Parameters:  []smd.JSONSchema{},
-				Returns: smd.JSONSchema{
-					Type: smd.String,
-				},
-			},
-		},
-	}
-}
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
 
-// Invoke is as generated code from zenrpc cmd
-func (s AuthService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
-	resp := zenrpc.Response{}
-	var err error
+		resp.Set(s.Invite(ctx, args.Id))
 
-	switch method {
-	case RPC.AuthService.Login:
+	case RPC.UserService.Update:
 		var args = struct {
-			Login    string `json:"login"`
-			Password string `json:"password"`
-			Remember bool   `json:"remember"`
+			User User `json:"user"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"login", "password", "remember"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"user"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2610,21 +8001,34 @@ func (s AuthService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Login(ctx, args.Login, args.Password, args.Remember))
+		resp.Set(s.Update(ctx, args.User))
 
-	case RPC.AuthService.Logout:
-		resp.Set(s.Logout(ctx))
+	case RPC.UserService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
 
-	case RPC.AuthService.Profile:
-		resp.Set(s.Profile(ctx))
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
 
-	case RPC.AuthService.ChangePassword:
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.UserService.Validate:
 		var args = struct {
-			Password string `json:"password"`
+			User User `json:"user"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"password"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"user"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -2635,10 +8039,7 @@ func (s AuthService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.ChangePassword(ctx, args.Password))
-
-	case RPC.AuthService.VfsAuthToken:
-		resp.Set(s.VfsAuthToken(ctx))
+		resp.Set(s.Validate(ctx, args.User))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -2647,18 +8048,18 @@ func (s AuthService) Invoke(ctx context.Context, method string, params json.RawM
 	return resp
 }
 
-func (UserService) SMD() smd.ServiceInfo {
+func (APITokenService) SMD() smd.ServiceInfo {
 	return smd.ServiceInfo{
 		Methods: map[string]smd.Service{
 			"Count": {
-				Description: `Count Users according to conditions in search params`,
+				Description: `Count APITokens according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `UserSearch`,
+						Description: `APITokenSearch`,
 						Type:        smd.Object,
-						TypeName:    "UserSearch",
+						TypeName:    "APITokenSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -2666,24 +8067,24 @@ func (UserService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "login",
+								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "statusId",
+								Name:     "prefix",
 								Optional: true,
-								Type:     smd.Integer,
+								Type:     smd.String,
 							},
 							{
-								Name:     "lastActivityAtFrom",
+								Name:     "userId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "lastActivityAtTo",
+								Name:     "statusId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
 								Name: "ids",
@@ -2692,11 +8093,6 @@ func (UserService) SMD() smd.ServiceInfo {
 									"type": smd.Integer,
 								},
 							},
-							{
-								Name:     "notId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
 						},
 					},
 				},
@@ -2709,14 +8105,14 @@ func (UserService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Get": {
-				Description: `Get а list of Users according to conditions in search params`,
+				Description: `Get а list of APITokens according to conditions in search params`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "search",
 						Optional:    true,
-						Description: `UserSearch`,
+						Description: `APITokenSearch`,
 						Type:        smd.Object,
-						TypeName:    "UserSearch",
+						TypeName:    "APITokenSearch",
 						Properties: smd.PropertyList{
 							{
 								Name:     "id",
@@ -2724,24 +8120,24 @@ func (UserService) SMD() smd.ServiceInfo {
 								Type:     smd.Integer,
 							},
 							{
-								Name:     "login",
+								Name:     "title",
 								Optional: true,
 								Type:     smd.String,
 							},
 							{
-								Name:     "statusId",
+								Name:     "prefix",
 								Optional: true,
-								Type:     smd.Integer,
+								Type:     smd.String,
 							},
 							{
-								Name:     "lastActivityAtFrom",
+								Name:     "userId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
-								Name:     "lastActivityAtTo",
+								Name:     "statusId",
 								Optional: true,
-								Type:     smd.String,
+								Type:     smd.Integer,
 							},
 							{
 								Name: "ids",
@@ -2750,11 +8146,6 @@ func (UserService) SMD() smd.ServiceInfo {
 									"type": smd.Integer,
 								},
 							},
-							{
-								Name:     "notId",
-								Optional: true,
-								Type:     smd.Integer,
-							},
 						},
 					},
 					{
@@ -2788,13 +8179,63 @@ func (UserService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `[]UserSummary`,
+					Description: `[]APITokenSummary`,
 					Type:        smd.Array,
-					TypeName:    "[]UserSummary",
+					TypeName:    "[]APITokenSummary",
 					Items: map[string]string{
-						"$ref": "#/definitions/UserSummary",
+						"$ref": "#/definitions/APITokenSummary",
 					},
 					Definitions: map[string]smd.Definition{
+						"APITokenSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "prefix",
+									Type: smd.String,
+								},
+								{
+									Name: "scopes",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.String,
+									},
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
 						"UserSummary": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -2810,11 +8251,20 @@ func (UserService) SMD() smd.ServiceInfo {
 									Name: "login",
 									Type: smd.String,
 								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
 								{
 									Name:     "lastActivityAt",
 									Optional: true,
 									Type:     smd.String,
 								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
 								{
 									Name:     "status",
 									Optional: true,
@@ -2847,7 +8297,7 @@ func (UserService) SMD() smd.ServiceInfo {
 				},
 			},
 			"GetByID": {
-				Description: `GetByID returns a User by its ID.`,
+				Description: `GetByID returns a APIToken by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -2856,36 +8306,58 @@ func (UserService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `User`,
+					Description: `APIToken`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "User",
+					TypeName:    "APIToken",
 					Properties: smd.PropertyList{
 						{
 							Name: "id",
 							Type: smd.Integer,
 						},
 						{
-							Name: "createdAt",
+							Name: "title",
 							Type: smd.String,
 						},
 						{
-							Name: "login",
+							Name: "prefix",
 							Type: smd.String,
 						},
 						{
-							Name: "password",
+							Name: "scopes",
+							Type: smd.Array,
+							Items: map[string]string{
+								"type": smd.String,
+							},
+						},
+						{
+							Name: "createdAt",
 							Type: smd.String,
 						},
 						{
-							Name:     "lastActivityAt",
+							Name:     "expiresAt",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:     "lastUsedAt",
 							Optional: true,
 							Type:     smd.String,
 						},
+						{
+							Name: "userId",
+							Type: smd.Integer,
+						},
 						{
 							Name: "statusId",
 							Type: smd.Integer,
 						},
+						{
+							Name:     "user",
+							Optional: true,
+							Ref:      "#/definitions/UserSummary",
+							Type:     smd.Object,
+						},
 						{
 							Name:     "status",
 							Optional: true,
@@ -2894,6 +8366,43 @@ func (UserService) SMD() smd.ServiceInfo {
 						},
 					},
 					Definitions: map[string]smd.Definition{
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
 						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -2918,48 +8427,120 @@ func (UserService) SMD() smd.ServiceInfo {
 					404: "Not Found",
 				},
 			},
+			"Scopes": {
+				Description: `Scopes returns all scopes that could be assigned to APIToken.
+Scope is "*" for all namespaces, "<namespace>" for all namespace methods or "<namespace>:read" for read only methods.`,
+				Parameters: []smd.JSONSchema{},
+				Returns: smd.JSONSchema{
+					Description: `[]string`,
+					Type:        smd.Array,
+					TypeName:    "[]",
+					Items: map[string]string{
+						"type": smd.String,
+					},
+				},
+			},
 			"Add": {
-				Description: `Add a User from the query`,
+				Description: `Add creates a APIToken for current user. Plain token is returned only once and could not be restored.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "user",
-						Description: `User`,
+						Name:        "apiToken",
+						Description: `APIToken`,
 						Type:        smd.Object,
-						TypeName:    "User",
+						TypeName:    "APIToken",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "createdAt",
+								Name: "title",
 								Type: smd.String,
 							},
 							{
-								Name: "login",
+								Name: "prefix",
 								Type: smd.String,
 							},
 							{
-								Name: "password",
+								Name: "scopes",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.String,
+								},
+							},
+							{
+								Name: "createdAt",
 								Type: smd.String,
 							},
 							{
-								Name:     "lastActivityAt",
+								Name:     "expiresAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastUsedAt",
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name: "userId",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:     "user",
+								Optional: true,
+								Ref:      "#/definitions/UserSummary",
+								Type:     smd.Object,
+							},
 							{
 								Name:     "status",
 								Optional: true,
 								Ref:      "#/definitions/Status",
 								Type:     smd.Object,
 							},
-						},
-						Definitions: map[string]smd.Definition{
+						},
+						Definitions: map[string]smd.Definition{
+							"UserSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "createdAt",
+										Type: smd.String,
+									},
+									{
+										Name: "login",
+										Type: smd.String,
+									},
+									{
+										Name:     "email",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name:     "lastActivityAt",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "role",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
 							"Status": {
 								Type: "object",
 								Properties: smd.PropertyList{
@@ -2981,44 +8562,118 @@ func (UserService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `User`,
+					Description: `APITokenWithSecret`,
 					Optional:    true,
 					Type:        smd.Object,
-					TypeName:    "User",
+					TypeName:    "APITokenWithSecret",
 					Properties: smd.PropertyList{
 						{
-							Name: "id",
-							Type: smd.Integer,
-						},
-						{
-							Name: "createdAt",
-							Type: smd.String,
-						},
-						{
-							Name: "login",
-							Type: smd.String,
-						},
-						{
-							Name: "password",
-							Type: smd.String,
-						},
-						{
-							Name:     "lastActivityAt",
+							Name:     "apiToken",
 							Optional: true,
-							Type:     smd.String,
-						},
-						{
-							Name: "statusId",
-							Type: smd.Integer,
+							Ref:      "#/definitions/APIToken",
+							Type:     smd.Object,
 						},
 						{
-							Name:     "status",
-							Optional: true,
-							Ref:      "#/definitions/Status",
-							Type:     smd.Object,
+							Name: "token",
+							Type: smd.String,
 						},
 					},
 					Definitions: map[string]smd.Definition{
+						"APIToken": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name: "prefix",
+									Type: smd.String,
+								},
+								{
+									Name: "scopes",
+									Type: smd.Array,
+									Items: map[string]string{
+										"type": smd.String,
+									},
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name:     "expiresAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastUsedAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "userId",
+									Type: smd.Integer,
+								},
+								{
+									Name: "statusId",
+									Type: smd.Integer,
+								},
+								{
+									Name:     "user",
+									Optional: true,
+									Ref:      "#/definitions/UserSummary",
+									Type:     smd.Object,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
+						"UserSummary": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "createdAt",
+									Type: smd.String,
+								},
+								{
+									Name: "login",
+									Type: smd.String,
+								},
+								{
+									Name:     "email",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:     "lastActivityAt",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name: "role",
+									Type: smd.String,
+								},
+								{
+									Name:     "status",
+									Optional: true,
+									Ref:      "#/definitions/Status",
+									Type:     smd.Object,
+								},
+							},
+						},
 						"Status": {
 							Type: "object",
 							Properties: smd.PropertyList{
@@ -3041,41 +8696,65 @@ func (UserService) SMD() smd.ServiceInfo {
 				Errors: map[int]string{
 					500: "Internal Error",
 					400: "Validation Error",
+					401: "Unauthorized",
 				},
 			},
 			"Update": {
-				Description: `Update updates the User data identified by id from the query`,
+				Description: `Update updates title, scopes, expiration time and status of the APIToken identified by id from the query.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:     "user",
-						Type:     smd.Object,
-						TypeName: "User",
+						Name:        "apiToken",
+						Description: `APIToken`,
+						Type:        smd.Object,
+						TypeName:    "APIToken",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "createdAt",
+								Name: "title",
 								Type: smd.String,
 							},
 							{
-								Name: "login",
+								Name: "prefix",
 								Type: smd.String,
 							},
 							{
-								Name: "password",
+								Name: "scopes",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.String,
+								},
+							},
+							{
+								Name: "createdAt",
 								Type: smd.String,
 							},
 							{
-								Name:     "lastActivityAt",
+								Name:     "expiresAt",
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name:     "lastUsedAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name: "userId",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:     "user",
+								Optional: true,
+								Ref:      "#/definitions/UserSummary",
+								Type:     smd.Object,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -3084,6 +8763,43 @@ func (UserService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
+							"UserSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "createdAt",
+										Type: smd.String,
+									},
+									{
+										Name: "login",
+										Type: smd.String,
+									},
+									{
+										Name:     "email",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name:     "lastActivityAt",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "role",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
 							"Status": {
 								Type: "object",
 								Properties: smd.PropertyList{
@@ -3105,9 +8821,8 @@ func (UserService) SMD() smd.ServiceInfo {
 					},
 				},
 				Returns: smd.JSONSchema{
-					Description: `User`,
+					Description: `bool`,
 					Type:        smd.Boolean,
-					TypeName:    "User",
 				},
 				Errors: map[int]string{
 					500: "Internal Error",
@@ -3116,7 +8831,7 @@ func (UserService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Delete": {
-				Description: `Delete deletes the User by its ID.`,
+				Description: `Delete revokes the APIToken by its ID.`,
 				Parameters: []smd.JSONSchema{
 					{
 						Name:        "id",
@@ -3135,39 +8850,61 @@ func (UserService) SMD() smd.ServiceInfo {
 				},
 			},
 			"Validate": {
-				Description: `Validate Verifies that User data is valid.`,
+				Description: `Validate Verifies that APIToken data is valid.`,
 				Parameters: []smd.JSONSchema{
 					{
-						Name:        "user",
-						Description: `User`,
+						Name:        "apiToken",
+						Description: `APIToken`,
 						Type:        smd.Object,
-						TypeName:    "User",
+						TypeName:    "APIToken",
 						Properties: smd.PropertyList{
 							{
 								Name: "id",
 								Type: smd.Integer,
 							},
 							{
-								Name: "createdAt",
+								Name: "title",
 								Type: smd.String,
 							},
 							{
-								Name: "login",
+								Name: "prefix",
 								Type: smd.String,
 							},
 							{
-								Name: "password",
+								Name: "scopes",
+								Type: smd.Array,
+								Items: map[string]string{
+									"type": smd.String,
+								},
+							},
+							{
+								Name: "createdAt",
 								Type: smd.String,
 							},
 							{
-								Name:     "lastActivityAt",
+								Name:     "expiresAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:     "lastUsedAt",
 								Optional: true,
 								Type:     smd.String,
 							},
+							{
+								Name: "userId",
+								Type: smd.Integer,
+							},
 							{
 								Name: "statusId",
 								Type: smd.Integer,
 							},
+							{
+								Name:     "user",
+								Optional: true,
+								Ref:      "#/definitions/UserSummary",
+								Type:     smd.Object,
+							},
 							{
 								Name:     "status",
 								Optional: true,
@@ -3176,6 +8913,43 @@ func (UserService) SMD() smd.ServiceInfo {
 							},
 						},
 						Definitions: map[string]smd.Definition{
+							"UserSummary": {
+								Type: "object",
+								Properties: smd.PropertyList{
+									{
+										Name: "id",
+										Type: smd.Integer,
+									},
+									{
+										Name: "createdAt",
+										Type: smd.String,
+									},
+									{
+										Name: "login",
+										Type: smd.String,
+									},
+									{
+										Name:     "email",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name:     "lastActivityAt",
+										Optional: true,
+										Type:     smd.String,
+									},
+									{
+										Name: "role",
+										Type: smd.String,
+									},
+									{
+										Name:     "status",
+										Optional: true,
+										Ref:      "#/definitions/Status",
+										Type:     smd.Object,
+									},
+								},
+							},
 							"Status": {
 								Type: "object",
 								Properties: smd.PropertyList{
@@ -3250,14 +9024,14 @@ func (UserService) SMD() smd.ServiceInfo {
 }
 
 // Invoke is as generated code from zenrpc cmd
-func (s UserService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+func (s APITokenService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
 	resp := zenrpc.Response{}
 	var err error
 
 	switch method {
-	case RPC.UserService.Count:
+	case RPC.APITokenService.Count:
 		var args = struct {
-			Search *UserSearch `json:"search"`
+			Search *APITokenSearch `json:"search"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -3274,10 +9048,10 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.Count(ctx, args.Search))
 
-	case RPC.UserService.Get:
+	case RPC.APITokenService.Get:
 		var args = struct {
-			Search  *UserSearch `json:"search"`
-			ViewOps *ViewOps    `json:"viewOps"`
+			Search  *APITokenSearch `json:"search"`
+			ViewOps *ViewOps        `json:"viewOps"`
 		}{}
 
 		if zenrpc.IsArray(params) {
@@ -3294,7 +9068,7 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.Get(ctx, args.Search, args.ViewOps))
 
-	case RPC.UserService.GetByID:
+	case RPC.APITokenService.GetByID:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -3313,13 +9087,16 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.GetByID(ctx, args.Id))
 
-	case RPC.UserService.Add:
+	case RPC.APITokenService.Scopes:
+		resp.Set(s.Scopes())
+
+	case RPC.APITokenService.Add:
 		var args = struct {
-			User User `json:"user"`
+			ApiToken APIToken `json:"apiToken"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"user"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"apiToken"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -3330,15 +9107,15 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Add(ctx, args.User))
+		resp.Set(s.Add(ctx, args.ApiToken))
 
-	case RPC.UserService.Update:
+	case RPC.APITokenService.Update:
 		var args = struct {
-			User User `json:"user"`
+			ApiToken APIToken `json:"apiToken"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"user"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"apiToken"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -3349,9 +9126,9 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Update(ctx, args.User))
+		resp.Set(s.Update(ctx, args.ApiToken))
 
-	case RPC.UserService.Delete:
+	case RPC.APITokenService.Delete:
 		var args = struct {
 			Id int `json:"id"`
 		}{}
@@ -3370,13 +9147,13 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 
 		resp.Set(s.Delete(ctx, args.Id))
 
-	case RPC.UserService.Validate:
+	case RPC.APITokenService.Validate:
 		var args = struct {
-			User User `json:"user"`
+			ApiToken APIToken `json:"apiToken"`
 		}{}
 
 		if zenrpc.IsArray(params) {
-			if params, err = zenrpc.ConvertToObject([]string{"user"}, params); err != nil {
+			if params, err = zenrpc.ConvertToObject([]string{"apiToken"}, params); err != nil {
 				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
 			}
 		}
@@ -3387,7 +9164,7 @@ func (s UserService) Invoke(ctx context.Context, method string, params json.RawM
 			}
 		}
 
-		resp.Set(s.Validate(ctx, args.User))
+		resp.Set(s.Validate(ctx, args.ApiToken))
 
 	default:
 		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
@@ -0,0 +1,154 @@
+// Package workflow describes editorial lifecycle of news and allowed transitions between its states.
+package workflow
+
+import (
+	"errors"
+)
+
+// State is a news editorial state.
+type State string
+
+const (
+	StateDraft     State = "draft"
+	StateReview    State = "review"
+	StateApproved  State = "approved"
+	StateScheduled State = "scheduled"
+	StatePublished State = "published"
+	StateArchived  State = "archived"
+)
+
+// Role is a user role in newsroom.
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleAuthor Role = "author"
+)
+
+var (
+	ErrUnknownState     = errors.New("unknown state")
+	ErrNotAllowed       = errors.New("transition is not allowed")
+	ErrForbidden        = errors.New("transition is forbidden for role")
+	ErrReasonRequired   = errors.New("reason is required")
+	editors             = []Role{RoleAdmin, RoleEditor}
+	everyone            = []Role{RoleAdmin, RoleEditor, RoleAuthor}
+	admins              = []Role{RoleAdmin}
+	states              = []State{StateDraft, StateReview, StateApproved, StateScheduled, StatePublished, StateArchived}
+	roles               = []Role{RoleAdmin, RoleEditor, RoleAuthor}
+	defaultNewsWorkflow = New(newsTransitions)
+	newsTransitions     = []Transition{
+		{From: StateDraft, To: StateReview, Roles: everyone},
+		{From: StateReview, To: StateDraft, Roles: editors, ReasonRequired: true},
+		{From: StateReview, To: StateApproved, Roles: editors},
+		{From: StateApproved, To: StateDraft, Roles: editors, ReasonRequired: true},
+		{From: StateApproved, To: StateScheduled, Roles: editors},
+		{From: StateApproved, To: StatePublished, Roles: editors},
+		{From: StateScheduled, To: StateApproved, Roles: editors},
+		{From: StateScheduled, To: StatePublished, Roles: editors},
+		{From: StatePublished, To: StateArchived, Roles: editors},
+		{From: StatePublished, To: StateDraft, Roles: admins, ReasonRequired: true},
+		{From: StateArchived, To: StateDraft, Roles: admins},
+	}
+)
+
+// Transition describes allowed move from one state to another.
+type Transition struct {
+	From           State
+	To             State
+	Roles          []Role // roles that could make transition
+	ReasonRequired bool   // transition requires comment, e.g. for rejection
+}
+
+// Allows checks that role could make transition.
+func (t Transition) Allows(role Role) bool {
+	for _, r := range t.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Workflow is a state machine with allowed transitions.
+type Workflow struct {
+	transitions []Transition
+}
+
+// New returns new Workflow with given transitions.
+func New(transitions []Transition) *Workflow {
+	return &Workflow{transitions: transitions}
+}
+
+// News returns editorial workflow of news.
+func News() *Workflow {
+	return defaultNewsWorkflow
+}
+
+// Check verifies that role could move item from one state to another with given reason.
+func (w Workflow) Check(from, to State, role Role, reason string) error {
+	if !IsValidState(from) || !IsValidState(to) {
+		return ErrUnknownState
+	}
+
+	t := w.find(from, to)
+	if t == nil {
+		return ErrNotAllowed
+	} else if !t.Allows(role) {
+		return ErrForbidden
+	} else if t.ReasonRequired && reason == "" {
+		return ErrReasonRequired
+	}
+
+	return nil
+}
+
+// Available returns transitions from state that are allowed for role.
+func (w Workflow) Available(from State, role Role) []Transition {
+	var r []Transition
+	for _, t := range w.transitions {
+		if t.From == from && t.Allows(role) {
+			r = append(r, t)
+		}
+	}
+	return r
+}
+
+func (w Workflow) find(from, to State) *Transition {
+	for i := range w.transitions {
+		if w.transitions[i].From == from && w.transitions[i].To == to {
+			return &w.transitions[i]
+		}
+	}
+	return nil
+}
+
+// States returns all states in lifecycle order.
+func States() []State {
+	return states
+}
+
+// IsValidState checks that state is known.
+func IsValidState(s State) bool {
+	for _, st := range states {
+		if st == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles returns all user roles.
+func Roles() []Role {
+	return roles
+}
+
+// IsValidRole checks that role is known.
+func IsValidRole(r Role) bool {
+	for _, rl := range roles {
+		if rl == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflow_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to State
+		role     Role
+		reason   string
+		want     error
+	}{
+		{name: "author sends to review", from: StateDraft, to: StateReview, role: RoleAuthor},
+		{name: "author could not approve", from: StateReview, to: StateApproved, role: RoleAuthor, want: ErrForbidden},
+		{name: "editor approves", from: StateReview, to: StateApproved, role: RoleEditor},
+		{name: "reject without reason", from: StateReview, to: StateDraft, role: RoleEditor, want: ErrReasonRequired},
+		{name: "reject with reason", from: StateReview, to: StateDraft, role: RoleEditor, reason: "typos"},
+		{name: "draft could not be published", from: StateDraft, to: StatePublished, role: RoleAdmin, want: ErrNotAllowed},
+		{name: "unpublish by editor", from: StatePublished, to: StateDraft, role: RoleEditor, reason: "legal", want: ErrForbidden},
+		{name: "unpublish by admin", from: StatePublished, to: StateDraft, role: RoleAdmin, reason: "legal"},
+		{name: "unknown state", from: StateDraft, to: State("deleted"), role: RoleAdmin, want: ErrUnknownState},
+		{name: "empty role", from: StateDraft, to: StateReview, want: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, News().Check(tt.from, tt.to, tt.role, tt.reason))
+		})
+	}
+}
+
+func TestWorkflow_Available(t *testing.T) {
+	states := func(list []Transition) (r []State) {
+		for _, t := range list {
+			r = append(r, t.To)
+		}
+		return r
+	}
+
+	assert.Equal(t, []State{StateReview}, states(News().Available(StateDraft, RoleAuthor)))
+	assert.Empty(t, News().Available(StateReview, RoleAuthor))
+	assert.Equal(t, []State{StateDraft, StateScheduled, StatePublished}, states(News().Available(StateApproved, RoleEditor)))
+	assert.Equal(t, []State{StateArchived, StateDraft}, states(News().Available(StatePublished, RoleAdmin)))
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, IsValidState(StateScheduled))
+	assert.False(t, IsValidState(""))
+	assert.True(t, IsValidRole(RoleEditor))
+	assert.False(t, IsValidRole("guest"))
+}